@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Drop index on deleted_at
+DROP INDEX IF EXISTS idx_reservations_deleted_at;
+
+-- Remove deleted_at column from reservations table
+ALTER TABLE reservations
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+-- Add deleted_at column to reservations table for soft deletion
+ALTER TABLE reservations
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Add comment to deleted_at column
+COMMENT ON COLUMN reservations.deleted_at IS 'Time the reservation was soft-deleted, NULL for active reservations';
+
+-- Create index on deleted_at for filtering out soft-deleted reservations
+CREATE INDEX IF NOT EXISTS idx_reservations_deleted_at ON reservations(deleted_at);
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+-- Drop reservation_status_history table and indexes
+DROP INDEX IF EXISTS idx_reservation_status_history_reservation_changed_at;
+DROP TABLE IF EXISTS reservation_status_history;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+-- Create reservation_status_history table to audit status changes
+CREATE TABLE IF NOT EXISTS reservation_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reservation_id UUID NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create composite index on reservation_id and changed_at for ordered history lookups
+CREATE INDEX IF NOT EXISTS idx_reservation_status_history_reservation_changed_at ON reservation_status_history(reservation_id, changed_at);
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Drop index on status
+DROP INDEX IF EXISTS idx_tables_status;
+
+-- Remove status column from tables table
+ALTER TABLE tables
+DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+-- Add status column to tables table to track tables out of service
+ALTER TABLE tables
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'maintenance', 'retired'));
+
+-- Add comment to status column
+COMMENT ON COLUMN tables.status IS 'Service status of the table: active, maintenance or retired';
+
+-- Create index on status for filtering available tables
+CREATE INDEX IF NOT EXISTS idx_tables_status ON tables(status);
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+-- Drop reservation_tables table and indexes
+DROP INDEX IF EXISTS idx_reservation_tables_table_number;
+DROP TABLE IF EXISTS reservation_tables;
@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- Create reservation_tables join table for reservations spanning several merged tables.
+-- Single-table reservations only use reservations.table_number
+CREATE TABLE IF NOT EXISTS reservation_tables (
+    reservation_id UUID NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    table_number VARCHAR(50) NOT NULL REFERENCES tables(number) ON DELETE RESTRICT,
+    PRIMARY KEY (reservation_id, table_number)
+);
+
+-- Create index on table_number for availability checks
+CREATE INDEX IF NOT EXISTS idx_reservation_tables_table_number ON reservation_tables(table_number);
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Remove the deleted user system account unless it still holds reservations or status history.
+-- Deleting it then would cascade to the reservations of deleted users, so the account is kept
+DELETE FROM users
+WHERE id = '00000000-0000-0000-0000-000000000001'
+  AND NOT EXISTS (SELECT 1 FROM reservations WHERE user_id = '00000000-0000-0000-0000-000000000001')
+  AND NOT EXISTS (SELECT 1 FROM reservation_status_history WHERE changed_by = '00000000-0000-0000-0000-000000000001');
@@ -0,0 +1,7 @@
+-- +migrate Up
+
+-- Create the system account that takes over reservations of deleted users.
+-- The empty password hash never matches, so the account cannot be logged into
+INSERT INTO users (id, email, password, name, role)
+VALUES ('00000000-0000-0000-0000-000000000001', 'deleted-user@system.invalid', '', 'Deleted user', 'user')
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,10 @@
+-- +migrate Down
+
+-- No-shows are kept as cancellations once the status is no longer supported
+UPDATE reservations SET status = 'cancelled' WHERE status = 'no_show';
+
+ALTER TABLE reservations
+DROP CONSTRAINT IF EXISTS reservations_status_check;
+
+ALTER TABLE reservations
+ADD CONSTRAINT reservations_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed'));
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Allow marking confirmed reservations whose guests never arrived as no-shows
+ALTER TABLE reservations
+DROP CONSTRAINT IF EXISTS reservations_status_check;
+
+ALTER TABLE reservations
+ADD CONSTRAINT reservations_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed', 'no_show'));
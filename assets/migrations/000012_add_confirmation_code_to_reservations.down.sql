@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Drop unique index on confirmation_code
+DROP INDEX IF EXISTS idx_reservations_confirmation_code;
+
+-- Remove confirmation_code column from reservations table
+ALTER TABLE reservations
+DROP COLUMN IF EXISTS confirmation_code;
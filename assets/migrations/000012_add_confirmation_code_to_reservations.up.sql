@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- Add confirmation_code column letting guests look up their reservation without an account
+ALTER TABLE reservations
+ADD COLUMN IF NOT EXISTS confirmation_code VARCHAR(6);
+
+-- Add comment to confirmation_code column
+COMMENT ON COLUMN reservations.confirmation_code IS 'Short code given to the guest for self-service lookup, NULL for reservations created before it was introduced';
+
+-- Confirmation codes identify a single reservation
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_confirmation_code ON reservations(confirmation_code)
+WHERE confirmation_code IS NOT NULL;
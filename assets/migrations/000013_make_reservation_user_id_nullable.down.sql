@@ -0,0 +1,7 @@
+-- +migrate Down
+
+-- Guest reservations are handed to the deleted user system account, which has no owner to act on them
+UPDATE reservations SET user_id = '00000000-0000-0000-0000-000000000001' WHERE user_id IS NULL;
+
+ALTER TABLE reservations
+ALTER COLUMN user_id SET NOT NULL;
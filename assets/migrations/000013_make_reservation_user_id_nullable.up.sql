@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Allow walk-in guest reservations created by staff without a linked account
+ALTER TABLE reservations
+ALTER COLUMN user_id DROP NOT NULL;
+
+-- Add comment to user_id column
+COMMENT ON COLUMN reservations.user_id IS 'Account that owns the reservation, NULL for guest reservations created by staff';
@@ -0,0 +1,41 @@
+-- +migrate Down
+
+-- Drop indexes on restaurant_id
+DROP INDEX IF EXISTS idx_reservations_restaurant_id;
+DROP INDEX IF EXISTS idx_tables_restaurant_id;
+
+-- Drop restaurant-scoped foreign keys
+ALTER TABLE reservation_tables
+DROP CONSTRAINT IF EXISTS fk_reservation_tables_table_number;
+
+ALTER TABLE reservations
+DROP CONSTRAINT IF EXISTS fk_reservations_table_number;
+
+-- Restore global uniqueness of table numbers. Fails while two restaurants share a table number
+ALTER TABLE tables
+DROP CONSTRAINT IF EXISTS uq_tables_restaurant_number;
+
+ALTER TABLE tables
+ADD CONSTRAINT tables_number_key UNIQUE (number);
+
+-- Restore foreign keys on table numbers
+ALTER TABLE reservations
+ADD CONSTRAINT fk_reservations_table_number
+FOREIGN KEY (table_number) REFERENCES tables(number) ON DELETE RESTRICT;
+
+ALTER TABLE reservation_tables
+ADD CONSTRAINT reservation_tables_table_number_fkey
+FOREIGN KEY (table_number) REFERENCES tables(number) ON DELETE RESTRICT;
+
+-- Remove restaurant_id columns
+ALTER TABLE reservation_tables
+DROP COLUMN IF EXISTS restaurant_id;
+
+ALTER TABLE reservations
+DROP COLUMN IF EXISTS restaurant_id;
+
+ALTER TABLE tables
+DROP COLUMN IF EXISTS restaurant_id;
+
+-- Drop restaurants table
+DROP TABLE IF EXISTS restaurants;
@@ -0,0 +1,67 @@
+-- +migrate Up
+
+-- Create restaurants table for deployments serving several venues
+CREATE TABLE IF NOT EXISTS restaurants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Existing tables and reservations belong to the default restaurant
+INSERT INTO restaurants (id, slug, name)
+VALUES ('00000000-0000-0000-0000-000000000002', 'default', 'Default restaurant')
+ON CONFLICT (id) DO NOTHING;
+
+-- Add restaurant_id column to tables table
+ALTER TABLE tables
+ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000002'
+REFERENCES restaurants(id) ON DELETE RESTRICT;
+
+-- Add restaurant_id column to reservations table
+ALTER TABLE reservations
+ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000002'
+REFERENCES restaurants(id) ON DELETE RESTRICT;
+
+-- Drop foreign keys on table numbers, they rely on globally unique table numbers
+ALTER TABLE reservations
+DROP CONSTRAINT IF EXISTS fk_reservations_table_number;
+
+ALTER TABLE reservation_tables
+DROP CONSTRAINT IF EXISTS reservation_tables_table_number_fkey;
+
+-- Make table numbers unique within a restaurant, so every restaurant can have its own table 1
+ALTER TABLE tables
+DROP CONSTRAINT IF EXISTS tables_number_key;
+
+ALTER TABLE tables
+ADD CONSTRAINT uq_tables_restaurant_number UNIQUE (restaurant_id, number);
+
+-- Add restaurant_id column to reservation_tables, filled from the reservation
+ALTER TABLE reservation_tables
+ADD COLUMN IF NOT EXISTS restaurant_id UUID;
+
+UPDATE reservation_tables rt
+SET restaurant_id = r.restaurant_id
+FROM reservations r
+WHERE r.id = rt.reservation_id;
+
+ALTER TABLE reservation_tables
+ALTER COLUMN restaurant_id SET NOT NULL;
+
+-- Add foreign keys from reservations and reservation_tables to the table of the same restaurant
+ALTER TABLE reservations
+ADD CONSTRAINT fk_reservations_table_number
+FOREIGN KEY (restaurant_id, table_number) REFERENCES tables(restaurant_id, number) ON DELETE RESTRICT;
+
+ALTER TABLE reservation_tables
+ADD CONSTRAINT fk_reservation_tables_table_number
+FOREIGN KEY (restaurant_id, table_number) REFERENCES tables(restaurant_id, number) ON DELETE RESTRICT;
+
+-- Add comments to restaurant_id columns
+COMMENT ON COLUMN tables.restaurant_id IS 'Restaurant the table belongs to. Table numbers are unique within a restaurant';
+COMMENT ON COLUMN reservations.restaurant_id IS 'Restaurant the reservation was made at';
+
+-- Create indexes on restaurant_id for scoped queries
+CREATE INDEX IF NOT EXISTS idx_tables_restaurant_id ON tables(restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_restaurant_id ON reservations(restaurant_id);
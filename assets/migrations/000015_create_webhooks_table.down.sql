@@ -0,0 +1,4 @@
+-- +migrate Down
+
+-- Drop webhooks table
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,13 @@
+-- +migrate Up
+
+-- Create webhooks table for integrators notified about reservation events
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add comments to webhooks table
+COMMENT ON TABLE webhooks IS 'Endpoints receiving signed reservation lifecycle events';
+COMMENT ON COLUMN webhooks.secret IS 'Key of the HMAC-SHA256 signature sent with every delivery';
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+-- Remove staff_notes column from reservations table
+ALTER TABLE reservations
+DROP COLUMN IF EXISTS staff_notes;
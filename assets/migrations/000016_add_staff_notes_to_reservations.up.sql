@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Add staff_notes column for internal notes hidden from guests
+ALTER TABLE reservations
+ADD COLUMN IF NOT EXISTS staff_notes TEXT;
+
+-- Add comment to staff_notes column
+COMMENT ON COLUMN reservations.staff_notes IS 'Internal notes visible to staff only, unlike the guest-facing special_requests';
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Drop index on recurrence_group_id
+DROP INDEX IF EXISTS idx_reservations_recurrence_group_id;
+
+-- Remove recurrence_group_id column from reservations table
+ALTER TABLE reservations
+DROP COLUMN IF EXISTS recurrence_group_id;
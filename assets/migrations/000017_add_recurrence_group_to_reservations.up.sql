@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- Add recurrence_group_id column linking the reservations created together by a recurring booking
+ALTER TABLE reservations
+ADD COLUMN IF NOT EXISTS recurrence_group_id UUID;
+
+-- Add comment to recurrence_group_id column
+COMMENT ON COLUMN reservations.recurrence_group_id IS 'Shared by all occurrences of a recurring booking, NULL for one-off reservations';
+
+-- Create index for cancelling a recurring booking as a whole
+CREATE INDEX IF NOT EXISTS idx_reservations_recurrence_group_id ON reservations(recurrence_group_id)
+WHERE recurrence_group_id IS NOT NULL;
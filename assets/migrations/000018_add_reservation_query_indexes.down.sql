@@ -0,0 +1,10 @@
+-- +migrate Down
+
+-- Drop query indexes
+DROP INDEX IF EXISTS idx_reservations_user_date_time;
+DROP INDEX IF EXISTS idx_reservations_date_covering;
+DROP INDEX IF EXISTS idx_reservations_active_table_slot;
+
+-- Restore the single-column index on table_number replaced by the composite one
+CREATE INDEX IF NOT EXISTS idx_reservations_table_number ON reservations(table_number);
+DROP INDEX IF EXISTS idx_reservations_table_date_time;
@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- Indexes on user_id, status and date already exist since 000003_create_reservations_table,
+-- as does (date, time, table_number), which serves lookups of the active parties in a slot
+
+-- Create composite index on table_number, date and time for per-table schedules and availability checks.
+-- It leads with table_number, so it replaces the single-column index on table_number
+CREATE INDEX IF NOT EXISTS idx_reservations_table_date_time ON reservations(table_number, date, time);
+DROP INDEX IF EXISTS idx_reservations_table_number;
+
+-- Create partial index on the slots held by active reservations. Availability checks only look at
+-- pending and confirmed reservations that were not deleted, which become a small part of the table
+-- once history builds up. The predicate matches the one of the availability query, so the planner
+-- can use the index for it
+CREATE INDEX IF NOT EXISTS idx_reservations_active_table_slot ON reservations(table_number, date, time)
+WHERE deleted_at IS NULL AND status IN ('pending', 'confirmed');
+
+-- Create covering index for reports grouping reservations by date. It holds every column the
+-- report aggregates read, so they can be answered by index-only scans
+CREATE INDEX IF NOT EXISTS idx_reservations_date_covering ON reservations(date)
+INCLUDE (time, status, guests, table_number, restaurant_id)
+WHERE deleted_at IS NULL;
+
+-- Create index for listing a user's reservations newest first
+CREATE INDEX IF NOT EXISTS idx_reservations_user_date_time ON reservations(user_id, date DESC, time DESC)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- +migrate Down
+
+-- Drop blocked_dates table
+DROP TABLE IF EXISTS blocked_dates;
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+-- Create blocked_dates table for holidays and private events closing the restaurant to bookings
+CREATE TABLE IF NOT EXISTS blocked_dates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    restaurant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000002' REFERENCES restaurants(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_blocked_dates_range CHECK (end_date >= start_date)
+);
+
+-- Add comments to blocked_dates table
+COMMENT ON TABLE blocked_dates IS 'Date ranges during which no reservations are accepted';
+COMMENT ON COLUMN blocked_dates.end_date IS 'Last blocked date, equal to start_date when a single date is blocked';
+COMMENT ON COLUMN blocked_dates.reason IS 'Shown to guests trying to book a blocked date';
+
+-- Create index for finding the ranges covering a date
+CREATE INDEX IF NOT EXISTS idx_blocked_dates_restaurant_range ON blocked_dates(restaurant_id, start_date, end_date);
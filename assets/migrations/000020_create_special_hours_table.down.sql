@@ -0,0 +1,7 @@
+-- +migrate Down
+
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_special_hours_updated_at ON special_hours;
+
+-- Drop special_hours table
+DROP TABLE IF EXISTS special_hours;
@@ -0,0 +1,27 @@
+-- +migrate Up
+
+-- Create special_hours table for dates opening at non-standard hours
+CREATE TABLE IF NOT EXISTS special_hours (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    date DATE NOT NULL,
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    restaurant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000002' REFERENCES restaurants(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_special_hours_range CHECK (close_time > open_time)
+);
+
+-- Add comments to special_hours table
+COMMENT ON TABLE special_hours IS 'Opening hours of single dates overriding the weekly business hours';
+COMMENT ON COLUMN special_hours.note IS 'Optional explanation, e.g. New Year''s Eve';
+
+-- Create unique index so that a date has at most one override per restaurant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_special_hours_restaurant_date ON special_hours(restaurant_id, date);
+
+-- Create trigger to automatically update updated_at for special_hours table
+CREATE TRIGGER update_special_hours_updated_at
+BEFORE UPDATE ON special_hours
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
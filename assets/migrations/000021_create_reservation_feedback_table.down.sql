@@ -0,0 +1,4 @@
+-- +migrate Down
+
+-- Drop reservation_feedback table
+DROP TABLE IF EXISTS reservation_feedback;
@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Create reservation_feedback table for guest ratings of completed reservations
+CREATE TABLE IF NOT EXISTS reservation_feedback (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reservation_id UUID NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_reservation_feedback_rating CHECK (rating BETWEEN 1 AND 5)
+);
+
+-- Add comments to reservation_feedback table
+COMMENT ON TABLE reservation_feedback IS 'Ratings left by guests once their reservation is completed';
+COMMENT ON COLUMN reservation_feedback.rating IS 'Rating from 1 (worst) to 5 (best)';
+
+-- Create unique index so that a reservation has at most one feedback
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reservation_feedback_reservation_id ON reservation_feedback(reservation_id);
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Drop index on restaurant_id
+DROP INDEX IF EXISTS idx_webhooks_restaurant_id;
+
+-- Remove restaurant_id column
+ALTER TABLE webhooks
+DROP COLUMN IF EXISTS restaurant_id;
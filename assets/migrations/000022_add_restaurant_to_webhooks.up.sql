@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- Add restaurant_id column to webhooks table, so integrators only receive the events of their restaurant
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000002'
+REFERENCES restaurants(id) ON DELETE RESTRICT;
+
+-- Add comment to restaurant_id column
+COMMENT ON COLUMN webhooks.restaurant_id IS 'Restaurant whose reservation events the webhook receives';
+
+-- Create index on restaurant_id for scoped queries
+CREATE INDEX IF NOT EXISTS idx_webhooks_restaurant_id ON webhooks(restaurant_id);
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"github.com/EduardMikhrin/university-booking-project/cmd/service/config"
 	"github.com/EduardMikhrin/university-booking-project/cmd/service/migrate"
 	"github.com/EduardMikhrin/university-booking-project/cmd/service/run"
+	"github.com/EduardMikhrin/university-booking-project/cmd/service/seed"
 	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +16,10 @@ func init() {
 }
 
 func registerServiceCommands(cmd *cobra.Command) {
+	cmd.AddCommand(config.Cmd)
 	cmd.AddCommand(migrate.Cmd)
 	cmd.AddCommand(run.Cmd)
+	cmd.AddCommand(seed.Cmd)
 }
 
 var Cmd = &cobra.Command{
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommands(Cmd)
+}
+
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Command for working with the service config",
+}
+
+func registerCommands(cmd *cobra.Command) {
+	cmd.AddCommand(validateCmd)
+}
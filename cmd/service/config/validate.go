@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Loads every config section and reports the ones that are missing or malformed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.ConfigFromFlags(cmd)
+		if err != nil {
+			return errors.Wrap(err, "failed to get config from flags")
+		}
+
+		// The loaders open the resources they describe, so the checks also catch
+		// unreachable databases. Listener addresses are only parsed, not bound, so
+		// the config of a running service can be validated while it holds the port
+		sections := []struct {
+			name string
+			load func()
+		}{
+			{name: "log", load: func() { cfg.Log() }},
+			{name: "db", load: func() { cfg.DB().RawDB().Close() }},
+			{name: "db_pool", load: func() { cfg.DBPool() }},
+			{name: "db_query", load: func() { cfg.DBQueryTimeout(); cfg.DBURL() }},
+			{name: "listeners", load: func() { cfg.ApiHttpAddr() }},
+			{name: "cache", load: func() { cfg.Cache() }},
+			{name: "notifier", load: func() { cfg.Notifier() }},
+			{name: "storage", load: func() { cfg.ObjectStore() }},
+			{name: "jwt", load: func() { cfg.JWT() }},
+			{name: "password_policy", load: func() { cfg.PasswordPolicy() }},
+			{name: "reservation_policy", load: func() { cfg.ReservationPolicy() }},
+			{name: "completer", load: func() { cfg.CompleterInterval() }},
+			{name: "metrics", load: func() { cfg.Metrics() }},
+			{name: "webhooks", load: func() { cfg.Webhooks() }},
+			{name: "tenancy", load: func() { cfg.Tenancy() }},
+		}
+
+		out := cmd.OutOrStdout()
+		failed := 0
+		for _, section := range sections {
+			if err := loadSection(section.load); err != nil {
+				failed++
+				fmt.Fprintf(out, "%-20s FAIL  %v\n", section.name, err)
+				continue
+			}
+			fmt.Fprintf(out, "%-20s ok\n", section.name)
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return errors.Errorf("%d of %d config sections are invalid", failed, len(sections))
+		}
+
+		return nil
+	},
+}
+
+// loadSection runs a config loader and turns the panic it raises on invalid config into an error
+func loadSection(load func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+				return
+			}
+			err = errors.Errorf("%v", r)
+		}
+	}()
+
+	load()
+	return nil
+}
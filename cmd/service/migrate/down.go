@@ -16,6 +16,6 @@ var downCmd = &cobra.Command{
 			return errors.Wrap(err, "failed to get config from flags")
 		}
 
-		return execute(cfg, migrate.Down)
+		return execute(cmd, cfg, migrate.Down)
 	},
 }
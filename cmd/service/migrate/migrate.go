@@ -1,6 +1,9 @@
 package migrate
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/EduardMikhrin/university-booking-project/assets"
 	"github.com/EduardMikhrin/university-booking-project/internal/config"
 	"github.com/pkg/errors"
@@ -8,7 +11,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const dryRunFlag = "dry-run"
+
 func init() {
+	for _, cmd := range []*cobra.Command{upCmd, downCmd} {
+		cmd.Flags().Bool(dryRunFlag, false, "Print the migrations that would be run without running them")
+	}
 	registerCommands(Cmd)
 }
 
@@ -20,13 +28,31 @@ var Cmd = &cobra.Command{
 func registerCommands(cmd *cobra.Command) {
 	cmd.AddCommand(upCmd)
 	cmd.AddCommand(downCmd)
+	cmd.AddCommand(statusCmd)
 }
 
-func execute(cfg config.Config, direction migrate.MigrationDirection) error {
-	migrationsFs := &migrate.EmbedFileSystemMigrationSource{
+func migrationSource() *migrate.EmbedFileSystemMigrationSource {
+	return &migrate.EmbedFileSystemMigrationSource{
 		FileSystem: assets.Migrations,
 		Root:       "migrations",
 	}
+}
+
+func execute(cmd *cobra.Command, cfg config.Config, direction migrate.MigrationDirection) error {
+	migrationsFs := migrationSource()
+
+	dryRun, _ := cmd.Flags().GetBool(dryRunFlag)
+	if dryRun {
+		// Planning compares the embedded migrations with the ones recorded in the database
+		// and runs none of them
+		planned, _, err := migrate.PlanMigration(cfg.DB().RawDB(), "postgres", migrationsFs, direction, 0)
+		if err != nil {
+			return errors.Wrap(err, "failed to plan migrations")
+		}
+
+		printPlan(cmd.OutOrStdout(), direction, planned)
+		return nil
+	}
 
 	applied, err := migrate.Exec(cfg.DB().RawDB(), "postgres", migrationsFs, direction)
 	if err != nil {
@@ -37,3 +63,21 @@ func execute(cfg config.Config, direction migrate.MigrationDirection) error {
 
 	return nil
 }
+
+// printPlan lists the migrations in the order they would be run
+func printPlan(out io.Writer, direction migrate.MigrationDirection, planned []*migrate.PlannedMigration) {
+	action := "applied"
+	if direction == migrate.Down {
+		action = "rolled back"
+	}
+
+	if len(planned) == 0 {
+		fmt.Fprintf(out, "no migrations would be %s\n", action)
+		return
+	}
+
+	fmt.Fprintf(out, "%d migrations would be %s:\n", len(planned), action)
+	for _, migration := range planned {
+		fmt.Fprintf(out, "  %s (%d statements)\n", migration.Id, len(migration.Queries))
+	}
+}
@@ -0,0 +1,66 @@
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
+	"github.com/pkg/errors"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Lists the migrations with their state and fails if any of them is pending",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.ConfigFromFlags(cmd)
+		if err != nil {
+			return errors.Wrap(err, "failed to get config from flags")
+		}
+
+		migrations, err := migrationSource().FindMigrations()
+		if err != nil {
+			return errors.Wrap(err, "failed to find migrations")
+		}
+
+		records, err := migrate.GetMigrationRecords(cfg.DB().RawDB(), "postgres")
+		if err != nil {
+			return errors.Wrap(err, "failed to get applied migrations")
+		}
+
+		pending := printStatus(cmd.OutOrStdout(), migrations, records)
+		if pending > 0 {
+			cmd.SilenceUsage = true
+			return errors.Errorf("%d of %d migrations are pending", pending, len(migrations))
+		}
+
+		return nil
+	},
+}
+
+// printStatus writes a table of the migrations with their state and returns how many are pending
+func printStatus(out io.Writer, migrations []*migrate.Migration, records []*migrate.MigrationRecord) int {
+	appliedAt := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		appliedAt[record.Id] = record.AppliedAt
+	}
+
+	pending := 0
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tSTATE\tAPPLIED AT")
+	for _, migration := range migrations {
+		at, ok := appliedAt[migration.Id]
+		if !ok {
+			pending++
+			fmt.Fprintf(w, "%s\tpending\t-\n", migration.Id)
+			continue
+		}
+		fmt.Fprintf(w, "%s\tapplied\t%s\n", migration.Id, at.UTC().Format(time.RFC3339))
+	}
+	_ = w.Flush()
+
+	return pending
+}
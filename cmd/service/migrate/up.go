@@ -16,6 +16,6 @@ var upCmd = &cobra.Command{
 			return errors.Wrap(err, "failed to get config from flags")
 		}
 
-		return execute(cfg, migrate.Up)
+		return execute(cmd, cfg, migrate.Up)
 	},
 }
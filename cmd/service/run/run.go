@@ -2,14 +2,18 @@ package run
 
 import (
 	"context"
+	"database/sql"
 	"os/signal"
 	"sync"
 	"syscall"
 
 	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
 	"github.com/EduardMikhrin/university-booking-project/internal/config"
+	"github.com/EduardMikhrin/university-booking-project/internal/data/instrumented"
 	"github.com/EduardMikhrin/university-booking-project/internal/data/postgres"
 	"github.com/EduardMikhrin/university-booking-project/internal/server"
+	"github.com/EduardMikhrin/university-booking-project/internal/webhook"
+	"github.com/EduardMikhrin/university-booking-project/internal/worker"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -42,16 +46,48 @@ var Cmd = &cobra.Command{
 func runService(ctx context.Context, cfg config.Config) error {
 	wg := new(sync.WaitGroup)
 	eg, ctx := errgroup.WithContext(ctx)
-	sqlxDB := sqlx.NewDb(cfg.DB().RawDB(), "postgres")
-	db := postgres.NewMaster(sqlxDB)
+	// The service pool carries a server side statement_timeout on top of the per-query context timeout.
+	// Migrations keep using cfg.DB(), so long schema changes are not aborted by it
+	dsn, err := postgres.WithStatementTimeout(cfg.DBURL(), cfg.DBStatementTimeout())
+	if err != nil {
+		return errors.Wrap(err, "failed to build database connection string")
+	}
+	rawDB, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return errors.Wrap(err, "failed to open database")
+	}
+	defer rawDB.Close()
+	cfg.DBPool().Apply(rawDB)
+	sqlxDB := sqlx.NewDb(rawDB, "postgres")
+	db := instrumented.NewMaster(postgres.NewMaster(sqlxDB, cfg.ReservationPolicy().Location), cfg.Metrics(), cfg.DBQueryTimeout())
+	webhooks := webhook.NewDispatcher(cfg.Log().WithField("worker", "webhook_dispatcher"), db.WebhookQ(), cfg.Webhooks())
+	invalidations := worker.NewInvalidationPublisher(cfg.Log().WithField("worker", "invalidation_publisher"), cfg.Cache())
 
 	wg.Add(1)
 	eg.Go(func() error {
-		server := server.NewServer(cfg.Log(), db, cfg.Cache(), cfg.ApiHttpListener(), cfg.JWT())
+		server := server.NewServer(cfg.Log(), db, cfg.Cache(), cfg.Notifier(), cfg.ObjectStore(), webhooks, invalidations, cfg.ApiHttpListener(), cfg.JWT(), cfg.PasswordPolicy(), cfg.ReservationPolicy(), cfg.Metrics(), cfg.Tenancy())
 		return server.Run(ctx)
 	})
 
-	err := eg.Wait()
+	eg.Go(func() error {
+		completer := worker.NewCompleter(cfg.Log().WithField("worker", "completer"), db, cfg.Cache(), cfg.CompleterInterval())
+		return completer.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		listener := worker.NewInvalidationListener(cfg.Log().WithField("worker", "invalidation_listener"), cfg.Cache())
+		return listener.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return webhooks.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return invalidations.Run(ctx)
+	})
+
+	err = eg.Wait()
 	wg.Wait()
 
 	return err
@@ -0,0 +1,119 @@
+package seed
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	adminRole = "admin"
+
+	emailFlag    = "email"
+	passwordFlag = "password"
+	nameFlag     = "name"
+	promoteFlag  = "promote"
+)
+
+func init() {
+	adminCmd.Flags().String(emailFlag, "", "Email of the admin user")
+	adminCmd.Flags().String(passwordFlag, "", "Password of the admin user")
+	adminCmd.Flags().String(nameFlag, "", "Name of the admin user")
+	adminCmd.Flags().Bool(promoteFlag, false, "Promote the user to admin if the email is already registered")
+	_ = adminCmd.MarkFlagRequired(emailFlag)
+}
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Creates an admin user or promotes an existing user to admin",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.ConfigFromFlags(cmd)
+		if err != nil {
+			return errors.Wrap(err, "failed to get config from flags")
+		}
+
+		email, _ := cmd.Flags().GetString(emailFlag)
+		password, _ := cmd.Flags().GetString(passwordFlag)
+		name, _ := cmd.Flags().GetString(nameFlag)
+		promote, _ := cmd.Flags().GetBool(promoteFlag)
+		email = strings.TrimSpace(email)
+		name = strings.TrimSpace(name)
+
+		ctx := context.Background()
+		db := newMaster(cfg)
+		log := cfg.Log().WithField("email", email)
+
+		existing, err := db.UserQ().GetByEmail(ctx, email)
+		if err != nil && !errors.Is(err, data.ErrNotFound) {
+			return errors.Wrap(err, "failed to check email existence")
+		}
+
+		if existing != nil {
+			if existing.Role == adminRole {
+				log.Info("user is already an admin")
+				return nil
+			}
+			if !promote {
+				cmd.SilenceUsage = true
+				return errors.Errorf("user with email %s already exists; rerun with --%s to make them an admin", email, promoteFlag)
+			}
+			if err := db.UserQ().UpdateRole(ctx, existing.ID, adminRole); err != nil {
+				return errors.Wrap(err, "failed to promote user")
+			}
+			log.WithField("user_id", existing.ID).Info("user promoted to admin")
+			return nil
+		}
+
+		if name == "" {
+			return errors.Errorf("--%s is required when creating a new admin", nameFlag)
+		}
+		if password == "" {
+			return errors.Errorf("--%s is required when creating a new admin", passwordFlag)
+		}
+		if violations := cfg.PasswordPolicy().Validate(password); len(violations) > 0 {
+			// Violations are listed by field, so the output does not change between runs
+			fields := make([]string, 0, len(violations))
+			for field := range violations {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			messages := make([]string, 0, len(violations))
+			for _, field := range fields {
+				messages = append(messages, violations[field].Translate(i18n.DefaultLanguage))
+			}
+			cmd.SilenceUsage = true
+			return errors.Errorf("password does not satisfy the password policy: %s", strings.Join(messages, "; "))
+		}
+
+		// Hashed the same way as passwords of registered users
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return errors.Wrap(err, "failed to hash password")
+		}
+
+		user := &types.User{
+			ID:        uuid.New(),
+			Email:     email,
+			Password:  string(hashedPassword),
+			Name:      name,
+			Role:      adminRole,
+			CreatedAt: time.Now(),
+		}
+		if err := db.UserQ().Create(ctx, user); err != nil {
+			return errors.Wrap(err, "failed to create admin user")
+		}
+
+		log.WithField("user_id", user.ID).Info("admin user created")
+		return nil
+	},
+}
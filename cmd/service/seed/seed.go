@@ -0,0 +1,28 @@
+package seed
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/config"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/data/postgres"
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerCommands(Cmd)
+}
+
+var Cmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Command for seeding initial data",
+}
+
+func registerCommands(cmd *cobra.Command) {
+	cmd.AddCommand(adminCmd)
+	cmd.AddCommand(tablesCmd)
+}
+
+// newMaster opens the database described by the config, in the configured restaurant timezone
+func newMaster(cfg config.Config) data.MasterQ {
+	return postgres.NewMaster(sqlx.NewDb(cfg.DB().RawDB(), "postgres"), cfg.ReservationPolicy().Location)
+}
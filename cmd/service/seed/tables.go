@@ -0,0 +1,137 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/cmd/utils"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const fileFlag = "file"
+
+// tableLocations lists the locations accepted by the tables table
+var tableLocations = []string{"main", "terrace", "private"}
+
+// tableSeed is a single entry of the tables seed file
+type tableSeed struct {
+	Number   string `json:"number"`
+	Capacity int    `json:"capacity"`
+	Location string `json:"location"`
+}
+
+func init() {
+	tablesCmd.Flags().String(fileFlag, "", "Path to a JSON array of {number, capacity, location} objects")
+	_ = tablesCmd.MarkFlagRequired(fileFlag)
+}
+
+var tablesCmd = &cobra.Command{
+	Use:   "tables",
+	Short: "Creates tables from a JSON file, skipping numbers that already exist",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.ConfigFromFlags(cmd)
+		if err != nil {
+			return errors.Wrap(err, "failed to get config from flags")
+		}
+
+		path, _ := cmd.Flags().GetString(fileFlag)
+		seeds, err := readTableSeeds(path)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		ctx := context.Background()
+		db := newMaster(cfg)
+		log := cfg.Log()
+
+		created, skipped := 0, 0
+		for _, seed := range seeds {
+			entryLog := log.WithField("number", seed.Number)
+
+			_, err := db.TableQ().GetByNumber(ctx, seed.Number)
+			if err == nil {
+				entryLog.Warn("table already exists, skipping")
+				skipped++
+				continue
+			}
+			if !errors.Is(err, data.ErrNotFound) {
+				return errors.Wrapf(err, "failed to check table %s", seed.Number)
+			}
+
+			table := &types.Table{
+				Number:      seed.Number,
+				Capacity:    seed.Capacity,
+				Location:    seed.Location,
+				IsAvailable: true,
+			}
+			if err := db.TableQ().Create(ctx, table); err != nil {
+				return errors.Wrapf(err, "failed to create table %s", seed.Number)
+			}
+			entryLog.WithField("table_id", table.ID).Info("table created")
+			created++
+		}
+
+		if created > 0 {
+			if err := cfg.Cache().TableCache().InvalidateTableCache(ctx); err != nil {
+				log.WithError(err).Warn("failed to invalidate table cache")
+			}
+		}
+
+		log.WithFields(logan.F{
+			"created": created,
+			"skipped": skipped,
+		}).Info("tables seeded")
+		return nil
+	},
+}
+
+// readTableSeeds reads and validates the tables seed file. Every invalid entry is reported at once
+func readTableSeeds(path string) ([]tableSeed, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read tables file")
+	}
+
+	var seeds []tableSeed
+	if err := json.Unmarshal(raw, &seeds); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tables file")
+	}
+
+	var problems []string
+	seen := make(map[string]bool, len(seeds))
+	for i := range seeds {
+		seed := &seeds[i]
+		seed.Number = strings.TrimSpace(seed.Number)
+		seed.Location = strings.TrimSpace(seed.Location)
+
+		switch {
+		case seed.Number == "":
+			problems = append(problems, fmt.Sprintf("entry %d: number is required", i))
+		case seen[seed.Number]:
+			problems = append(problems, fmt.Sprintf("entry %d: duplicate table number %s", i, seed.Number))
+		}
+		seen[seed.Number] = true
+
+		if seed.Capacity <= 0 {
+			problems = append(problems, fmt.Sprintf("entry %d: capacity must be greater than 0", i))
+		}
+		if !slices.Contains(tableLocations, seed.Location) {
+			problems = append(problems, fmt.Sprintf("entry %d: location must be one of %s", i, strings.Join(tableLocations, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.Errorf("invalid tables file:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return seeds, nil
+}
@@ -1,5 +1,7 @@
 package cache
 
+import "context"
+
 // CacheQ defines methods for cache-related operations
 type CacheQ interface {
 	// TokenCache methods for JWT token management
@@ -16,4 +18,12 @@ type CacheQ interface {
 
 	// ReportCache methods for report/statistics caching
 	ReportCache() ReportCacheQ
+
+	// PublishInvalidation broadcasts a batch of invalidation events to all subscribed instances
+	// in a single message
+	PublishInvalidation(ctx context.Context, events []InvalidationEvent) error
+
+	// SubscribeInvalidations calls handle for every broadcast invalidation event,
+	// including the instance's own, until the context is cancelled
+	SubscribeInvalidations(ctx context.Context, handle func(InvalidationEvent)) error
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/EduardMikhrin/university-booking-project/internal/cache"
 	rdb "github.com/EduardMikhrin/university-booking-project/internal/cache/redis"
 	"github.com/pkg/errors"
@@ -10,7 +12,13 @@ import (
 	"gitlab.com/distributed_lab/kit/kv"
 )
 
-const cacheConfigKey = "cache"
+const (
+	cacheConfigKey = "cache"
+
+	defaultMaxRetries      = 2
+	defaultMinRetryBackoff = 50 * time.Millisecond
+	defaultMaxRetryBackoff = 500 * time.Millisecond
+)
 
 type Cacher interface {
 	Cache() cache.CacheQ
@@ -31,6 +39,13 @@ type config struct {
 	URL      string `fig:"url,required"`
 	Password string `fig:"password,required"`
 	DB       int    `fig:"db,required"`
+
+	// MaxRetries is how many times a command failing with a transient error is retried
+	MaxRetries int `fig:"max_retries"`
+	// MinRetryBackoff is the delay before the first retry, doubled for every further one
+	MinRetryBackoff time.Duration `fig:"min_retry_backoff"`
+	// MaxRetryBackoff caps the delay between retries
+	MaxRetryBackoff time.Duration `fig:"max_retry_backoff"`
 }
 
 func (c *cacher) Cache() cache.CacheQ {
@@ -40,17 +55,35 @@ func (c *cacher) Cache() cache.CacheQ {
 		Addr:     config.URL,
 		Password: config.Password,
 		DB:       config.DB,
+		// Retries are handled by the retry hook
+		MaxRetries: -1,
 	})
+	redisClient.AddHook(rdb.NewRetryHook(rdb.RetryPolicy{
+		MaxRetries: config.MaxRetries,
+		MinBackoff: config.MinRetryBackoff,
+		MaxBackoff: config.MaxRetryBackoff,
+	}))
 
 	return rdb.NewMaster(redisClient)
 }
 
 func (c *cacher) Config() *config {
 	return c.once.Do(func() interface{} {
-		var cfg config
+		cfg := config{
+			MaxRetries:      defaultMaxRetries,
+			MinRetryBackoff: defaultMinRetryBackoff,
+			MaxRetryBackoff: defaultMaxRetryBackoff,
+		}
 		if err := figure.Out(&cfg).From(kv.MustGetStringMap(c.getter, cacheConfigKey)).Please(); err != nil {
 			panic(errors.Wrap(err, "failed to figure out cache config"))
 		}
+		if cfg.MaxRetries < 0 {
+			panic(errors.New("cache max_retries must not be negative"))
+		}
+		if cfg.MinRetryBackoff <= 0 || cfg.MaxRetryBackoff < cfg.MinRetryBackoff {
+			panic(errors.New("cache retry backoff must be positive and max_retry_backoff must not be below min_retry_backoff"))
+		}
+
 		return &cfg
 	}).(*config)
 }
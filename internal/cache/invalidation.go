@@ -0,0 +1,15 @@
+package cache
+
+// Entity types named in invalidation events
+const (
+	EntityReservation = "reservation"
+	EntityTable       = "table"
+	EntityUser        = "user"
+)
+
+// InvalidationEvent announces to all service instances that cached data of an entity changed.
+// An empty ID stands for every entity of the type
+type InvalidationEvent struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id,omitempty"`
+}
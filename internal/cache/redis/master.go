@@ -1,10 +1,16 @@
 package redis
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/EduardMikhrin/university-booking-project/internal/cache"
 	"github.com/redis/go-redis/v9"
 )
 
+// invalidationChannel is the pub/sub channel invalidation events are broadcast on
+const invalidationChannel = "cache:invalidation"
+
 // Master implements the CacheQ interface using Redis
 type Master struct {
 	client *redis.Client
@@ -63,3 +69,44 @@ func (m *Master) ReportCache() cache.ReportCacheQ {
 	return m.reportCache
 }
 
+// PublishInvalidation broadcasts a batch of invalidation events to all subscribed instances
+// in a single message
+func (m *Master) PublishInvalidation(ctx context.Context, events []cache.InvalidationEvent) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return m.client.Publish(ctx, invalidationChannel, data).Err()
+}
+
+// SubscribeInvalidations calls handle for every broadcast invalidation event until the context is cancelled.
+// Malformed messages are skipped
+func (m *Master) SubscribeInvalidations(ctx context.Context, handle func(cache.InvalidationEvent)) error {
+	pubsub := m.client.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	// Wait for the subscription to be confirmed so connection errors are reported to the caller
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var events []cache.InvalidationEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &events); err != nil {
+				continue
+			}
+			for _, event := range events {
+				handle(event)
+			}
+		}
+	}
+}
@@ -8,13 +8,19 @@ import (
 
 	"github.com/EduardMikhrin/university-booking-project/internal/cache"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// The detailed monthly stats key carries a version that is bumped whenever the cached report
+// gains fields, so that reports cached by an older release are not served without them
 const (
-	monthlyStatsListKey      = "reports:monthly:list"
-	detailedMonthlyStatsPrefix = "reports:monthly:"
-	reportsCachePattern      = "reports:*"
+	monthlyStatsListKey        = "reports:monthly:list"
+	yearlyStatsKey             = "reports:yearly"
+	detailedMonthlyStatsPrefix = "reports:monthly:v2:"
+	userStatsKeyPrefix         = "reports:user:"
+	todaySnapshotKey           = "reports:today"
+	reportsCachePattern        = "reports:*"
 )
 
 // ReportCache implements cache.ReportCacheQ interface using Redis
@@ -33,12 +39,12 @@ func (c *ReportCache) SetMonthlyStatsList(ctx context.Context, stats []*types.Mo
 	if err != nil {
 		return err
 	}
-	return c.client.Set(ctx, monthlyStatsListKey, data, expiration).Err()
+	return c.client.Set(ctx, scopedKey(ctx, monthlyStatsListKey), data, expiration).Err()
 }
 
 // GetMonthlyStatsList retrieves cached monthly statistics list
 func (c *ReportCache) GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlyStats, error) {
-	val, err := c.client.Get(ctx, monthlyStatsListKey).Result()
+	val, err := c.client.Get(ctx, scopedKey(ctx, monthlyStatsListKey)).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, errors.New("monthly stats list not found in cache")
@@ -54,9 +60,36 @@ func (c *ReportCache) GetMonthlyStatsList(ctx context.Context) ([]*types.Monthly
 	return stats, nil
 }
 
+// SetYearlyStats caches yearly statistics
+func (c *ReportCache) SetYearlyStats(ctx context.Context, stats []*types.YearlyStats, expiration time.Duration) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, scopedKey(ctx, yearlyStatsKey), data, expiration).Err()
+}
+
+// GetYearlyStats retrieves cached yearly statistics
+func (c *ReportCache) GetYearlyStats(ctx context.Context) ([]*types.YearlyStats, error) {
+	val, err := c.client.Get(ctx, scopedKey(ctx, yearlyStatsKey)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("yearly stats not found in cache")
+		}
+		return nil, err
+	}
+
+	var stats []*types.YearlyStats
+	if err := json.Unmarshal([]byte(val), &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
 // SetDetailedMonthlyStats caches detailed monthly statistics
 func (c *ReportCache) SetDetailedMonthlyStats(ctx context.Context, month string, stats *types.DetailedMonthlyStats, expiration time.Duration) error {
-	key := detailedMonthlyStatsPrefix + month
+	key := scopedKey(ctx, detailedMonthlyStatsPrefix+month)
 	data, err := json.Marshal(stats)
 	if err != nil {
 		return err
@@ -66,7 +99,7 @@ func (c *ReportCache) SetDetailedMonthlyStats(ctx context.Context, month string,
 
 // GetDetailedMonthlyStats retrieves cached detailed monthly statistics
 func (c *ReportCache) GetDetailedMonthlyStats(ctx context.Context, month string) (*types.DetailedMonthlyStats, error) {
-	key := detailedMonthlyStatsPrefix + month
+	key := scopedKey(ctx, detailedMonthlyStatsPrefix+month)
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -83,16 +116,79 @@ func (c *ReportCache) GetDetailedMonthlyStats(ctx context.Context, month string)
 	return &stats, nil
 }
 
-// InvalidateMonthlyStats invalidates monthly statistics cache
-func (c *ReportCache) InvalidateMonthlyStats(ctx context.Context, month string) error {
-	key := detailedMonthlyStatsPrefix + month
+// SetUserStats caches reservation statistics for a specific user
+func (c *ReportCache) SetUserStats(ctx context.Context, userID uuid.UUID, stats *types.UserStats, expiration time.Duration) error {
+	key := scopedKey(ctx, userStatsKeyPrefix+userID.String())
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, key, data, expiration).Err()
+}
+
+// GetUserStats retrieves cached reservation statistics for a specific user
+func (c *ReportCache) GetUserStats(ctx context.Context, userID uuid.UUID) (*types.UserStats, error) {
+	key := scopedKey(ctx, userStatsKeyPrefix+userID.String())
+	val, err := c.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("user stats not found in cache")
+		}
+		return nil, err
+	}
+
+	var stats types.UserStats
+	if err := json.Unmarshal([]byte(val), &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// SetTodaySnapshot caches the live reservation counts for the current date
+func (c *ReportCache) SetTodaySnapshot(ctx context.Context, snapshot *types.TodaySnapshot, expiration time.Duration) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, scopedKey(ctx, todaySnapshotKey), data, expiration).Err()
+}
+
+// GetTodaySnapshot retrieves the cached live reservation counts for the current date
+func (c *ReportCache) GetTodaySnapshot(ctx context.Context) (*types.TodaySnapshot, error) {
+	val, err := c.client.Get(ctx, scopedKey(ctx, todaySnapshotKey)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("today snapshot not found in cache")
+		}
+		return nil, err
+	}
+
+	var snapshot types.TodaySnapshot
+	if err := json.Unmarshal([]byte(val), &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// InvalidateUserStats invalidates reservation statistics cache for a specific user
+func (c *ReportCache) InvalidateUserStats(ctx context.Context, userID uuid.UUID) error {
+	key := scopedKey(ctx, userStatsKeyPrefix+userID.String())
 	return c.client.Del(ctx, key).Err()
 }
 
+// InvalidateMonthlyStats invalidates monthly statistics cache together with the yearly rollup
+// and today's snapshot
+func (c *ReportCache) InvalidateMonthlyStats(ctx context.Context, month string) error {
+	key := scopedKey(ctx, detailedMonthlyStatsPrefix+month)
+	return c.client.Del(ctx, key, scopedKey(ctx, yearlyStatsKey), scopedKey(ctx, todaySnapshotKey)).Err()
+}
+
 // InvalidateAllStats invalidates all statistics cache
 func (c *ReportCache) InvalidateAllStats(ctx context.Context) error {
 	// Delete all report keys using pattern matching
-	iter := c.client.Scan(ctx, 0, reportsCachePattern, 0).Iterator()
+	iter := c.client.Scan(ctx, 0, scopedPattern(ctx, reportsCachePattern), 0).Iterator()
 	keys := []string{}
 	for iter.Next(ctx) {
 		keys = append(keys, iter.Val())
@@ -107,4 +203,3 @@ func (c *ReportCache) InvalidateAllStats(ctx context.Context) error {
 
 	return nil
 }
-
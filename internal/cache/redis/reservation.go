@@ -15,9 +15,16 @@ import (
 const (
 	reservationKeyPrefix         = "reservation:"
 	userReservationsKeyPrefix    = "reservations:user:"
+	userUpcomingCountKeyPrefix   = "reservations:upcoming:"
 	reservationListKeyPrefix     = "reservations:list:"
 	userReservationsCachePattern = "reservations:user:*"
 	reservationListCachePattern  = "reservations:list:*"
+	idempotencyKeyPrefix         = "reservations:idempotency:"
+	lookupAttemptsKeyPrefix      = "reservations:lookup:"
+	confirmationResendsKeyPrefix = "reservations:resend:"
+
+	// listInvalidationBatchSize bounds the keys fetched per SCAN call and removed per UNLINK call
+	listInvalidationBatchSize = 500
 )
 
 // ReservationCache implements cache.ReservationCacheQ interface using Redis
@@ -32,7 +39,7 @@ func NewReservationCache(client *redis.Client) cache.ReservationCacheQ {
 
 // SetReservation caches a single reservation
 func (c *ReservationCache) SetReservation(ctx context.Context, reservationID uuid.UUID, reservation *types.Reservation, expiration time.Duration) error {
-	key := reservationKeyPrefix + reservationID.String()
+	key := scopedKey(ctx, reservationKeyPrefix+reservationID.String())
 	data, err := json.Marshal(reservation)
 	if err != nil {
 		return err
@@ -42,7 +49,7 @@ func (c *ReservationCache) SetReservation(ctx context.Context, reservationID uui
 
 // GetReservation retrieves cached reservation
 func (c *ReservationCache) GetReservation(ctx context.Context, reservationID uuid.UUID) (*types.Reservation, error) {
-	key := reservationKeyPrefix + reservationID.String()
+	key := scopedKey(ctx, reservationKeyPrefix+reservationID.String())
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -61,7 +68,7 @@ func (c *ReservationCache) GetReservation(ctx context.Context, reservationID uui
 
 // SetUserReservations caches reservations for a specific user
 func (c *ReservationCache) SetUserReservations(ctx context.Context, userID uuid.UUID, reservations []*types.Reservation, expiration time.Duration) error {
-	key := userReservationsKeyPrefix + userID.String()
+	key := scopedKey(ctx, userReservationsKeyPrefix+userID.String())
 	data, err := json.Marshal(reservations)
 	if err != nil {
 		return err
@@ -71,7 +78,7 @@ func (c *ReservationCache) SetUserReservations(ctx context.Context, userID uuid.
 
 // GetUserReservations retrieves cached user reservations
 func (c *ReservationCache) GetUserReservations(ctx context.Context, userID uuid.UUID) ([]*types.Reservation, error) {
-	key := userReservationsKeyPrefix + userID.String()
+	key := scopedKey(ctx, userReservationsKeyPrefix+userID.String())
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -90,7 +97,7 @@ func (c *ReservationCache) GetUserReservations(ctx context.Context, userID uuid.
 
 // SetReservationList caches filtered reservation list
 func (c *ReservationCache) SetReservationList(ctx context.Context, key string, reservations []*types.Reservation, expiration time.Duration) error {
-	fullKey := reservationListKeyPrefix + key
+	fullKey := scopedKey(ctx, reservationListKeyPrefix+key)
 	data, err := json.Marshal(reservations)
 	if err != nil {
 		return err
@@ -100,7 +107,7 @@ func (c *ReservationCache) SetReservationList(ctx context.Context, key string, r
 
 // GetReservationList retrieves cached reservation list
 func (c *ReservationCache) GetReservationList(ctx context.Context, key string) ([]*types.Reservation, error) {
-	fullKey := reservationListKeyPrefix + key
+	fullKey := scopedKey(ctx, reservationListKeyPrefix+key)
 	val, err := c.client.Get(ctx, fullKey).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -119,12 +126,121 @@ func (c *ReservationCache) GetReservationList(ctx context.Context, key string) (
 
 // DeleteReservation removes reservation from cache
 func (c *ReservationCache) DeleteReservation(ctx context.Context, reservationID uuid.UUID) error {
-	key := reservationKeyPrefix + reservationID.String()
+	key := scopedKey(ctx, reservationKeyPrefix+reservationID.String())
 	return c.client.Del(ctx, key).Err()
 }
 
-// InvalidateUserReservations invalidates cache for user's reservations
+// SetUserUpcomingCount caches the number of upcoming reservations of a user
+func (c *ReservationCache) SetUserUpcomingCount(ctx context.Context, userID uuid.UUID, count int, expiration time.Duration) error {
+	key := scopedKey(ctx, userUpcomingCountKeyPrefix+userID.String())
+	return c.client.Set(ctx, key, count, expiration).Err()
+}
+
+// GetUserUpcomingCount retrieves the cached number of upcoming reservations of a user
+func (c *ReservationCache) GetUserUpcomingCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	key := scopedKey(ctx, userUpcomingCountKeyPrefix+userID.String())
+	count, err := c.client.Get(ctx, key).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, errors.New("user upcoming count not found in cache")
+		}
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// InvalidateUserReservations invalidates cache for user's reservations, including their upcoming count
 func (c *ReservationCache) InvalidateUserReservations(ctx context.Context, userID uuid.UUID) error {
-	key := userReservationsKeyPrefix + userID.String()
-	return c.client.Del(ctx, key).Err()
+	return c.client.Del(ctx,
+		scopedKey(ctx, userReservationsKeyPrefix+userID.String()),
+		scopedKey(ctx, userUpcomingCountKeyPrefix+userID.String()),
+	).Err()
+}
+
+// InvalidateReservationLists invalidates all cached filtered reservation lists.
+// Keys are removed batch by batch while scanning, so memory use does not grow with the number of lists
+func (c *ReservationCache) InvalidateReservationLists(ctx context.Context) error {
+	iter := c.client.Scan(ctx, 0, scopedPattern(ctx, reservationListCachePattern), listInvalidationBatchSize).Iterator()
+	keys := make([]string, 0, listInvalidationBatchSize)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == listInvalidationBatchSize {
+			if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return c.client.Unlink(ctx, keys...).Err()
+	}
+
+	return nil
+}
+
+// SetIdempotencyKey maps a user's idempotency key to a reservation unless the key is already taken
+func (c *ReservationCache) SetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, reservationID uuid.UUID, expiration time.Duration) (bool, error) {
+	fullKey := idempotencyKeyPrefix + userID.String() + ":" + key
+	return c.client.SetNX(ctx, fullKey, reservationID.String(), expiration).Result()
+}
+
+// GetIdempotencyKey retrieves the reservation ID a user's idempotency key is mapped to
+func (c *ReservationCache) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (uuid.UUID, error) {
+	fullKey := idempotencyKeyPrefix + userID.String() + ":" + key
+	val, err := c.client.Get(ctx, fullKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return uuid.Nil, errors.New("idempotency key not found in cache")
+		}
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(val)
+}
+
+// DeleteIdempotencyKey removes a user's idempotency key
+func (c *ReservationCache) DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error {
+	fullKey := idempotencyKeyPrefix + userID.String() + ":" + key
+	return c.client.Del(ctx, fullKey).Err()
+}
+
+// IncrementLookupAttempts counts a reservation lookup made by a client within a fixed window
+func (c *ReservationCache) IncrementLookupAttempts(ctx context.Context, client string, window time.Duration) (int64, error) {
+	key := lookupAttemptsKeyPrefix + client
+	count, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// The window starts with the first lookup
+	if count == 1 {
+		if err := c.client.Expire(ctx, key, window).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// IncrementConfirmationResends counts a resent confirmation of a reservation within a fixed window
+func (c *ReservationCache) IncrementConfirmationResends(ctx context.Context, reservationID uuid.UUID, window time.Duration) (int64, error) {
+	key := confirmationResendsKeyPrefix + reservationID.String()
+	count, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// The window starts with the first resend
+	if count == 1 {
+		if err := c.client.Expire(ctx, key, window).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
 }
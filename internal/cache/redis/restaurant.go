@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+)
+
+// restaurantKeyPrefix namespaces the keys cached for a restaurant-scoped request
+const restaurantKeyPrefix = "restaurant:"
+
+// scopedKey namespaces key to the restaurant ctx is scoped to. Keys of unscoped requests are left as is
+func scopedKey(ctx context.Context, key string) string {
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		return restaurantKeyPrefix + restaurantID.String() + ":" + key
+	}
+	return key
+}
+
+// scopedPattern namespaces an invalidation pattern to the restaurant ctx is scoped to.
+// Unscoped invalidations, e.g. from background workers, match the keys of every restaurant
+func scopedPattern(ctx context.Context, pattern string) string {
+	if _, ok := tenant.RestaurantFromContext(ctx); ok {
+		return scopedKey(ctx, pattern)
+	}
+	return "*" + pattern
+}
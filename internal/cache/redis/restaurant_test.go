@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopedKey(t *testing.T) {
+	restaurantID := uuid.MustParse("6f1c2a4e-3b7d-4c8e-9a1f-2d3e4f5a6b7c")
+	scoped := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	assert.Equal(t, "tables:all", scopedKey(context.Background(), "tables:all"))
+	assert.Equal(t, "restaurant:6f1c2a4e-3b7d-4c8e-9a1f-2d3e4f5a6b7c:tables:all", scopedKey(scoped, "tables:all"))
+}
+
+func TestScopedPattern(t *testing.T) {
+	restaurantID := uuid.MustParse("6f1c2a4e-3b7d-4c8e-9a1f-2d3e4f5a6b7c")
+	scoped := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	// Unscoped invalidations also match the keys of every restaurant
+	assert.Equal(t, "*tables:*", scopedPattern(context.Background(), "tables:*"))
+	assert.Equal(t, "restaurant:6f1c2a4e-3b7d-4c8e-9a1f-2d3e4f5a6b7c:tables:*", scopedPattern(scoped, "tables:*"))
+}
@@ -0,0 +1,154 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RetryPolicy describes how transient Redis failures are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; zero disables retries
+	MaxRetries int
+	// MinBackoff is the delay before the first retry; it doubles with every further retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+// backoff returns the delay before the given retry, counting from zero
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.MinBackoff
+	for i := 0; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// withRetry runs fn and retries it with exponential backoff while it fails with a transient error.
+// It gives up early when ctx is done and returns the last error
+func withRetry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	err := fn()
+	for retry := 0; retry < policy.MaxRetries && isTransientError(err); retry++ {
+		timer := time.NewTimer(policy.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = fn()
+	}
+	return err
+}
+
+// isTransientError reports whether err is a connection-level or temporary server failure
+// that is worth retrying. Cache misses and cancelled contexts are not transient
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := err.Error()
+	for _, prefix := range []string{"LOADING ", "READONLY ", "TRYAGAIN ", "CLUSTERDOWN ", "ERR max number of clients reached"} {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotentCommands are the commands that leave the same result however often they are replayed.
+// Counters (INCR), conditional writes (SETNX) and PUBLISH are missing on purpose: a replay after
+// a lost reply would count twice, report an existing key or deliver a message twice
+var idempotentCommands = map[string]bool{
+	"get": true, "mget": true, "exists": true, "ttl": true, "pttl": true, "type": true, "ping": true,
+	"scan": true, "sscan": true, "smembers": true, "sismember": true, "scard": true,
+	"hget": true, "hmget": true, "hgetall": true,
+	"set": true, "del": true, "unlink": true, "expire": true, "pexpire": true,
+	"sadd": true, "srem": true, "hset": true, "hdel": true,
+	"multi": true, "exec": true,
+}
+
+// isIdempotent reports whether cmd can be replayed safely. SET is idempotent only
+// without NX, XX or GET, which make its result depend on the key's previous value
+func isIdempotent(cmd redis.Cmder) bool {
+	name := cmd.Name()
+	if !idempotentCommands[name] {
+		return false
+	}
+	if name == "set" {
+		for _, arg := range cmd.Args()[1:] {
+			if s, ok := arg.(string); ok {
+				switch strings.ToLower(s) {
+				case "nx", "xx", "get":
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// retryHook retries commands and pipelines that fail with a transient error. Only idempotent
+// commands are retried, since a command whose reply was lost may already have been applied;
+// a pipeline is retried only when every command in it is idempotent
+type retryHook struct {
+	policy RetryPolicy
+}
+
+// NewRetryHook creates a client hook applying the given retry policy
+func NewRetryHook(policy RetryPolicy) redis.Hook {
+	return retryHook{policy: policy}
+}
+
+func (h retryHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h retryHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !isIdempotent(cmd) {
+			return next(ctx, cmd)
+		}
+		return withRetry(ctx, h.policy, func() error {
+			cmd.SetErr(nil)
+			return next(ctx, cmd)
+		})
+	}
+}
+
+func (h retryHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if !isIdempotent(cmd) {
+				return next(ctx, cmds)
+			}
+		}
+		return withRetry(ctx, h.policy, func() error {
+			for _, cmd := range cmds {
+				cmd.SetErr(nil)
+			}
+			return next(ctx, cmds)
+		})
+	}
+}
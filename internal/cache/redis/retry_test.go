@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "cache miss", err: redis.Nil, want: false},
+		{name: "cancelled", err: context.Canceled, want: false},
+		{name: "connection closed", err: io.EOF, want: true},
+		{name: "server loading", err: errors.New("LOADING Redis is loading the dataset in memory"), want: true},
+		{name: "wrong type", err: errors.New("WRONGTYPE Operation against a key holding the wrong kind of value"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientError(tt.err))
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		cmd  redis.Cmder
+		want bool
+	}{
+		{name: "get", cmd: redis.NewStringCmd(ctx, "get", "key"), want: true},
+		{name: "set", cmd: redis.NewStatusCmd(ctx, "set", "key", "value", "ex", 60), want: true},
+		{name: "del", cmd: redis.NewIntCmd(ctx, "del", "key"), want: true},
+		{name: "set if not exists", cmd: redis.NewBoolCmd(ctx, "set", "key", "value", "ex", 60, "nx"), want: false},
+		{name: "setnx", cmd: redis.NewBoolCmd(ctx, "setnx", "key", "value"), want: false},
+		{name: "incr", cmd: redis.NewIntCmd(ctx, "incr", "key"), want: false},
+		{name: "publish", cmd: redis.NewIntCmd(ctx, "publish", "channel", "message"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isIdempotent(tt.cmd))
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, MinBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	assert.Equal(t, 10*time.Millisecond, policy.backoff(0))
+	assert.Equal(t, 20*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 40*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 50*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 50*time.Millisecond, policy.backoff(10))
+}
+
+func TestWithRetry(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("recovers from transient error", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), policy, func() error {
+			calls++
+			if calls < 2 {
+				return io.EOF
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), policy, func() error {
+			calls++
+			return io.EOF
+		})
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), policy, func() error {
+			calls++
+			return redis.Nil
+		})
+		assert.ErrorIs(t, err, redis.Nil)
+		assert.Equal(t, 1, calls)
+	})
+}
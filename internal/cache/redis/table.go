@@ -18,6 +18,7 @@ const (
 	tableNumberKeyPrefix      = "table:number:"
 	allTablesKey              = "tables:all"
 	availableTablesKeyPrefix  = "tables:available:"
+	capacitySummaryKey        = "tables:summary"
 	tableCachePattern         = "table:*"
 	tablesCachePattern        = "tables:*"
 )
@@ -34,7 +35,7 @@ func NewTableCache(client *redis.Client) cache.TableCacheQ {
 
 // SetTable caches a single table
 func (c *TableCache) SetTable(ctx context.Context, tableID uuid.UUID, table *types.Table, expiration time.Duration) error {
-	key := tableKeyPrefix + tableID.String()
+	key := scopedKey(ctx, tableKeyPrefix+tableID.String())
 	data, err := json.Marshal(table)
 	if err != nil {
 		return err
@@ -44,7 +45,7 @@ func (c *TableCache) SetTable(ctx context.Context, tableID uuid.UUID, table *typ
 
 // GetTable retrieves cached table data
 func (c *TableCache) GetTable(ctx context.Context, tableID uuid.UUID) (*types.Table, error) {
-	key := tableKeyPrefix + tableID.String()
+	key := scopedKey(ctx, tableKeyPrefix+tableID.String())
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -63,7 +64,7 @@ func (c *TableCache) GetTable(ctx context.Context, tableID uuid.UUID) (*types.Ta
 
 // SetTableByNumber caches table by table number
 func (c *TableCache) SetTableByNumber(ctx context.Context, number string, table *types.Table, expiration time.Duration) error {
-	key := tableNumberKeyPrefix + number
+	key := scopedKey(ctx, tableNumberKeyPrefix+number)
 	data, err := json.Marshal(table)
 	if err != nil {
 		return err
@@ -73,7 +74,7 @@ func (c *TableCache) SetTableByNumber(ctx context.Context, number string, table
 
 // GetTableByNumber retrieves cached table by number
 func (c *TableCache) GetTableByNumber(ctx context.Context, number string) (*types.Table, error) {
-	key := tableNumberKeyPrefix + number
+	key := scopedKey(ctx, tableNumberKeyPrefix+number)
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -96,12 +97,12 @@ func (c *TableCache) SetAllTables(ctx context.Context, tables []*types.Table, ex
 	if err != nil {
 		return err
 	}
-	return c.client.Set(ctx, allTablesKey, data, expiration).Err()
+	return c.client.Set(ctx, scopedKey(ctx, allTablesKey), data, expiration).Err()
 }
 
 // GetAllTables retrieves cached list of all tables
 func (c *TableCache) GetAllTables(ctx context.Context) ([]*types.Table, error) {
-	val, err := c.client.Get(ctx, allTablesKey).Result()
+	val, err := c.client.Get(ctx, scopedKey(ctx, allTablesKey)).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, errors.New("tables not found in cache")
@@ -119,7 +120,7 @@ func (c *TableCache) GetAllTables(ctx context.Context) ([]*types.Table, error) {
 
 // SetAvailableTables caches available tables for a specific date/time
 func (c *TableCache) SetAvailableTables(ctx context.Context, date string, time string, guests int, tables []*types.Table, expiration time.Duration) error {
-	key := fmt.Sprintf("%s%s:%s:%d", availableTablesKeyPrefix, date, time, guests)
+	key := scopedKey(ctx, fmt.Sprintf("%s%s:%s:%d", availableTablesKeyPrefix, date, time, guests))
 	data, err := json.Marshal(tables)
 	if err != nil {
 		return err
@@ -129,7 +130,7 @@ func (c *TableCache) SetAvailableTables(ctx context.Context, date string, time s
 
 // GetAvailableTables retrieves cached available tables
 func (c *TableCache) GetAvailableTables(ctx context.Context, date string, time string, guests int) ([]*types.Table, error) {
-	key := fmt.Sprintf("%s%s:%s:%d", availableTablesKeyPrefix, date, time, guests)
+	key := scopedKey(ctx, fmt.Sprintf("%s%s:%s:%d", availableTablesKeyPrefix, date, time, guests))
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -146,10 +147,62 @@ func (c *TableCache) GetAvailableTables(ctx context.Context, date string, time s
 	return tables, nil
 }
 
+// SetCapacitySummary caches the table capacity summary
+func (c *TableCache) SetCapacitySummary(ctx context.Context, summary *types.CapacitySummary, expiration time.Duration) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, scopedKey(ctx, capacitySummaryKey), data, expiration).Err()
+}
+
+// GetCapacitySummary retrieves the cached table capacity summary
+func (c *TableCache) GetCapacitySummary(ctx context.Context) (*types.CapacitySummary, error) {
+	val, err := c.client.Get(ctx, scopedKey(ctx, capacitySummaryKey)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("capacity summary not found in cache")
+		}
+		return nil, err
+	}
+
+	var summary types.CapacitySummary
+	if err := json.Unmarshal([]byte(val), &summary); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// InvalidateAvailableTables invalidates the cached available tables of a single date.
+// Keys are removed batch by batch while scanning, like the reservation lists
+func (c *TableCache) InvalidateAvailableTables(ctx context.Context, date string) error {
+	iter := c.client.Scan(ctx, 0, scopedPattern(ctx, availableTablesKeyPrefix+date+":*"), listInvalidationBatchSize).Iterator()
+	keys := make([]string, 0, listInvalidationBatchSize)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == listInvalidationBatchSize {
+			if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return c.client.Unlink(ctx, keys...).Err()
+	}
+
+	return nil
+}
+
 // InvalidateTableCache invalidates all table-related cache
 func (c *TableCache) InvalidateTableCache(ctx context.Context) error {
 	// Delete all table keys using pattern matching
-	iter := c.client.Scan(ctx, 0, tablesCachePattern, 0).Iterator()
+	iter := c.client.Scan(ctx, 0, scopedPattern(ctx, tablesCachePattern), 0).Iterator()
 	keys := []string{}
 	for iter.Next(ctx) {
 		keys = append(keys, iter.Val())
@@ -159,7 +212,7 @@ func (c *TableCache) InvalidateTableCache(ctx context.Context) error {
 	}
 
 	// Also add table: pattern
-	iter = c.client.Scan(ctx, 0, tableCachePattern, 0).Iterator()
+	iter = c.client.Scan(ctx, 0, scopedPattern(ctx, tableCachePattern), 0).Iterator()
 	for iter.Next(ctx) {
 		keys = append(keys, iter.Val())
 	}
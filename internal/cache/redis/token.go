@@ -14,6 +14,7 @@ import (
 const (
 	tokenKeyPrefix      = "token:"
 	tokenBlacklistPrefix = "token:blacklist:"
+	userTokensKeyPrefix  = "token:user:"
 )
 
 // TokenCache implements cache.TokenCacheQ interface using Redis
@@ -73,6 +74,46 @@ func (c *TokenCache) SetTokenBlacklist(ctx context.Context, token string, expira
 	return c.client.Set(ctx, key, "1", expiration).Err()
 }
 
+// AddUserToken tracks a token in the set of the user's tokens, which expires with the latest token
+func (c *TokenCache) AddUserToken(ctx context.Context, userID uuid.UUID, token string, expiration time.Duration) error {
+	key := userTokensKeyPrefix + userID.String()
+
+	pipe := c.client.TxPipeline()
+	pipe.SAdd(ctx, key, token)
+	pipe.Expire(ctx, key, expiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RemoveUserToken removes a token from the set of the user's tokens
+func (c *TokenCache) RemoveUserToken(ctx context.Context, userID uuid.UUID, token string) error {
+	key := userTokensKeyPrefix + userID.String()
+	return c.client.SRem(ctx, key, token).Err()
+}
+
+// ListUserTokens retrieves all tokens tracked for a user
+func (c *TokenCache) ListUserTokens(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	key := userTokensKeyPrefix + userID.String()
+	return c.client.SMembers(ctx, key).Result()
+}
+
+// ClearUserTokens removes all tokens tracked for a user together with the set itself
+func (c *TokenCache) ClearUserTokens(ctx context.Context, userID uuid.UUID) error {
+	userTokensKey := userTokensKeyPrefix + userID.String()
+	tokens, err := c.client.SMembers(ctx, userTokensKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(tokens)+1)
+	for _, token := range tokens {
+		keys = append(keys, tokenKeyPrefix+token)
+	}
+	keys = append(keys, userTokensKey)
+
+	return c.client.Del(ctx, keys...).Err()
+}
+
 // IsTokenBlacklisted checks if token is blacklisted
 func (c *TokenCache) IsTokenBlacklisted(ctx context.Context, token string) (bool, error) {
 	key := tokenBlacklistPrefix + token
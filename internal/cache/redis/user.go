@@ -53,6 +53,8 @@ func (c *UserCache) GetUser(ctx context.Context, userID uuid.UUID) (*types.User,
 		return nil, err
 	}
 
+	types.NormalizeUser(&user)
+
 	return &user, nil
 }
 
@@ -88,6 +90,8 @@ func (c *UserCache) GetUserByEmail(ctx context.Context, email string) (*types.Us
 		return nil, err
 	}
 
+	types.NormalizeUser(&user)
+
 	return &user, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
 )
 
 // ReportCacheQ defines methods for report/statistics caching
@@ -15,16 +16,37 @@ type ReportCacheQ interface {
 	// GetMonthlyStatsList retrieves cached monthly statistics list
 	GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlyStats, error)
 
+	// SetYearlyStats caches yearly statistics
+	SetYearlyStats(ctx context.Context, stats []*types.YearlyStats, expiration time.Duration) error
+
+	// GetYearlyStats retrieves cached yearly statistics
+	GetYearlyStats(ctx context.Context) ([]*types.YearlyStats, error)
+
 	// SetDetailedMonthlyStats caches detailed monthly statistics
 	SetDetailedMonthlyStats(ctx context.Context, month string, stats *types.DetailedMonthlyStats, expiration time.Duration) error
 
 	// GetDetailedMonthlyStats retrieves cached detailed monthly statistics
 	GetDetailedMonthlyStats(ctx context.Context, month string) (*types.DetailedMonthlyStats, error)
 
-	// InvalidateMonthlyStats invalidates monthly statistics cache
+	// SetUserStats caches reservation statistics for a specific user
+	SetUserStats(ctx context.Context, userID uuid.UUID, stats *types.UserStats, expiration time.Duration) error
+
+	// GetUserStats retrieves cached reservation statistics for a specific user
+	GetUserStats(ctx context.Context, userID uuid.UUID) (*types.UserStats, error)
+
+	// SetTodaySnapshot caches the live reservation counts for the current date
+	SetTodaySnapshot(ctx context.Context, snapshot *types.TodaySnapshot, expiration time.Duration) error
+
+	// GetTodaySnapshot retrieves the cached live reservation counts for the current date
+	GetTodaySnapshot(ctx context.Context) (*types.TodaySnapshot, error)
+
+	// InvalidateUserStats invalidates reservation statistics cache for a specific user
+	InvalidateUserStats(ctx context.Context, userID uuid.UUID) error
+
+	// InvalidateMonthlyStats invalidates monthly statistics cache together with the yearly rollup
+	// and today's snapshot
 	InvalidateMonthlyStats(ctx context.Context, month string) error
 
 	// InvalidateAllStats invalidates all statistics cache
 	InvalidateAllStats(ctx context.Context) error
 }
-
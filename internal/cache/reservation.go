@@ -31,7 +31,34 @@ type ReservationCacheQ interface {
 	// DeleteReservation removes reservation from cache
 	DeleteReservation(ctx context.Context, reservationID uuid.UUID) error
 
-	// InvalidateUserReservations invalidates cache for user's reservations
+	// SetUserUpcomingCount caches the number of upcoming reservations of a user
+	SetUserUpcomingCount(ctx context.Context, userID uuid.UUID, count int, expiration time.Duration) error
+
+	// GetUserUpcomingCount retrieves the cached number of upcoming reservations of a user
+	GetUserUpcomingCount(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// InvalidateUserReservations invalidates cache for user's reservations, including their upcoming count
 	InvalidateUserReservations(ctx context.Context, userID uuid.UUID) error
+
+	// InvalidateReservationLists invalidates all cached filtered reservation lists
+	InvalidateReservationLists(ctx context.Context) error
+
+	// SetIdempotencyKey maps a user's idempotency key to a reservation unless the key is already taken.
+	// It reports whether the mapping was stored
+	SetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, reservationID uuid.UUID, expiration time.Duration) (bool, error)
+
+	// GetIdempotencyKey retrieves the reservation ID a user's idempotency key is mapped to
+	GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (uuid.UUID, error)
+
+	// DeleteIdempotencyKey removes a user's idempotency key
+	DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error
+
+	// IncrementLookupAttempts counts a reservation lookup made by a client and returns the number
+	// of lookups it made within the current window
+	IncrementLookupAttempts(ctx context.Context, client string, window time.Duration) (int64, error)
+
+	// IncrementConfirmationResends counts a resent confirmation of a reservation and returns the number
+	// of confirmations resent within the current window
+	IncrementConfirmationResends(ctx context.Context, reservationID uuid.UUID, window time.Duration) (int64, error)
 }
 
@@ -34,6 +34,16 @@ type TableCacheQ interface {
 	// GetAvailableTables retrieves cached available tables
 	GetAvailableTables(ctx context.Context, date string, time string, guests int) ([]*types.Table, error)
 
+	// SetCapacitySummary caches the table capacity summary
+	SetCapacitySummary(ctx context.Context, summary *types.CapacitySummary, expiration time.Duration) error
+
+	// GetCapacitySummary retrieves the cached table capacity summary
+	GetCapacitySummary(ctx context.Context) (*types.CapacitySummary, error)
+
+	// InvalidateAvailableTables invalidates the cached available tables of a single date,
+	// leaving the other dates cached
+	InvalidateAvailableTables(ctx context.Context, date string) error
+
 	// InvalidateTableCache invalidates all table-related cache
 	InvalidateTableCache(ctx context.Context) error
 }
@@ -24,6 +24,18 @@ type TokenCacheQ interface {
 	// SetTokenBlacklist adds token to blacklist (for logout)
 	SetTokenBlacklist(ctx context.Context, token string, expiration time.Duration) error
 
+	// AddUserToken tracks a token among the active tokens of a user
+	AddUserToken(ctx context.Context, userID uuid.UUID, token string, expiration time.Duration) error
+
+	// RemoveUserToken stops tracking a token among the active tokens of a user
+	RemoveUserToken(ctx context.Context, userID uuid.UUID, token string) error
+
+	// ListUserTokens retrieves all active tokens of a user
+	ListUserTokens(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// ClearUserTokens removes all active tokens of a user from cache
+	ClearUserTokens(ctx context.Context, userID uuid.UUID) error
+
 	// IsTokenBlacklisted checks if token is blacklisted
 	IsTokenBlacklisted(ctx context.Context, token string) (bool, error)
 }
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type Completerer interface {
+	CompleterInterval() time.Duration
+}
+
+const (
+	completerKey = "completer"
+
+	defaultCompleterInterval = 5 * time.Minute
+)
+
+func NewCompleterer(getter kv.Getter) Completerer {
+	return &completer{getter: getter}
+}
+
+type completerConfig struct {
+	Interval time.Duration `fig:"interval"`
+}
+
+type completer struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// CompleterInterval returns how often past confirmed reservations are marked as completed
+func (c *completer) CompleterInterval() time.Duration {
+	return c.once.Do(func() interface{} {
+		var cfg completerConfig
+		err := figure.
+			Out(&cfg).
+			With(figure.BaseHooks, completerHooks).
+			From(kv.MustGetStringMap(c.getter, completerKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load completer config"))
+		}
+
+		if cfg.Interval <= 0 {
+			cfg.Interval = defaultCompleterInterval
+		}
+
+		return cfg.Interval
+	}).(time.Duration)
+}
+
+var completerHooks = figure.Hooks{
+	"time.Duration": func(value interface{}) (reflect.Value, error) {
+		switch v := value.(type) {
+		case string:
+			duration, err := time.ParseDuration(v)
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "failed to parse duration: %s", v)
+			}
+			return reflect.ValueOf(duration), nil
+		default:
+			return reflect.Value{}, errors.Errorf("unsupported conversion from %T to time.Duration", value)
+		}
+	},
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	cacher "github.com/EduardMikhrin/university-booking-project/internal/cache/config"
+	notifierer "github.com/EduardMikhrin/university-booking-project/internal/notifier/config"
+	storager "github.com/EduardMikhrin/university-booking-project/internal/storage/config"
 	"gitlab.com/distributed_lab/kit/comfig"
 	"gitlab.com/distributed_lab/kit/kv"
 	"gitlab.com/distributed_lab/kit/pgdb"
@@ -10,9 +12,19 @@ import (
 type Config interface {
 	comfig.Logger
 	pgdb.Databaser
+	DBPooler
+	DBQueryer
 	Listenerer
 	cacher.Cacher
+	notifierer.Notifierer
+	storager.Storager
 	JWTer
+	PasswordPolicyer
+	ReservationPolicyer
+	Completerer
+	Metricser
+	Tenancyer
+	Webhooker
 }
 
 type config struct {
@@ -20,18 +32,38 @@ type config struct {
 
 	comfig.Logger
 	pgdb.Databaser
+	DBPooler
+	DBQueryer
 	cacher.Cacher
+	notifierer.Notifierer
+	storager.Storager
 	Listenerer
 	JWTer
+	PasswordPolicyer
+	ReservationPolicyer
+	Completerer
+	Metricser
+	Tenancyer
+	Webhooker
 }
 
 func New(getter kv.Getter) Config {
 	return &config{
-		getter:     getter,
-		Logger:     comfig.NewLogger(getter, comfig.LoggerOpts{}),
-		Databaser:  pgdb.NewDatabaser(getter),
-		Cacher:     cacher.NewCacher(getter),
-		Listenerer: NewListenerer(getter),
-		JWTer:      NewJWTer(getter),
+		getter:              getter,
+		Logger:              comfig.NewLogger(getter, comfig.LoggerOpts{}),
+		Databaser:           pgdb.NewDatabaser(getter),
+		DBPooler:            NewDBPooler(getter),
+		DBQueryer:           NewDBQueryer(getter),
+		Cacher:              cacher.NewCacher(getter),
+		Notifierer:          notifierer.NewNotifierer(getter),
+		Storager:            storager.NewStorager(getter),
+		Listenerer:          NewListenerer(getter),
+		JWTer:               NewJWTer(getter),
+		PasswordPolicyer:    NewPasswordPolicyer(getter),
+		ReservationPolicyer: NewReservationPolicyer(getter),
+		Completerer:         NewCompleterer(getter),
+		Metricser:           NewMetricser(getter),
+		Tenancyer:           NewTenancyer(getter),
+		Webhooker:           NewWebhooker(getter),
 	}
 }
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type DBPooler interface {
+	DBPool() DBPool
+}
+
+const (
+	dbPoolKey = "db_pool"
+
+	defaultMaxOpenConns    = 25
+	defaultMaxIdleConns    = 10
+	defaultConnMaxLifetime = 30 * time.Minute
+	defaultConnMaxIdleTime = 5 * time.Minute
+)
+
+func NewDBPooler(getter kv.Getter) DBPooler {
+	return &dbPool{getter: getter}
+}
+
+// DBPool holds the connection pool settings of the database handle
+type DBPool struct {
+	// MaxOpenConns caps the number of open connections, in use or idle
+	MaxOpenConns int `fig:"max_open_conns"`
+	// MaxIdleConns caps the number of idle connections kept for reuse. It never exceeds MaxOpenConns
+	MaxIdleConns int `fig:"max_idle_conns"`
+	// ConnMaxLifetime closes connections once they have been open this long
+	ConnMaxLifetime time.Duration `fig:"conn_max_lifetime"`
+	// ConnMaxIdleTime closes connections that have been idle this long
+	ConnMaxIdleTime time.Duration `fig:"conn_max_idle_time"`
+}
+
+// Apply configures the connection pool of db
+func (p DBPool) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+type dbPool struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// DBPool returns the database connection pool settings. Omitted or non-positive values fall back to defaults.
+// The pool is only configured here: the pool limits pgdb reads from the db section are rejected
+func (d *dbPool) DBPool() DBPool {
+	return d.once.Do(func() interface{} {
+		var cfg DBPool
+		err := figure.
+			Out(&cfg).
+			With(figure.BaseHooks, dbPoolHooks).
+			From(kv.MustGetStringMap(d.getter, dbPoolKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load db pool config"))
+		}
+
+		db := kv.MustGetStringMap(d.getter, dbKey)
+		for _, key := range []string{"max_open_connection", "max_idle_connections"} {
+			if _, ok := db[key]; ok {
+				panic(errors.Errorf("%s.%s is not supported, set the connection pool in %s", dbKey, key, dbPoolKey))
+			}
+		}
+
+		if cfg.MaxOpenConns <= 0 {
+			cfg.MaxOpenConns = defaultMaxOpenConns
+		}
+		if cfg.MaxIdleConns <= 0 {
+			cfg.MaxIdleConns = defaultMaxIdleConns
+		}
+		if cfg.MaxIdleConns > cfg.MaxOpenConns {
+			cfg.MaxIdleConns = cfg.MaxOpenConns
+		}
+		if cfg.ConnMaxLifetime <= 0 {
+			cfg.ConnMaxLifetime = defaultConnMaxLifetime
+		}
+		if cfg.ConnMaxIdleTime <= 0 {
+			cfg.ConnMaxIdleTime = defaultConnMaxIdleTime
+		}
+
+		return cfg
+	}).(DBPool)
+}
+
+var dbPoolHooks = figure.Hooks{
+	"time.Duration": func(value interface{}) (reflect.Value, error) {
+		switch v := value.(type) {
+		case string:
+			duration, err := time.ParseDuration(v)
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "failed to parse duration: %s", v)
+			}
+			return reflect.ValueOf(duration), nil
+		default:
+			return reflect.Value{}, errors.Errorf("unsupported conversion from %T to time.Duration", value)
+		}
+	},
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type DBQueryer interface {
+	DBQueryTimeout() time.Duration
+	DBStatementTimeout() time.Duration
+	DBURL() string
+}
+
+const (
+	dbQueryKey = "db_query"
+	dbKey      = "db"
+
+	defaultDBQueryTimeout     = 5 * time.Second
+	defaultDBStatementTimeout = 30 * time.Second
+)
+
+func NewDBQueryer(getter kv.Getter) DBQueryer {
+	return &dbQuery{getter: getter}
+}
+
+type dbQueryConfig struct {
+	// Timeout cancels a query from the service side through its context
+	Timeout time.Duration `fig:"timeout"`
+	// StatementTimeout is passed to Postgres as the statement_timeout of every service connection,
+	// so the server aborts statements that outlive it even when no context deadline is set
+	StatementTimeout time.Duration `fig:"statement_timeout"`
+}
+
+type dbConfig struct {
+	URL string `fig:"url,required"`
+}
+
+type dbQuery struct {
+	getter  kv.Getter
+	once    comfig.Once
+	urlOnce comfig.Once
+}
+
+// DBQueryTimeout returns the longest a single database query may run before it is cancelled
+func (d *dbQuery) DBQueryTimeout() time.Duration {
+	return d.config().Timeout
+}
+
+// DBStatementTimeout returns the statement_timeout set on the service's database connections.
+// It is never shorter than DBQueryTimeout, so queries are normally cancelled by their context first
+// and the server side limit only catches statements running without a deadline
+func (d *dbQuery) DBStatementTimeout() time.Duration {
+	return d.config().StatementTimeout
+}
+
+// DBURL returns the connection string of the database
+func (d *dbQuery) DBURL() string {
+	return d.urlOnce.Do(func() interface{} {
+		var cfg dbConfig
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(d.getter, dbKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load db config"))
+		}
+
+		return cfg.URL
+	}).(string)
+}
+
+func (d *dbQuery) config() dbQueryConfig {
+	return d.once.Do(func() interface{} {
+		var cfg dbQueryConfig
+		err := figure.
+			Out(&cfg).
+			With(figure.BaseHooks, jwtHooks).
+			From(kv.MustGetStringMap(d.getter, dbQueryKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load db query config"))
+		}
+
+		if cfg.Timeout <= 0 {
+			cfg.Timeout = defaultDBQueryTimeout
+		}
+		if cfg.StatementTimeout <= 0 {
+			cfg.StatementTimeout = defaultDBStatementTimeout
+		}
+		if cfg.StatementTimeout < cfg.Timeout {
+			panic(errors.Errorf("db query statement_timeout %s must not be shorter than timeout %s", cfg.StatementTimeout, cfg.Timeout))
+		}
+
+		return cfg
+	}).(dbQueryConfig)
+}
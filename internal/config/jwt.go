@@ -1,10 +1,13 @@
 package config
 
 import (
+	"crypto/rsa"
+	"os"
 	"reflect"
 	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/server"
+	jwtgo "github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
 	"gitlab.com/distributed_lab/figure"
 	"gitlab.com/distributed_lab/kit/comfig"
@@ -24,7 +27,10 @@ func NewJWTer(getter kv.Getter) JWTer {
 }
 
 type jwtConfig struct {
-	SecretKey            string        `fig:"secret_key,required"`
+	Algorithm            string        `fig:"algorithm"`
+	SecretKey            string        `fig:"secret_key"`
+	PrivateKeyPath       string        `fig:"private_key_path"`
+	PublicKeyPath        string        `fig:"public_key_path"`
 	Issuer               string        `fig:"issuer,required"`
 	Audience             string        `fig:"audience,required"`
 	AccessTokenLifetime  time.Duration `fig:"access_token_lifetime,required"`
@@ -36,31 +42,74 @@ type jwt struct {
 	once   comfig.Once
 }
 
+// JWT returns the token signing config. HS256, the default, signs with secret_key.
+// RS256 signs with the key at private_key_path and verifies with the one at public_key_path,
+// which defaults to the public half of the private key
 func (j *jwt) JWT() server.JWT {
-	cfg := j.jwtConfig(jwtKey)
-	return server.JWT{
-		SecretKey:            cfg.SecretKey,
-		Issuer:               cfg.Issuer,
-		Audience:             cfg.Audience,
-		AccessTokenLifetime:  cfg.AccessTokenLifetime,
-		RefreshTokenLifetime: cfg.RefreshTokenLifetime,
-	}
-}
-
-func (j *jwt) jwtConfig(key string) jwtConfig {
 	return j.once.Do(func() interface{} {
 		var cfg jwtConfig
 		err := figure.
 			Out(&cfg).
 			With(figure.BaseHooks, jwtHooks).
-			From(kv.MustGetStringMap(j.getter, key)).
+			From(kv.MustGetStringMap(j.getter, jwtKey)).
 			Please()
 		if err != nil {
 			panic(errors.Wrap(err, "failed to load jwt config"))
 		}
 
-		return cfg
-	}).(jwtConfig)
+		result := server.JWT{
+			Algorithm:            cfg.Algorithm,
+			SecretKey:            cfg.SecretKey,
+			Issuer:               cfg.Issuer,
+			Audience:             cfg.Audience,
+			AccessTokenLifetime:  cfg.AccessTokenLifetime,
+			RefreshTokenLifetime: cfg.RefreshTokenLifetime,
+		}
+
+		switch cfg.Algorithm {
+		case "", server.JWTAlgorithmHS256:
+			result.Algorithm = server.JWTAlgorithmHS256
+			if cfg.SecretKey == "" {
+				panic(errors.New("jwt secret_key is required for HS256"))
+			}
+		case server.JWTAlgorithmRS256:
+			if cfg.PrivateKeyPath == "" {
+				panic(errors.New("jwt private_key_path is required for RS256"))
+			}
+			result.PrivateKey, err = readRSAPrivateKey(cfg.PrivateKeyPath)
+			if err != nil {
+				panic(errors.Wrap(err, "failed to load jwt private key"))
+			}
+
+			result.PublicKey = &result.PrivateKey.PublicKey
+			if cfg.PublicKeyPath != "" {
+				result.PublicKey, err = readRSAPublicKey(cfg.PublicKeyPath)
+				if err != nil {
+					panic(errors.Wrap(err, "failed to load jwt public key"))
+				}
+			}
+		default:
+			panic(errors.Errorf("unsupported jwt algorithm %q, expected HS256 or RS256", cfg.Algorithm))
+		}
+
+		return result
+	}).(server.JWT)
+}
+
+func readRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key file")
+	}
+	return jwtgo.ParseRSAPrivateKeyFromPEM(pem)
+}
+
+func readRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key file")
+	}
+	return jwtgo.ParseRSAPublicKeyFromPEM(pem)
 }
 
 var jwtHooks = figure.Hooks{
@@ -13,6 +13,8 @@ import (
 
 type Listenerer interface {
 	ApiHttpListener() net.Listener
+	// ApiHttpAddr returns the configured HTTP address without binding it
+	ApiHttpAddr() string
 }
 
 const (
@@ -28,9 +30,15 @@ type listeners struct {
 	ApiHttp net.Listener `fig:"api_http_addr,required"`
 }
 
+type listenerAddrs struct {
+	ApiGrpc string `fig:"api_grpc_addr,required"`
+	ApiHttp string `fig:"api_http_addr,required"`
+}
+
 type listener struct {
-	getter kv.Getter
-	once   comfig.Once
+	getter    kv.Getter
+	once      comfig.Once
+	addrsOnce comfig.Once
 }
 
 func (l *listener) ApiGrpcListener() net.Listener {
@@ -41,6 +49,34 @@ func (l *listener) ApiHttpListener() net.Listener {
 	return l.listener(listenersKey).ApiHttp
 }
 
+// ApiHttpAddr returns the configured HTTP address, checked to be a valid TCP address
+// but not bound, so it can be validated while the service holds the port
+func (l *listener) ApiHttpAddr() string {
+	return l.addrs(listenersKey).ApiHttp
+}
+
+func (l *listener) addrs(key string) listenerAddrs {
+	return l.addrsOnce.Do(func() interface{} {
+		var addrs listenerAddrs
+		err := figure.
+			Out(&addrs).
+			With(figure.BaseHooks).
+			From(kv.MustGetStringMap(l.getter, key)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load listener config"))
+		}
+
+		for _, addr := range []string{addrs.ApiGrpc, addrs.ApiHttp} {
+			if _, err := net.ResolveTCPAddr("tcp", addr); err != nil {
+				panic(errors.Wrapf(err, "invalid listener address %s", addr))
+			}
+		}
+
+		return addrs
+	}).(listenerAddrs)
+}
+
 func (l *listener) listener(key string) listeners {
 	return l.once.Do(func() interface{} {
 		var ls listeners
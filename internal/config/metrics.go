@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type Metricser interface {
+	Metrics() *metrics.Metrics
+}
+
+const (
+	metricsKey = "metrics"
+
+	defaultMetricsNamespace = "booking"
+)
+
+func NewMetricser(getter kv.Getter) Metricser {
+	return &metricser{getter: getter}
+}
+
+type metricsConfig struct {
+	Namespace string `fig:"namespace"`
+}
+
+type metricser struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// Metrics returns the service metrics registered under the configured namespace
+func (m *metricser) Metrics() *metrics.Metrics {
+	return m.once.Do(func() interface{} {
+		var cfg metricsConfig
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(m.getter, metricsKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load metrics config"))
+		}
+
+		if cfg.Namespace == "" {
+			cfg.Namespace = defaultMetricsNamespace
+		}
+
+		return metrics.New(cfg.Namespace)
+	}).(*metrics.Metrics)
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/server"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type PasswordPolicyer interface {
+	PasswordPolicy() server.PasswordPolicy
+}
+
+const (
+	passwordPolicyKey = "password_policy"
+)
+
+func NewPasswordPolicyer(getter kv.Getter) PasswordPolicyer {
+	return &passwordPolicy{getter: getter}
+}
+
+type passwordPolicy struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// PasswordPolicy returns the configured password policy. When the section is
+// missing, only the default minimum length is enforced
+func (p *passwordPolicy) PasswordPolicy() server.PasswordPolicy {
+	return p.once.Do(func() interface{} {
+		var cfg server.PasswordPolicy
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(p.getter, passwordPolicyKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load password policy config"))
+		}
+
+		if cfg.MinLength <= 0 {
+			cfg.MinLength = server.DefaultPasswordMinLength
+		}
+
+		return cfg
+	}).(server.PasswordPolicy)
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/server"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type ReservationPolicyer interface {
+	ReservationPolicy() server.ReservationPolicy
+}
+
+const (
+	reservationPolicyKey = "reservation_policy"
+
+	defaultRestaurantName = "Restaurant"
+)
+
+func NewReservationPolicyer(getter kv.Getter) ReservationPolicyer {
+	return &reservationPolicy{getter: getter}
+}
+
+type businessHoursConfig struct {
+	Monday    string `fig:"monday"`
+	Tuesday   string `fig:"tuesday"`
+	Wednesday string `fig:"wednesday"`
+	Thursday  string `fig:"thursday"`
+	Friday    string `fig:"friday"`
+	Saturday  string `fig:"saturday"`
+	Sunday    string `fig:"sunday"`
+}
+
+type reservationPolicyConfig struct {
+	RestaurantName          string              `fig:"restaurant_name"`
+	BusinessHours           businessHoursConfig `fig:"business_hours"`
+	MinPartySize            int                 `fig:"min_party_size"`
+	MaxPartySize            int                 `fig:"max_party_size"`
+	DuplicateWindow         time.Duration       `fig:"duplicate_window"`
+	MaxActivePerUser        int                 `fig:"max_active_per_user"`
+	MaxConcurrentParties    int                 `fig:"max_concurrent_parties"`
+	MaxAdvanceDays          int                 `fig:"max_advance_days"`
+	MinLeadHours            int                 `fig:"min_lead_hours"`
+	AutoConfirmReservations bool                `fig:"auto_confirm_reservations"`
+	Timezone                string              `fig:"timezone"`
+	SlotMinutes             int                 `fig:"slot_minutes"`
+}
+
+type reservationPolicy struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// ReservationPolicy returns the configured reservation rules. Weekdays missing
+// from business_hours accept reservations at any time and every numeric limit left at zero is not enforced.
+// Without a timezone reservation dates and times are taken as server local time
+func (p *reservationPolicy) ReservationPolicy() server.ReservationPolicy {
+	return p.once.Do(func() interface{} {
+		var cfg reservationPolicyConfig
+		err := figure.
+			Out(&cfg).
+			With(figure.BaseHooks).
+			From(kv.MustGetStringMap(p.getter, reservationPolicyKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load reservation policy config"))
+		}
+
+		if cfg.MinPartySize < 0 || cfg.MaxPartySize < 0 {
+			panic(errors.New("party size limits must not be negative"))
+		}
+		if cfg.MaxPartySize > 0 && cfg.MinPartySize > cfg.MaxPartySize {
+			panic(errors.New("min_party_size must not exceed max_party_size"))
+		}
+		if cfg.DuplicateWindow < 0 {
+			panic(errors.New("duplicate_window must not be negative"))
+		}
+		if cfg.MaxActivePerUser < 0 {
+			panic(errors.New("max_active_per_user must not be negative"))
+		}
+		if cfg.MaxConcurrentParties < 0 {
+			panic(errors.New("max_concurrent_parties must not be negative"))
+		}
+		if cfg.MaxAdvanceDays < 0 || cfg.MinLeadHours < 0 {
+			panic(errors.New("max_advance_days and min_lead_hours must not be negative"))
+		}
+		if cfg.MaxAdvanceDays > 0 && cfg.MinLeadHours > cfg.MaxAdvanceDays*24 {
+			panic(errors.New("min_lead_hours must not exceed max_advance_days"))
+		}
+
+		if cfg.SlotMinutes < 0 || (cfg.SlotMinutes > 0 && (24*60)%cfg.SlotMinutes != 0) {
+			panic(errors.New("slot_minutes must divide a day evenly, e.g. 15, 30 or 60"))
+		}
+
+		location := time.Local
+		if cfg.Timezone != "" {
+			location, err = time.LoadLocation(cfg.Timezone)
+			if err != nil {
+				panic(errors.Wrapf(err, "failed to load timezone %q", cfg.Timezone))
+			}
+		}
+
+		days := map[time.Weekday]string{
+			time.Monday:    cfg.BusinessHours.Monday,
+			time.Tuesday:   cfg.BusinessHours.Tuesday,
+			time.Wednesday: cfg.BusinessHours.Wednesday,
+			time.Thursday:  cfg.BusinessHours.Thursday,
+			time.Friday:    cfg.BusinessHours.Friday,
+			time.Saturday:  cfg.BusinessHours.Saturday,
+			time.Sunday:    cfg.BusinessHours.Sunday,
+		}
+
+		hours := make(server.BusinessHours)
+		for day, value := range days {
+			if value == "" {
+				continue
+			}
+			opening, err := server.ParseOpeningHours(value)
+			if err != nil {
+				panic(errors.Wrapf(err, "failed to load business hours for %s", day))
+			}
+			hours[day] = opening
+		}
+
+		if cfg.RestaurantName == "" {
+			cfg.RestaurantName = defaultRestaurantName
+		}
+
+		return server.ReservationPolicy{
+			RestaurantName:          cfg.RestaurantName,
+			BusinessHours:           hours,
+			MinPartySize:            cfg.MinPartySize,
+			MaxPartySize:            cfg.MaxPartySize,
+			DuplicateWindow:         cfg.DuplicateWindow,
+			MaxActivePerUser:        cfg.MaxActivePerUser,
+			MaxConcurrentParties:    cfg.MaxConcurrentParties,
+			MaxAdvanceDays:          cfg.MaxAdvanceDays,
+			MinLeadHours:            cfg.MinLeadHours,
+			AutoConfirmReservations: cfg.AutoConfirmReservations,
+			Location:                location,
+			SlotMinutes:             cfg.SlotMinutes,
+		}
+	}).(server.ReservationPolicy)
+}
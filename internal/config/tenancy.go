@@ -0,0 +1,46 @@
+package config
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/server"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type Tenancyer interface {
+	Tenancy() server.Tenancy
+}
+
+const (
+	tenancyKey = "tenancy"
+)
+
+func NewTenancyer(getter kv.Getter) Tenancyer {
+	return &tenancy{getter: getter}
+}
+
+type tenancyConfig struct {
+	Enabled bool `fig:"enabled"`
+}
+
+type tenancy struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// Tenancy returns whether requests are scoped to the restaurant they name
+func (t *tenancy) Tenancy() server.Tenancy {
+	return t.once.Do(func() interface{} {
+		var cfg tenancyConfig
+		err := figure.
+			Out(&cfg).
+			From(kv.MustGetStringMap(t.getter, tenancyKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load tenancy config"))
+		}
+
+		return server.Tenancy{Enabled: cfg.Enabled}
+	}).(server.Tenancy)
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/webhook"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+type Webhooker interface {
+	Webhooks() webhook.Config
+}
+
+const (
+	webhooksKey = "webhooks"
+
+	defaultWebhookQueueSize  = 1000
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultWebhookMinBackoff = time.Second
+	defaultWebhookMaxBackoff = time.Minute
+)
+
+func NewWebhooker(getter kv.Getter) Webhooker {
+	return &webhooks{getter: getter}
+}
+
+type webhooksConfig struct {
+	QueueSize  int           `fig:"queue_size"`
+	Timeout    time.Duration `fig:"timeout"`
+	MaxRetries int           `fig:"max_retries"`
+	MinBackoff time.Duration `fig:"min_retry_backoff"`
+	MaxBackoff time.Duration `fig:"max_retry_backoff"`
+}
+
+type webhooks struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+// Webhooks returns how reservation events are queued and delivered to webhooks
+func (w *webhooks) Webhooks() webhook.Config {
+	return w.once.Do(func() interface{} {
+		var cfg webhooksConfig
+		err := figure.
+			Out(&cfg).
+			With(figure.BaseHooks).
+			From(kv.MustGetStringMap(w.getter, webhooksKey)).
+			Please()
+		if err != nil {
+			panic(errors.Wrap(err, "failed to load webhooks config"))
+		}
+
+		if cfg.MaxRetries < 0 {
+			panic(errors.New("max_retries must not be negative"))
+		}
+		if cfg.QueueSize <= 0 {
+			cfg.QueueSize = defaultWebhookQueueSize
+		}
+		if cfg.Timeout <= 0 {
+			cfg.Timeout = defaultWebhookTimeout
+		}
+		if cfg.MinBackoff <= 0 {
+			cfg.MinBackoff = defaultWebhookMinBackoff
+		}
+		if cfg.MaxBackoff < cfg.MinBackoff {
+			cfg.MaxBackoff = max(defaultWebhookMaxBackoff, cfg.MinBackoff)
+		}
+
+		return webhook.Config{
+			QueueSize:  cfg.QueueSize,
+			Timeout:    cfg.Timeout,
+			MaxRetries: cfg.MaxRetries,
+			MinBackoff: cfg.MinBackoff,
+			MaxBackoff: cfg.MaxBackoff,
+		}
+	}).(webhook.Config)
+}
@@ -0,0 +1,25 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// BlockedDateQ defines methods for blocked date database operations
+type BlockedDateQ interface {
+	// Create blocks a range of dates
+	Create(ctx context.Context, blocked *types.BlockedDate) error
+
+	// GetInRange retrieves the blocked ranges overlapping the dates from through to, ordered by start date
+	GetInRange(ctx context.Context, from, to time.Time) ([]*types.BlockedDate, error)
+
+	// GetByDate retrieves a blocked range covering the "YYYY-MM-DD" date.
+	// It returns ErrNotFound when the date is not blocked
+	GetByDate(ctx context.Context, date string) (*types.BlockedDate, error)
+
+	// Delete removes a blocked range by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+}
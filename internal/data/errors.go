@@ -0,0 +1,13 @@
+package data
+
+import "errors"
+
+// ErrNotFound is returned by queries when the requested record does not exist.
+// Implementations wrap it with the name of the record, so check it with errors.Is
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned by updates when the record was modified after the caller read it
+var ErrConflict = errors.New("conflict")
+
+// ErrQueryTimeout is returned when a query did not finish within the configured query timeout
+var ErrQueryTimeout = errors.New("query timeout")
@@ -0,0 +1,18 @@
+package data
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// FeedbackQ defines methods for reservation feedback database operations
+type FeedbackQ interface {
+	// Create stores the feedback on a reservation. A reservation that already has feedback results in ErrConflict,
+	// a reservation outside the restaurant ctx is scoped to in ErrNotFound
+	Create(ctx context.Context, feedback *types.Feedback) error
+
+	// GetByReservationID retrieves the feedback on a reservation
+	GetByReservationID(ctx context.Context, reservationID uuid.UUID) (*types.Feedback, error)
+}
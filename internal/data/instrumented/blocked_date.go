@@ -0,0 +1,46 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// BlockedDateQ decorates a BlockedDateQ with query metrics
+type BlockedDateQ struct {
+	next    data.BlockedDateQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create blocks a range of dates
+func (q *BlockedDateQ) Create(ctx context.Context, blocked *types.BlockedDate) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "blocked_date.create", &err)
+	defer done()
+	return q.next.Create(ctx, blocked)
+}
+
+// GetInRange retrieves the blocked ranges overlapping the dates from through to
+func (q *BlockedDateQ) GetInRange(ctx context.Context, from, to time.Time) (blocked []*types.BlockedDate, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "blocked_date.get_in_range", &err)
+	defer done()
+	return q.next.GetInRange(ctx, from, to)
+}
+
+// GetByDate retrieves a blocked range covering the date
+func (q *BlockedDateQ) GetByDate(ctx context.Context, date string) (blocked *types.BlockedDate, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "blocked_date.get_by_date", &err)
+	defer done()
+	return q.next.GetByDate(ctx, date)
+}
+
+// Delete removes a blocked range by ID
+func (q *BlockedDateQ) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "blocked_date.delete", &err)
+	defer done()
+	return q.next.Delete(ctx, id)
+}
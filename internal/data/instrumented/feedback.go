@@ -0,0 +1,32 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// FeedbackQ decorates a FeedbackQ with query metrics
+type FeedbackQ struct {
+	next    data.FeedbackQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create stores the feedback on a reservation
+func (q *FeedbackQ) Create(ctx context.Context, feedback *types.Feedback) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "feedback.create", &err)
+	defer done()
+	return q.next.Create(ctx, feedback)
+}
+
+// GetByReservationID retrieves the feedback on a reservation
+func (q *FeedbackQ) GetByReservationID(ctx context.Context, reservationID uuid.UUID) (feedback *types.Feedback, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "feedback.get_by_reservation_id", &err)
+	defer done()
+	return q.next.GetByReservationID(ctx, reservationID)
+}
@@ -0,0 +1,109 @@
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+)
+
+// Master decorates a MasterQ, records the duration and errors of every query
+// and bounds each query with a timeout
+type Master struct {
+	next    data.MasterQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// NewMaster creates a new Master instance wrapping the given MasterQ.
+// A non-positive timeout leaves queries bounded only by the caller's context
+func NewMaster(next data.MasterQ, metrics *metrics.Metrics, timeout time.Duration) data.MasterQ {
+	return &Master{
+		next:    next,
+		metrics: metrics,
+		timeout: timeout,
+	}
+}
+
+// UserQ returns the instrumented user query interface
+func (m *Master) UserQ() data.UserQ {
+	return &UserQ{next: m.next.UserQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// ReservationQ returns the instrumented reservation query interface
+func (m *Master) ReservationQ() data.ReservationQ {
+	return &ReservationQ{next: m.next.ReservationQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// TableQ returns the instrumented table query interface
+func (m *Master) TableQ() data.TableQ {
+	return &TableQ{next: m.next.TableQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// ReportsQ returns the instrumented reports query interface
+func (m *Master) ReportsQ() data.ReportsQ {
+	return &ReportsQ{next: m.next.ReportsQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// StatusHistoryQ returns the instrumented reservation status history query interface
+func (m *Master) StatusHistoryQ() data.StatusHistoryQ {
+	return &StatusHistoryQ{next: m.next.StatusHistoryQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// RestaurantQ returns the instrumented restaurant query interface
+func (m *Master) RestaurantQ() data.RestaurantQ {
+	return &RestaurantQ{next: m.next.RestaurantQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// WebhookQ returns the instrumented webhook query interface
+func (m *Master) WebhookQ() data.WebhookQ {
+	return &WebhookQ{next: m.next.WebhookQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// BlockedDateQ returns the instrumented blocked date query interface
+func (m *Master) BlockedDateQ() data.BlockedDateQ {
+	return &BlockedDateQ{next: m.next.BlockedDateQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// SpecialHoursQ returns the instrumented special hours query interface
+func (m *Master) SpecialHoursQ() data.SpecialHoursQ {
+	return &SpecialHoursQ{next: m.next.SpecialHoursQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// FeedbackQ returns the instrumented reservation feedback query interface
+func (m *Master) FeedbackQ() data.FeedbackQ {
+	return &FeedbackQ{next: m.next.FeedbackQ(), metrics: m.metrics, timeout: m.timeout}
+}
+
+// Transaction runs fn with an instrumented transaction-scoped master. Every query in the
+// transaction is bounded by the timeout, the transaction as a whole only by the caller's context
+func (m *Master) Transaction(ctx context.Context, fn func(tx data.MasterQ) error) (err error) {
+	ctx, done := begin(ctx, m.metrics, 0, "master.transaction", &err)
+	defer done()
+	return m.next.Transaction(ctx, func(tx data.MasterQ) error {
+		return fn(NewMaster(tx, m.metrics, m.timeout))
+	})
+}
+
+// begin derives the query context from ctx, bounded by timeout, and starts timing the operation.
+// The returned function releases the context and records the operation once err is final.
+// A query cut off by the timeout, rather than by the caller, reports data.ErrQueryTimeout
+func begin(ctx context.Context, m *metrics.Metrics, timeout time.Duration, operation string, err *error) (context.Context, func()) {
+	start := time.Now()
+	parent := ctx
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func() {
+		if *err != nil && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			*err = fmt.Errorf("%s exceeded %s: %w", operation, timeout, data.ErrQueryTimeout)
+		}
+		cancel()
+		m.ObserveDBQuery(operation, start, *err)
+	}
+}
@@ -0,0 +1,48 @@
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBegin(t *testing.T) {
+	m := metrics.New("test")
+
+	t.Run("query timeout", func(t *testing.T) {
+		var err error
+		ctx, done := begin(context.Background(), m, time.Millisecond, "test.timeout", &err)
+		<-ctx.Done()
+		err = ctx.Err()
+		done()
+
+		assert.ErrorIs(t, err, data.ErrQueryTimeout)
+	})
+
+	t.Run("caller cancelled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var err error
+		ctx, done := begin(parent, m, time.Minute, "test.cancelled", &err)
+		err = ctx.Err()
+		done()
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, errors.Is(err, data.ErrQueryTimeout))
+	})
+
+	t.Run("no timeout", func(t *testing.T) {
+		var err error
+		ctx, done := begin(context.Background(), m, 0, "test.no_timeout", &err)
+		defer done()
+
+		_, hasDeadline := ctx.Deadline()
+		assert.False(t, hasDeadline)
+	})
+}
@@ -0,0 +1,67 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// ReportsQ decorates a ReportsQ with query metrics
+type ReportsQ struct {
+	next    data.ReportsQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// GetMonthlyStatsList retrieves a list of all months with available statistics
+func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context, filters *types.MonthlyStatsFilters) (stats []*types.MonthlyStats, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_monthly_stats_list", &err)
+	defer done()
+	return q.next.GetMonthlyStatsList(ctx, filters)
+}
+
+// GetYearlyStats retrieves statistics aggregated per year
+func (q *ReportsQ) GetYearlyStats(ctx context.Context) (stats []*types.YearlyStats, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_yearly_stats", &err)
+	defer done()
+	return q.next.GetYearlyStats(ctx)
+}
+
+// GetWeeklyStats retrieves statistics aggregated per ISO week for the given date range (inclusive)
+func (q *ReportsQ) GetWeeklyStats(ctx context.Context, dateFrom, dateTo time.Time) (stats []*types.WeeklyStats, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_weekly_stats", &err)
+	defer done()
+	return q.next.GetWeeklyStats(ctx, dateFrom, dateTo)
+}
+
+// GetDetailedMonthlyStats retrieves detailed statistics for a specific month
+func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (stats *types.DetailedMonthlyStats, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_detailed_monthly_stats", &err)
+	defer done()
+	return q.next.GetDetailedMonthlyStats(ctx, month)
+}
+
+// GetUserStats retrieves reservation statistics for a specific user
+func (q *ReportsQ) GetUserStats(ctx context.Context, userID uuid.UUID) (stats *types.UserStats, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_user_stats", &err)
+	defer done()
+	return q.next.GetUserStats(ctx, userID)
+}
+
+// GetTableOccupancy retrieves per-table occupancy statistics for the given date range (inclusive)
+func (q *ReportsQ) GetTableOccupancy(ctx context.Context, dateFrom, dateTo time.Time, slots int) (occupancy []*types.TableOccupancy, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_table_occupancy", &err)
+	defer done()
+	return q.next.GetTableOccupancy(ctx, dateFrom, dateTo, slots)
+}
+
+// GetTodaySnapshot retrieves reservation counts by status and expected covers for the current date
+func (q *ReportsQ) GetTodaySnapshot(ctx context.Context) (snapshot *types.TodaySnapshot, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reports.get_today_snapshot", &err)
+	defer done()
+	return q.next.GetTodaySnapshot(ctx)
+}
@@ -0,0 +1,186 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// ReservationQ decorates a ReservationQ with query metrics
+type ReservationQ struct {
+	next    data.ReservationQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create creates a new reservation
+func (q *ReservationQ) Create(ctx context.Context, reservation *types.Reservation, initialStatus string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.create", &err)
+	defer done()
+	return q.next.Create(ctx, reservation, initialStatus)
+}
+
+// BulkCreate inserts the reservations in a single transaction
+func (q *ReservationQ) BulkCreate(ctx context.Context, reservations []*types.Reservation, initialStatus string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.bulk_create", &err)
+	defer done()
+	return q.next.BulkCreate(ctx, reservations, initialStatus)
+}
+
+// SetTables replaces the merged table set of a reservation
+func (q *ReservationQ) SetTables(ctx context.Context, id uuid.UUID, tableNumbers []string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.set_tables", &err)
+	defer done()
+	return q.next.SetTables(ctx, id, tableNumbers)
+}
+
+// GetByID retrieves a reservation by ID
+func (q *ReservationQ) GetByID(ctx context.Context, id uuid.UUID) (reservation *types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_id", &err)
+	defer done()
+	return q.next.GetByID(ctx, id)
+}
+
+// GetByConfirmationCode retrieves a reservation by its confirmation code
+func (q *ReservationQ) GetByConfirmationCode(ctx context.Context, code string) (reservation *types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_confirmation_code", &err)
+	defer done()
+	return q.next.GetByConfirmationCode(ctx, code)
+}
+
+// GetByPhoneAndDate retrieves the pending and confirmed reservations made under a guest phone on a date
+func (q *ReservationQ) GetByPhoneAndDate(ctx context.Context, phone string, date string) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_phone_and_date", &err)
+	defer done()
+	return q.next.GetByPhoneAndDate(ctx, phone, date)
+}
+
+// GetAll retrieves all reservations with optional filters
+func (q *ReservationQ) GetAll(ctx context.Context, userID *uuid.UUID, filters *types.ReservationFilters) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_all", &err)
+	defer done()
+	return q.next.GetAll(ctx, userID, filters)
+}
+
+// GetByUserID retrieves all reservations for a specific user
+func (q *ReservationQ) GetByUserID(ctx context.Context, userID uuid.UUID) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_user_id", &err)
+	defer done()
+	return q.next.GetByUserID(ctx, userID)
+}
+
+// GetByRecurrenceGroup retrieves the reservations of a recurring booking
+func (q *ReservationQ) GetByRecurrenceGroup(ctx context.Context, groupID uuid.UUID) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_recurrence_group", &err)
+	defer done()
+	return q.next.GetByRecurrenceGroup(ctx, groupID)
+}
+
+// GetByTableNumber retrieves the reservations holding a table between two dates inclusive
+func (q *ReservationQ) GetByTableNumber(ctx context.Context, tableNumber string, dateFrom, dateTo time.Time) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_by_table_number", &err)
+	defer done()
+	return q.next.GetByTableNumber(ctx, tableNumber, dateFrom, dateTo)
+}
+
+// CountActiveByUser counts the pending and confirmed reservations of a user that have not started yet
+func (q *ReservationQ) CountActiveByUser(ctx context.Context, userID uuid.UUID) (count int, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.count_active_by_user", &err)
+	defer done()
+	return q.next.CountActiveByUser(ctx, userID)
+}
+
+// CountUpcomingByUser counts the reservations of a user that have not started yet and were not cancelled
+func (q *ReservationQ) CountUpcomingByUser(ctx context.Context, userID uuid.UUID) (count int, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.count_upcoming_by_user", &err)
+	defer done()
+	return q.next.CountUpcomingByUser(ctx, userID)
+}
+
+// Update updates a reservation's information, optionally guarded by its last known modification time
+func (q *ReservationQ) Update(ctx context.Context, id uuid.UUID, reservation *types.Reservation, expectedUpdatedAt *time.Time) (updatedAt time.Time, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.update", &err)
+	defer done()
+	return q.next.Update(ctx, id, reservation, expectedUpdatedAt)
+}
+
+// UpdateStatus updates only the status of a reservation
+func (q *ReservationQ) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, status string) (reservation *types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.update_status", &err)
+	defer done()
+	return q.next.UpdateStatus(ctx, id, fromStatus, status)
+}
+
+// UpdateStaffNotes replaces the staff notes of a reservation
+func (q *ReservationQ) UpdateStaffNotes(ctx context.Context, id uuid.UUID, notes *string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.update_staff_notes", &err)
+	defer done()
+	return q.next.UpdateStaffNotes(ctx, id, notes)
+}
+
+// BulkUpdateStatus applies the status changes in a single transaction
+func (q *ReservationQ) BulkUpdateStatus(ctx context.Context, changes []*types.StatusChange) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.bulk_update_status", &err)
+	defer done()
+	return q.next.BulkUpdateStatus(ctx, changes)
+}
+
+// Delete soft-deletes a reservation by ID
+func (q *ReservationQ) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.delete", &err)
+	defer done()
+	return q.next.Delete(ctx, id)
+}
+
+// HardDelete permanently removes a reservation by ID and returns the removed reservation
+func (q *ReservationQ) HardDelete(ctx context.Context, id uuid.UUID) (reservation *types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.hard_delete", &err)
+	defer done()
+	return q.next.HardDelete(ctx, id)
+}
+
+// GetDeleted retrieves all soft-deleted reservations
+func (q *ReservationQ) GetDeleted(ctx context.Context) (reservations []*types.Reservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_deleted", &err)
+	defer done()
+	return q.next.GetDeleted(ctx)
+}
+
+// Restore undoes a soft delete of a reservation
+func (q *ReservationQ) Restore(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.restore", &err)
+	defer done()
+	return q.next.Restore(ctx, id)
+}
+
+// MarkPastAsCompleted marks past confirmed reservations as completed
+func (q *ReservationQ) MarkPastAsCompleted(ctx context.Context) (completed []types.CompletedReservation, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.mark_past_as_completed", &err)
+	defer done()
+	return q.next.MarkPastAsCompleted(ctx)
+}
+
+// CheckTableAvailability checks if a table is available at a specific date and time
+func (q *ReservationQ) CheckTableAvailability(ctx context.Context, tableNumber string, date string, time string) (available bool, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.check_table_availability", &err)
+	defer done()
+	return q.next.CheckTableAvailability(ctx, tableNumber, date, time)
+}
+
+// CountConcurrentAt counts the pending and confirmed reservations across all tables that overlap a reservation starting at a date and time
+func (q *ReservationQ) CountConcurrentAt(ctx context.Context, date string, clock string, window time.Duration, excludeID uuid.UUID) (count int, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.count_concurrent_at", &err)
+	defer done()
+	return q.next.CountConcurrentAt(ctx, date, clock, window, excludeID)
+}
+
+// GetBookedTimes returns the times at which a table is held by an active reservation on a date
+func (q *ReservationQ) GetBookedTimes(ctx context.Context, tableNumber string, date string) (times []string, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "reservation.get_booked_times", &err)
+	defer done()
+	return q.next.GetBookedTimes(ctx, tableNumber, date)
+}
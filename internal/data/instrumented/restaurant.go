@@ -0,0 +1,39 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// RestaurantQ decorates a RestaurantQ with query metrics
+type RestaurantQ struct {
+	next    data.RestaurantQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// GetByID retrieves a restaurant by ID
+func (q *RestaurantQ) GetByID(ctx context.Context, id uuid.UUID) (restaurant *types.Restaurant, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "restaurant.get_by_id", &err)
+	defer done()
+	return q.next.GetByID(ctx, id)
+}
+
+// GetBySlug retrieves a restaurant by its slug
+func (q *RestaurantQ) GetBySlug(ctx context.Context, slug string) (restaurant *types.Restaurant, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "restaurant.get_by_slug", &err)
+	defer done()
+	return q.next.GetBySlug(ctx, slug)
+}
+
+// GetAll retrieves all restaurants ordered by name
+func (q *RestaurantQ) GetAll(ctx context.Context) (restaurants []*types.Restaurant, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "restaurant.get_all", &err)
+	defer done()
+	return q.next.GetAll(ctx)
+}
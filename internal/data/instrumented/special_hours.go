@@ -0,0 +1,60 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// SpecialHoursQ decorates a SpecialHoursQ with query metrics
+type SpecialHoursQ struct {
+	next    data.SpecialHoursQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create overrides the business hours of a date
+func (q *SpecialHoursQ) Create(ctx context.Context, hours *types.SpecialHours) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.create", &err)
+	defer done()
+	return q.next.Create(ctx, hours)
+}
+
+// GetByID retrieves special hours by ID
+func (q *SpecialHoursQ) GetByID(ctx context.Context, id uuid.UUID) (hours *types.SpecialHours, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.get_by_id", &err)
+	defer done()
+	return q.next.GetByID(ctx, id)
+}
+
+// GetByDate retrieves the special hours of the date
+func (q *SpecialHoursQ) GetByDate(ctx context.Context, date string) (hours *types.SpecialHours, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.get_by_date", &err)
+	defer done()
+	return q.next.GetByDate(ctx, date)
+}
+
+// GetInRange retrieves the special hours of the dates from through to
+func (q *SpecialHoursQ) GetInRange(ctx context.Context, from, to time.Time) (hours []*types.SpecialHours, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.get_in_range", &err)
+	defer done()
+	return q.next.GetInRange(ctx, from, to)
+}
+
+// Update saves the date, times and note of existing special hours
+func (q *SpecialHoursQ) Update(ctx context.Context, hours *types.SpecialHours) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.update", &err)
+	defer done()
+	return q.next.Update(ctx, hours)
+}
+
+// Delete removes special hours by ID
+func (q *SpecialHoursQ) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "special_hours.delete", &err)
+	defer done()
+	return q.next.Delete(ctx, id)
+}
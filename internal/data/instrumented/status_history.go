@@ -0,0 +1,32 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// StatusHistoryQ decorates a StatusHistoryQ with query metrics
+type StatusHistoryQ struct {
+	next    data.StatusHistoryQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create records a reservation status change
+func (q *StatusHistoryQ) Create(ctx context.Context, change *types.StatusChange) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "status_history.create", &err)
+	defer done()
+	return q.next.Create(ctx, change)
+}
+
+// GetByReservationID retrieves the status history of a reservation ordered from oldest to newest
+func (q *StatusHistoryQ) GetByReservationID(ctx context.Context, reservationID uuid.UUID) (changes []*types.StatusChange, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "status_history.get_by_reservation_id", &err)
+	defer done()
+	return q.next.GetByReservationID(ctx, reservationID)
+}
@@ -0,0 +1,95 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// TableQ decorates a TableQ with query metrics
+type TableQ struct {
+	next    data.TableQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create creates a new table
+func (q *TableQ) Create(ctx context.Context, table *types.Table) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.create", &err)
+	defer done()
+	return q.next.Create(ctx, table)
+}
+
+// GetByID retrieves a table by ID
+func (q *TableQ) GetByID(ctx context.Context, id uuid.UUID) (table *types.Table, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_by_id", &err)
+	defer done()
+	return q.next.GetByID(ctx, id)
+}
+
+// GetByNumber retrieves a table by table number
+func (q *TableQ) GetByNumber(ctx context.Context, number string) (table *types.Table, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_by_number", &err)
+	defer done()
+	return q.next.GetByNumber(ctx, number)
+}
+
+// GetAll retrieves all tables
+func (q *TableQ) GetAll(ctx context.Context) (tables []*types.Table, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_all", &err)
+	defer done()
+	return q.next.GetAll(ctx)
+}
+
+// GetByLocation retrieves all tables in a specific location
+func (q *TableQ) GetByLocation(ctx context.Context, location string) (tables []*types.Table, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_by_location", &err)
+	defer done()
+	return q.next.GetByLocation(ctx, location)
+}
+
+// GetAvailable retrieves available tables with optional filters
+func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabilityFilters) (tables []*types.Table, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_available", &err)
+	defer done()
+	return q.next.GetAvailable(ctx, filters)
+}
+
+// GetCapacitySummary aggregates table and seat counts
+func (q *TableQ) GetCapacitySummary(ctx context.Context) (summary *types.CapacitySummary, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.get_capacity_summary", &err)
+	defer done()
+	return q.next.GetCapacitySummary(ctx)
+}
+
+// UpdateAvailability updates the availability status of a table
+func (q *TableQ) UpdateAvailability(ctx context.Context, id uuid.UUID, isAvailable bool) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.update_availability", &err)
+	defer done()
+	return q.next.UpdateAvailability(ctx, id, isAvailable)
+}
+
+// BulkUpdateAvailability updates the availability status of several tables in one transaction
+func (q *TableQ) BulkUpdateAvailability(ctx context.Context, ids []uuid.UUID, isAvailable bool) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.bulk_update_availability", &err)
+	defer done()
+	return q.next.BulkUpdateAvailability(ctx, ids, isAvailable)
+}
+
+// UpdateStatus updates the service status of a table
+func (q *TableQ) UpdateStatus(ctx context.Context, id uuid.UUID, status string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.update_status", &err)
+	defer done()
+	return q.next.UpdateStatus(ctx, id, status)
+}
+
+// Update updates a table's information
+func (q *TableQ) Update(ctx context.Context, id uuid.UUID, table *types.Table) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "table.update", &err)
+	defer done()
+	return q.next.Update(ctx, id, table)
+}
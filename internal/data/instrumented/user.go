@@ -0,0 +1,67 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// UserQ decorates a UserQ with query metrics
+type UserQ struct {
+	next    data.UserQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create creates a new user
+func (q *UserQ) Create(ctx context.Context, user *types.User) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.create", &err)
+	defer done()
+	return q.next.Create(ctx, user)
+}
+
+// GetByID retrieves a user by ID
+func (q *UserQ) GetByID(ctx context.Context, id uuid.UUID) (user *types.User, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.get_by_id", &err)
+	defer done()
+	return q.next.GetByID(ctx, id)
+}
+
+// GetByEmail retrieves a user by email
+func (q *UserQ) GetByEmail(ctx context.Context, email string) (user *types.User, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.get_by_email", &err)
+	defer done()
+	return q.next.GetByEmail(ctx, email)
+}
+
+// GetAll retrieves a page of users together with the total number of matches
+func (q *UserQ) GetAll(ctx context.Context, limit, offset int, search *string) (users []*types.User, total int, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.get_all", &err)
+	defer done()
+	return q.next.GetAll(ctx, limit, offset, search)
+}
+
+// Update updates the given fields of a user
+func (q *UserQ) Update(ctx context.Context, id uuid.UUID, user *types.User) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.update", &err)
+	defer done()
+	return q.next.Update(ctx, id, user)
+}
+
+// UpdateRole changes the role of a user
+func (q *UserQ) UpdateRole(ctx context.Context, id uuid.UUID, role string) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.update_role", &err)
+	defer done()
+	return q.next.UpdateRole(ctx, id, role)
+}
+
+// Delete removes a user and hands their reservations over to the deleted user account
+func (q *UserQ) Delete(ctx context.Context, id uuid.UUID) (dates []time.Time, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "user.delete", &err)
+	defer done()
+	return q.next.Delete(ctx, id)
+}
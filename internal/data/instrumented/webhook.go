@@ -0,0 +1,39 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// WebhookQ decorates a WebhookQ with query metrics
+type WebhookQ struct {
+	next    data.WebhookQ
+	metrics *metrics.Metrics
+	timeout time.Duration
+}
+
+// Create registers a new webhook
+func (q *WebhookQ) Create(ctx context.Context, webhook *types.Webhook) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "webhook.create", &err)
+	defer done()
+	return q.next.Create(ctx, webhook)
+}
+
+// GetAll retrieves all webhooks ordered by registration time
+func (q *WebhookQ) GetAll(ctx context.Context) (webhooks []*types.Webhook, err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "webhook.get_all", &err)
+	defer done()
+	return q.next.GetAll(ctx)
+}
+
+// Delete removes a webhook by ID
+func (q *WebhookQ) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := begin(ctx, q.metrics, q.timeout, "webhook.delete", &err)
+	defer done()
+	return q.next.Delete(ctx, id)
+}
@@ -1,5 +1,7 @@
 package data
 
+import "context"
+
 // MasterQ is the master query interface that combines all query interfaces
 // It provides access to all database operations through a single interface
 type MasterQ interface {
@@ -14,4 +16,26 @@ type MasterQ interface {
 
 	// ReportsQ returns the reports query interface
 	ReportsQ() ReportsQ
+
+	// StatusHistoryQ returns the reservation status history query interface
+	StatusHistoryQ() StatusHistoryQ
+
+	// RestaurantQ returns the restaurant query interface
+	RestaurantQ() RestaurantQ
+
+	// WebhookQ returns the webhook query interface
+	WebhookQ() WebhookQ
+
+	// BlockedDateQ returns the blocked date query interface
+	BlockedDateQ() BlockedDateQ
+
+	// SpecialHoursQ returns the special hours query interface
+	SpecialHoursQ() SpecialHoursQ
+
+	// FeedbackQ returns the reservation feedback query interface
+	FeedbackQ() FeedbackQ
+
+	// Transaction runs fn with a master whose queries all run in one transaction, which is
+	// committed when fn returns nil and rolled back otherwise
+	Transaction(ctx context.Context, fn func(tx MasterQ) error) error
 }
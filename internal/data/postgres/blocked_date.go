@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// BlockedDateQ implements data.BlockedDateQ interface
+type BlockedDateQ struct {
+	db sqlxExt
+}
+
+// NewBlockedDateQ creates a new BlockedDateQ instance
+func NewBlockedDateQ(db sqlxExt) data.BlockedDateQ {
+	return &BlockedDateQ{db: db}
+}
+
+// restaurantBlockedDate binds a blocked range together with the restaurant it is created for
+type restaurantBlockedDate struct {
+	*types.BlockedDate
+	RestaurantID uuid.UUID `db:"restaurant_id"`
+}
+
+// Create blocks a range of dates
+func (q *BlockedDateQ) Create(ctx context.Context, blocked *types.BlockedDate) error {
+	query := `
+		INSERT INTO blocked_dates (id, start_date, end_date, reason, created_at)
+		VALUES (:id, :start_date, :end_date, :reason, :created_at)
+	`
+
+	if blocked.ID == uuid.Nil {
+		blocked.ID = uuid.New()
+	}
+
+	if blocked.CreatedAt.IsZero() {
+		blocked.CreatedAt = time.Now()
+	}
+
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query = `
+			INSERT INTO blocked_dates (id, start_date, end_date, reason, restaurant_id, created_at)
+			VALUES (:id, :start_date, :end_date, :reason, :restaurant_id, :created_at)
+		`
+		_, err := sqlx.NamedExecContext(ctx, q.db, query, restaurantBlockedDate{BlockedDate: blocked, RestaurantID: restaurantID})
+		return err
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, q.db, query, blocked)
+	return err
+}
+
+// GetInRange retrieves the blocked ranges overlapping the dates from through to, ordered by start date
+func (q *BlockedDateQ) GetInRange(ctx context.Context, from, to time.Time) ([]*types.BlockedDate, error) {
+	query := `
+		SELECT id, start_date, end_date, reason, created_at
+		FROM blocked_dates
+		WHERE start_date <= $2 AND end_date >= $1
+	`
+	args := []interface{}{from.Format("2006-01-02"), to.Format("2006-01-02")}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 3)
+	query += scope + " ORDER BY start_date, end_date"
+	args = append(args, scopeArgs...)
+
+	blocked := make([]*types.BlockedDate, 0)
+	if err := sqlx.SelectContext(ctx, q.db, &blocked, query, args...); err != nil {
+		return nil, err
+	}
+
+	return blocked, nil
+}
+
+// GetByDate retrieves a blocked range covering the date. When ranges overlap, the earliest one is returned
+func (q *BlockedDateQ) GetByDate(ctx context.Context, date string) (*types.BlockedDate, error) {
+	query := `
+		SELECT id, start_date, end_date, reason, created_at
+		FROM blocked_dates
+		WHERE start_date <= $1 AND end_date >= $1
+	`
+	args := []interface{}{date}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope + " ORDER BY start_date LIMIT 1"
+	args = append(args, scopeArgs...)
+
+	var blocked types.BlockedDate
+	if err := sqlx.GetContext(ctx, q.db, &blocked, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("blocked date %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &blocked, nil
+}
+
+// Delete removes a blocked range by ID
+func (q *BlockedDateQ) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM blocked_dates WHERE id = $1`
+	args := []interface{}{id}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope
+	args = append(args, scopeArgs...)
+
+	result, err := q.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("blocked date %w", data.ErrNotFound)
+	}
+
+	return nil
+}
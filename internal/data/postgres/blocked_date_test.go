@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlockedDateTestDB(t *testing.T) (*BlockedDateQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	blockedDateQ := NewBlockedDateQ(sqlxDB).(*BlockedDateQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return blockedDateQ, mock, teardown
+}
+
+func TestBlockedDateQ_Create(t *testing.T) {
+	start := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	restaurantID := uuid.New()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "successful create",
+			ctx:  context.Background(),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO blocked_dates \(id, start_date, end_date, reason, created_at\)`).
+					WithArgs(sqlmock.AnyArg(), start, end, "New Year", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "restaurant scoped create",
+			ctx:  tenant.WithRestaurant(context.Background(), restaurantID),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO blocked_dates \(id, start_date, end_date, reason, restaurant_id, created_at\)`).
+					WithArgs(sqlmock.AnyArg(), start, end, "New Year", restaurantID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "database error",
+			ctx:  context.Background(),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO blocked_dates`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockedDateQ, mock, teardown := setupBlockedDateTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			blocked := &types.BlockedDate{StartDate: start, EndDate: end, Reason: "New Year"}
+			err := blockedDateQ.Create(tt.ctx, blocked)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, blocked.ID)
+				assert.False(t, blocked.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBlockedDateQ_GetInRange(t *testing.T) {
+	blockedDateQ, mock, teardown := setupBlockedDateTestDB(t)
+	defer teardown()
+
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "start_date", "end_date", "reason", "created_at"}).
+		AddRow(uuid.New(), time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC), "Renovation", time.Now()).
+		AddRow(uuid.New(), time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "Christmas", time.Now())
+	mock.ExpectQuery(`SELECT id, start_date, end_date, reason, created_at FROM blocked_dates WHERE start_date <= \$2 AND end_date >= \$1 ORDER BY start_date, end_date`).
+		WithArgs("2025-12-01", "2025-12-31").
+		WillReturnRows(rows)
+
+	blocked, err := blockedDateQ.GetInRange(context.Background(), from, to)
+
+	require.NoError(t, err)
+	require.Len(t, blocked, 2)
+	assert.Equal(t, "Renovation", blocked[0].Reason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBlockedDateQ_GetByDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantReason  string
+		errNotFound bool
+	}{
+		{
+			name: "blocked date",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "start_date", "end_date", "reason", "created_at"}).
+					AddRow(uuid.New(), time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 26, 0, 0, 0, 0, time.UTC), "Christmas", time.Now())
+				mock.ExpectQuery(`SELECT id, start_date, end_date, reason, created_at FROM blocked_dates WHERE start_date <= \$1 AND end_date >= \$1 ORDER BY start_date LIMIT 1`).
+					WithArgs("2025-12-26").
+					WillReturnRows(rows)
+			},
+			wantReason: "Christmas",
+		},
+		{
+			name: "date not blocked",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, start_date, end_date, reason, created_at FROM blocked_dates`).
+					WithArgs("2025-12-26").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "start_date", "end_date", "reason", "created_at"}))
+			},
+			errNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockedDateQ, mock, teardown := setupBlockedDateTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			blocked, err := blockedDateQ.GetByDate(context.Background(), "2025-12-26")
+
+			if tt.errNotFound {
+				assert.True(t, errors.Is(err, data.ErrNotFound))
+				assert.Nil(t, blocked)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantReason, blocked.Reason)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBlockedDateQ_Delete(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errNotFound bool
+	}{
+		{
+			name: "successful delete",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM blocked_dates WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "blocked date not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM blocked_dates WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockedDateQ, mock, teardown := setupBlockedDateTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := blockedDateQ.Delete(context.Background(), id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
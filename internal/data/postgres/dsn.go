@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const statementTimeoutParam = "statement_timeout"
+
+// WithStatementTimeout sets statement_timeout on the connections opened with dsn, so Postgres itself
+// aborts statements running longer than timeout. Both URL and key=value connection strings are accepted;
+// a statement_timeout already present in dsn is replaced
+func WithStatementTimeout(dsn string, timeout time.Duration) (string, error) {
+	value := strconv.FormatInt(timeout.Milliseconds(), 10)
+
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return strings.TrimSpace(dsn + " " + statementTimeoutParam + "=" + value), nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse database url")
+	}
+
+	query := u.Query()
+	query.Set(statementTimeoutParam, value)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
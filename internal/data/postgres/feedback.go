@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// FeedbackQ implements data.FeedbackQ interface
+type FeedbackQ struct {
+	db sqlxExt
+}
+
+// NewFeedbackQ creates a new FeedbackQ instance
+func NewFeedbackQ(db sqlxExt) data.FeedbackQ {
+	return &FeedbackQ{db: db}
+}
+
+// Create stores the feedback on a reservation. Feedback can only be left on a reservation
+// of the restaurant ctx is scoped to
+func (q *FeedbackQ) Create(ctx context.Context, feedback *types.Feedback) error {
+	query := `
+		INSERT INTO reservation_feedback (id, reservation_id, rating, comment, created_at)
+		SELECT $1, id, $3, $4, $5
+		FROM reservations
+		WHERE id = $2
+	`
+
+	if feedback.ID == uuid.Nil {
+		feedback.ID = uuid.New()
+	}
+
+	if feedback.CreatedAt.IsZero() {
+		feedback.CreatedAt = time.Now()
+	}
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 6)
+	args = append([]interface{}{feedback.ID, feedback.ReservationID, feedback.Rating, feedback.Comment, feedback.CreatedAt}, args...)
+	result, err := q.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_reservation_feedback_reservation_id" {
+			return fmt.Errorf("feedback %w", data.ErrConflict)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reservation %w", data.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetByReservationID retrieves the feedback on a reservation
+func (q *FeedbackQ) GetByReservationID(ctx context.Context, reservationID uuid.UUID) (*types.Feedback, error) {
+	query := `
+		SELECT f.id, f.reservation_id, f.rating, f.comment, f.created_at
+		FROM reservation_feedback f
+		JOIN reservations r ON r.id = f.reservation_id
+		WHERE f.reservation_id = $1
+	`
+
+	scope, args := restaurantScope(ctx, "r.restaurant_id", 2)
+	var feedback types.Feedback
+	if err := sqlx.GetContext(ctx, q.db, &feedback, query+scope, append([]interface{}{reservationID}, args...)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("feedback %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &feedback, nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFeedbackTestDB(t *testing.T) (*FeedbackQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	feedbackQ := NewFeedbackQ(sqlxDB).(*FeedbackQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return feedbackQ, mock, teardown
+}
+
+func TestFeedbackQ_Create(t *testing.T) {
+	reservationID := uuid.New()
+	comment := "Lovely evening"
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errConflict bool
+		errNotFound bool
+	}{
+		{
+			name: "successful create",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_feedback`).
+					WithArgs(sqlmock.AnyArg(), reservationID, 5, &comment, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "reservation of another restaurant",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_feedback`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+		{
+			name: "reservation already rated",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_feedback`).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_reservation_feedback_reservation_id"})
+			},
+			wantErr:     true,
+			errConflict: true,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_feedback`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedbackQ, mock, teardown := setupFeedbackTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			feedback := &types.Feedback{ReservationID: reservationID, Rating: 5, Comment: &comment}
+			err := feedbackQ.Create(context.Background(), feedback)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errConflict, errors.Is(err, data.ErrConflict))
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, feedback.ID)
+				assert.False(t, feedback.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFeedbackQ_GetByReservationID(t *testing.T) {
+	reservationID := uuid.New()
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errNotFound bool
+	}{
+		{
+			name: "feedback found",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "reservation_id", "rating", "comment", "created_at"}).
+					AddRow(uuid.New(), reservationID, 4, nil, time.Now())
+				mock.ExpectQuery(`SELECT f.id, f.reservation_id, f.rating, f.comment, f.created_at FROM reservation_feedback f JOIN reservations r ON r.id = f.reservation_id WHERE f.reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "no feedback",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservation_feedback f JOIN reservations r.*WHERE f.reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "reservation_id", "rating", "comment", "created_at"}))
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedbackQ, mock, teardown := setupFeedbackTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := feedbackQ.GetByReservationID(context.Background(), reservationID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 4, got.Rating)
+				assert.Nil(t, got.Comment)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,25 +1,35 @@
 package postgres
 
 import (
-	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"context"
+	"time"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
 )
 
 // Master implements the MasterQ interface
 type Master struct {
-	db *sqlx.DB
+	db  sqlxExt
+	loc *time.Location
 
-	userQ        data.UserQ
-	reservationQ data.ReservationQ
-	tableQ       data.TableQ
-	reportsQ     data.ReportsQ
+	userQ          data.UserQ
+	reservationQ   data.ReservationQ
+	tableQ         data.TableQ
+	reportsQ       data.ReportsQ
+	statusHistoryQ data.StatusHistoryQ
+	restaurantQ    data.RestaurantQ
+	webhookQ       data.WebhookQ
+	blockedDateQ   data.BlockedDateQ
+	specialHoursQ  data.SpecialHoursQ
+	feedbackQ      data.FeedbackQ
 }
 
-// NewMaster creates a new Master instance
-func NewMaster(db *sqlx.DB) data.MasterQ {
+// NewMaster creates a new Master instance on a database or a transaction. loc is the restaurant
+// timezone reservation dates and times are given in; nil means server local time
+func NewMaster(db sqlxExt, loc *time.Location) data.MasterQ {
 	return &Master{
-		db: db,
+		db:  db,
+		loc: loc,
 	}
 }
 
@@ -34,7 +44,7 @@ func (m *Master) UserQ() data.UserQ {
 // ReservationQ returns the reservation query interface
 func (m *Master) ReservationQ() data.ReservationQ {
 	if m.reservationQ == nil {
-		m.reservationQ = NewReservationQ(m.db)
+		m.reservationQ = NewReservationQ(m.db, m.loc)
 	}
 	return m.reservationQ
 }
@@ -50,7 +60,64 @@ func (m *Master) TableQ() data.TableQ {
 // ReportsQ returns the reports query interface
 func (m *Master) ReportsQ() data.ReportsQ {
 	if m.reportsQ == nil {
-		m.reportsQ = NewReportsQ(m.db)
+		m.reportsQ = NewReportsQ(m.db, m.loc)
 	}
 	return m.reportsQ
 }
+
+// StatusHistoryQ returns the reservation status history query interface
+func (m *Master) StatusHistoryQ() data.StatusHistoryQ {
+	if m.statusHistoryQ == nil {
+		m.statusHistoryQ = NewStatusHistoryQ(m.db)
+	}
+	return m.statusHistoryQ
+}
+
+// RestaurantQ returns the restaurant query interface
+func (m *Master) RestaurantQ() data.RestaurantQ {
+	if m.restaurantQ == nil {
+		m.restaurantQ = NewRestaurantQ(m.db)
+	}
+	return m.restaurantQ
+}
+
+// WebhookQ returns the webhook query interface
+func (m *Master) WebhookQ() data.WebhookQ {
+	if m.webhookQ == nil {
+		m.webhookQ = NewWebhookQ(m.db)
+	}
+	return m.webhookQ
+}
+
+// BlockedDateQ returns the blocked date query interface
+func (m *Master) BlockedDateQ() data.BlockedDateQ {
+	if m.blockedDateQ == nil {
+		m.blockedDateQ = NewBlockedDateQ(m.db)
+	}
+	return m.blockedDateQ
+}
+
+// SpecialHoursQ returns the special hours query interface
+func (m *Master) SpecialHoursQ() data.SpecialHoursQ {
+	if m.specialHoursQ == nil {
+		m.specialHoursQ = NewSpecialHoursQ(m.db)
+	}
+	return m.specialHoursQ
+}
+
+// FeedbackQ returns the reservation feedback query interface
+func (m *Master) FeedbackQ() data.FeedbackQ {
+	if m.feedbackQ == nil {
+		m.feedbackQ = NewFeedbackQ(m.db)
+	}
+	return m.feedbackQ
+}
+
+// Transaction runs fn with a master whose query objects all use a single transaction. The transaction
+// is committed when fn succeeds and rolled back when it returns an error. Calling Transaction on a
+// transaction-scoped master joins the transaction in progress
+func (m *Master) Transaction(ctx context.Context, fn func(tx data.MasterQ) error) error {
+	return inTx(ctx, m.db, func(tx sqlxExt) error {
+		return fn(NewMaster(tx, m.loc))
+	})
+}
@@ -1,9 +1,13 @@
 package postgres
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,13 +20,16 @@ func TestNewMaster(t *testing.T) {
 	defer db.Close()
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	master := NewMaster(sqlxDB)
+	master := NewMaster(sqlxDB, nil)
 
 	assert.NotNil(t, master)
 	assert.NotNil(t, master.UserQ())
 	assert.NotNil(t, master.ReservationQ())
 	assert.NotNil(t, master.TableQ())
 	assert.NotNil(t, master.ReportsQ())
+	assert.NotNil(t, master.StatusHistoryQ())
+	assert.NotNil(t, master.RestaurantQ())
+	assert.NotNil(t, master.WebhookQ())
 }
 
 func TestMaster_UserQ(t *testing.T) {
@@ -33,7 +40,7 @@ func TestMaster_UserQ(t *testing.T) {
 	defer db.Close()
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	master := NewMaster(sqlxDB).(*Master)
+	master := NewMaster(sqlxDB, nil).(*Master)
 
 	userQ1 := master.UserQ()
 	userQ2 := master.UserQ()
@@ -50,7 +57,7 @@ func TestMaster_ReservationQ(t *testing.T) {
 	defer db.Close()
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	master := NewMaster(sqlxDB).(*Master)
+	master := NewMaster(sqlxDB, nil).(*Master)
 
 	reservationQ1 := master.ReservationQ()
 	reservationQ2 := master.ReservationQ()
@@ -67,7 +74,7 @@ func TestMaster_TableQ(t *testing.T) {
 	defer db.Close()
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	master := NewMaster(sqlxDB).(*Master)
+	master := NewMaster(sqlxDB, nil).(*Master)
 
 	tableQ1 := master.TableQ()
 	tableQ2 := master.TableQ()
@@ -84,7 +91,7 @@ func TestMaster_ReportsQ(t *testing.T) {
 	defer db.Close()
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	master := NewMaster(sqlxDB).(*Master)
+	master := NewMaster(sqlxDB, nil).(*Master)
 
 	reportsQ1 := master.ReportsQ()
 	reportsQ2 := master.ReportsQ()
@@ -93,3 +100,118 @@ func TestMaster_ReportsQ(t *testing.T) {
 	assert.Equal(t, reportsQ1, reportsQ2)
 }
 
+
+func TestMaster_StatusHistoryQ(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	master := NewMaster(sqlxDB, nil).(*Master)
+
+	statusHistoryQ1 := master.StatusHistoryQ()
+	statusHistoryQ2 := master.StatusHistoryQ()
+
+	// Should return the same instance (lazy initialization)
+	assert.Equal(t, statusHistoryQ1, statusHistoryQ2)
+}
+
+func TestMaster_RestaurantQ(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	master := NewMaster(sqlxDB, nil).(*Master)
+
+	restaurantQ1 := master.RestaurantQ()
+	restaurantQ2 := master.RestaurantQ()
+
+	// Should return the same instance (lazy initialization)
+	assert.Equal(t, restaurantQ1, restaurantQ2)
+}
+
+func TestMaster_Transaction(t *testing.T) {
+	reservationID := uuid.New()
+	errAborted := errors.New("aborted")
+
+	tests := []struct {
+		name    string
+		fn      func(tx data.MasterQ) error
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "commits when fn succeeds",
+			fn: func(tx data.MasterQ) error {
+				return tx.ReservationQ().SetTables(context.Background(), reservationID, nil)
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				// SetTables joins the transaction instead of beginning its own
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "rolls back when fn fails",
+			fn: func(tx data.MasterQ) error {
+				if err := tx.ReservationQ().SetTables(context.Background(), reservationID, nil); err != nil {
+					return err
+				}
+				return errAborted
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: errAborted,
+		},
+		{
+			name: "nested transaction joins the outer one",
+			fn: func(tx data.MasterQ) error {
+				return tx.Transaction(context.Background(), func(nested data.MasterQ) error {
+					return nested.ReservationQ().SetTables(context.Background(), reservationID, nil)
+				})
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			tt.mock(mock)
+
+			master := NewMaster(sqlx.NewDb(db, "postgres"), nil)
+			err = master.Transaction(context.Background(), tt.fn)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,18 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/data"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
 type ReportsQ struct {
-	db *sqlx.DB
+	db  sqlxExt
+	loc *time.Location
 }
 
-func NewReportsQ(db *sqlx.DB) data.ReportsQ {
-	return &ReportsQ{db: db}
+// NewReportsQ creates a new ReportsQ instance. Reports relative to today use the
+// date in loc, the restaurant timezone; nil means server local time
+func NewReportsQ(db sqlxExt, loc *time.Location) data.ReportsQ {
+	return &ReportsQ{db: db, loc: loc}
 }
 
 //
@@ -24,7 +31,7 @@ func NewReportsQ(db *sqlx.DB) data.ReportsQ {
 // ────────────────────────────────────────────────────────────────
 //
 
-func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlyStats, error) {
+func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context, filters *types.MonthlyStatsFilters) ([]*types.MonthlyStats, error) {
 	query := `
 		SELECT 
 			TO_CHAR(date, 'YYYY-MM') AS month,
@@ -33,10 +40,35 @@ func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlySta
 			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations,
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) * 50.0, 0) AS revenue
 		FROM reservations
-		GROUP BY TO_CHAR(date, 'YYYY-MM')
-		ORDER BY month DESC
+		WHERE deleted_at IS NULL
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 1)
+	query += scope
+	argPos := len(args) + 1
+
+	if filters != nil {
+		if filters.From != nil {
+			query += fmt.Sprintf(" AND date >= $%d::date", argPos)
+			args = append(args, filters.From.Format("2006-01-02"))
+			argPos++
+		}
+
+		// To is inclusive, so every day of its month is counted
+		if filters.To != nil {
+			query += fmt.Sprintf(" AND date < $%d::date", argPos)
+			args = append(args, filters.To.AddDate(0, 1, 0).Format("2006-01-02"))
+			argPos++
+		}
+	}
+
+	query += " GROUP BY TO_CHAR(date, 'YYYY-MM') ORDER BY month DESC"
+
+	if filters != nil && filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argPos)
+		args = append(args, filters.Limit)
+	}
+
 	type result struct {
 		Month                 string  `db:"month"`
 		TotalReservations     int     `db:"total_reservations"`
@@ -46,7 +78,7 @@ func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlySta
 	}
 
 	var results []result
-	err := q.db.SelectContext(ctx, &results, query)
+	err := sqlx.SelectContext(ctx, q.db, &results, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +97,111 @@ func (q *ReportsQ) GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlySta
 	return stats, nil
 }
 
+// GetYearlyStats retrieves statistics aggregated per year, most recent first
+func (q *ReportsQ) GetYearlyStats(ctx context.Context) ([]*types.YearlyStats, error) {
+	query := `
+		SELECT
+			TO_CHAR(date, 'YYYY') AS year,
+			COUNT(*) AS total_reservations,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed_reservations,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) * 50.0, 0) AS revenue
+		FROM reservations
+		WHERE deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 1)
+	query += scope + " GROUP BY TO_CHAR(date, 'YYYY') ORDER BY year DESC"
+
+	type result struct {
+		Year                  string  `db:"year"`
+		TotalReservations     int     `db:"total_reservations"`
+		CompletedReservations int     `db:"completed_reservations"`
+		CancelledReservations int     `db:"cancelled_reservations"`
+		Revenue               float64 `db:"revenue"`
+	}
+
+	var results []result
+	err := sqlx.SelectContext(ctx, q.db, &results, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*types.YearlyStats, len(results))
+	for i, r := range results {
+		stats[i] = &types.YearlyStats{
+			Year:                  r.Year,
+			TotalReservations:     r.TotalReservations,
+			CompletedReservations: r.CompletedReservations,
+			CancelledReservations: r.CancelledReservations,
+			Revenue:               r.Revenue,
+		}
+	}
+
+	return stats, nil
+}
+
+//
+// ────────────────────────────────────────────────────────────────
+//   WEEKLY OVERVIEW
+// ────────────────────────────────────────────────────────────────
+//
+
+// GetWeeklyStats retrieves statistics aggregated per ISO week, oldest first.
+// Weeks are labeled IYYY-IW and start on Monday
+func (q *ReportsQ) GetWeeklyStats(ctx context.Context, dateFrom, dateTo time.Time) ([]*types.WeeklyStats, error) {
+	if dateTo.Before(dateFrom) {
+		return nil, errors.New("invalid date range")
+	}
+
+	query := `
+		SELECT
+			TO_CHAR(date, 'IYYY-IW') AS week,
+			TO_CHAR(DATE_TRUNC('week', date), 'YYYY-MM-DD') AS week_start,
+			COUNT(*) AS total_reservations,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed_reservations,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) * 50.0, 0) AS revenue
+		FROM reservations
+		WHERE date >= $1::date
+		  AND date <= $2::date
+		  AND deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	query += scope + " GROUP BY TO_CHAR(date, 'IYYY-IW'), DATE_TRUNC('week', date) ORDER BY week_start"
+
+	type result struct {
+		Week                  string  `db:"week"`
+		WeekStart             string  `db:"week_start"`
+		TotalReservations     int     `db:"total_reservations"`
+		CompletedReservations int     `db:"completed_reservations"`
+		CancelledReservations int     `db:"cancelled_reservations"`
+		Revenue               float64 `db:"revenue"`
+	}
+
+	var results []result
+	args = append([]interface{}{dateFrom.Format("2006-01-02"), dateTo.Format("2006-01-02")}, args...)
+	err := sqlx.SelectContext(ctx, q.db, &results, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*types.WeeklyStats, len(results))
+	for i, r := range results {
+		stats[i] = &types.WeeklyStats{
+			Week:                  r.Week,
+			WeekStart:             r.WeekStart,
+			TotalReservations:     r.TotalReservations,
+			CompletedReservations: r.CompletedReservations,
+			CancelledReservations: r.CancelledReservations,
+			Revenue:               r.Revenue,
+		}
+	}
+
+	return stats, nil
+}
+
 //
 // ────────────────────────────────────────────────────────────────
 //   MONTHLY DETAILS (POPULAR TABLES + PEAK HOURS)
@@ -78,6 +215,8 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 	}
 
 	startDate := month + "-01"
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	args = append([]interface{}{startDate}, args...)
 
 	//
 	// ─── BASIC STATS ──────────────────────────────────────────────
@@ -89,11 +228,13 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
             COUNT(*) AS total_reservations,
             COUNT(*) FILTER (WHERE status = 'completed') AS completed_reservations,
             COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations,
-            COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) * 50.0, 0) AS revenue
+            COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) * 50.0, 0) AS revenue,
+            COALESCE(AVG(guests) FILTER (WHERE status = 'completed'), 0) AS average_party_size,
+            COUNT(*) FILTER (WHERE status = 'no_show') AS no_show_count
         FROM reservations
         WHERE date >= $1::date
           AND date < ($1::date + INTERVAL '1 month')
-        GROUP BY TO_CHAR(date, 'YYYY-MM')
+          AND deleted_at IS NULL
     `
 
 	type statsResult struct {
@@ -102,17 +243,45 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 		CompletedReservations int     `db:"completed_reservations"`
 		CancelledReservations int     `db:"cancelled_reservations"`
 		Revenue               float64 `db:"revenue"`
+		AveragePartySize      float64 `db:"average_party_size"`
+		NoShowCount           int     `db:"no_show_count"`
 	}
 
 	var stats statsResult
-	err := q.db.GetContext(ctx, &stats, statsQuery, startDate)
+	err := sqlx.GetContext(ctx, q.db, &stats, statsQuery+scope+" GROUP BY TO_CHAR(date, 'YYYY-MM')", args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("statistics for this month not found")
+			return nil, fmt.Errorf("statistics for this month %w", data.ErrNotFound)
 		}
 		return nil, err
 	}
 
+	//
+	// ─── RATINGS ────────────────────────────────────────────────────
+	//
+
+	ratingsQuery := `
+        SELECT
+            ROUND(AVG(f.rating), 2)::float8 AS average_rating,
+            COUNT(f.id) AS rating_count
+        FROM reservation_feedback f
+        JOIN reservations ON reservations.id = f.reservation_id
+        WHERE date >= $1::date
+          AND date < ($1::date + INTERVAL '1 month')
+          AND deleted_at IS NULL
+    `
+
+	type ratingsResult struct {
+		AverageRating *float64 `db:"average_rating"`
+		RatingCount   int      `db:"rating_count"`
+	}
+
+	var ratings ratingsResult
+	err = sqlx.GetContext(ctx, q.db, &ratings, ratingsQuery+scope, args...)
+	if err != nil {
+		return nil, err
+	}
+
 	//
 	// ─── POPULAR TABLES ─────────────────────────────────────────────
 	//
@@ -120,23 +289,24 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 	popularTablesQuery := `
         SELECT 
             table_number,
-            COUNT(*) AS count
+            COUNT(*) AS count,
+            ROUND(AVG(f.rating), 2)::float8 AS average_rating
         FROM reservations
+        LEFT JOIN reservation_feedback f ON f.reservation_id = reservations.id
         WHERE date >= $1::date
           AND date < ($1::date + INTERVAL '1 month')
+          AND deleted_at IS NULL
           AND status = 'completed'
-        GROUP BY table_number
-        ORDER BY count DESC
-        LIMIT 10
     `
 
 	type popularTableResult struct {
-		TableNumber string `db:"table_number"`
-		Count       int    `db:"count"`
+		TableNumber   string   `db:"table_number"`
+		Count         int      `db:"count"`
+		AverageRating *float64 `db:"average_rating"`
 	}
 
 	var popularTables []popularTableResult
-	err = q.db.SelectContext(ctx, &popularTables, popularTablesQuery, startDate)
+	err = sqlx.SelectContext(ctx, q.db, &popularTables, popularTablesQuery+scope+" GROUP BY table_number ORDER BY count DESC LIMIT 10", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +314,9 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 	//
 	// ─── PEAK HOURS — FIXED WITH HH:MI FORMAT ──────────────────────
 	//
+	// Times hold the restaurant wall clock, so they are grouped as stored
+	// without any conversion to the database session timezone
+	//
 
 	peakHoursQuery := `
         SELECT 
@@ -152,10 +325,8 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
         FROM reservations
         WHERE date >= $1::date
           AND date < ($1::date + INTERVAL '1 month')
+          AND deleted_at IS NULL
           AND status = 'completed'
-        GROUP BY TO_CHAR(time, 'HH24:MI')
-        ORDER BY count DESC
-        LIMIT 10
     `
 
 	type peakHourResult struct {
@@ -164,7 +335,7 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 	}
 
 	var peakHours []peakHourResult
-	err = q.db.SelectContext(ctx, &peakHours, peakHoursQuery, startDate)
+	err = sqlx.SelectContext(ctx, q.db, &peakHours, peakHoursQuery+scope+" GROUP BY TO_CHAR(time, 'HH24:MI') ORDER BY count DESC LIMIT 10", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -181,14 +352,19 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 			CancelledReservations: stats.CancelledReservations,
 			Revenue:               stats.Revenue,
 		},
-		PopularTables: make([]types.PopularTable, len(popularTables)),
-		PeakHours:     make([]types.PeakHour, len(peakHours)),
+		AveragePartySize: stats.AveragePartySize,
+		NoShowCount:      stats.NoShowCount,
+		AverageRating:    ratings.AverageRating,
+		RatingCount:      ratings.RatingCount,
+		PopularTables:    make([]types.PopularTable, len(popularTables)),
+		PeakHours:        make([]types.PeakHour, len(peakHours)),
 	}
 
 	for i, pt := range popularTables {
 		detailedStats.PopularTables[i] = types.PopularTable{
-			TableNumber: pt.TableNumber,
-			Count:       pt.Count,
+			TableNumber:   pt.TableNumber,
+			Count:         pt.Count,
+			AverageRating: pt.AverageRating,
 		}
 	}
 
@@ -201,3 +377,152 @@ func (q *ReportsQ) GetDetailedMonthlyStats(ctx context.Context, month string) (*
 
 	return detailedStats, nil
 }
+
+//
+// ────────────────────────────────────────────────────────────────
+//   PER-USER STATISTICS
+// ────────────────────────────────────────────────────────────────
+//
+
+func (q *ReportsQ) GetUserStats(ctx context.Context, userID uuid.UUID) (*types.UserStats, error) {
+	query := `
+		SELECT
+			COUNT(*) AS total_reservations,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed_reservations,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations,
+			COUNT(*) FILTER (WHERE status = 'no_show') AS no_show_reservations,
+			MAX(date) AS last_booking_date
+		FROM reservations
+		WHERE user_id = $1
+		  AND deleted_at IS NULL
+	`
+
+	type result struct {
+		TotalReservations     int        `db:"total_reservations"`
+		CompletedReservations int        `db:"completed_reservations"`
+		CancelledReservations int        `db:"cancelled_reservations"`
+		NoShowReservations    int        `db:"no_show_reservations"`
+		LastBookingDate       *time.Time `db:"last_booking_date"`
+	}
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	var r result
+	err := sqlx.GetContext(ctx, q.db, &r, query+scope, append([]interface{}{userID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UserStats{
+		UserID:                userID,
+		TotalReservations:     r.TotalReservations,
+		CompletedReservations: r.CompletedReservations,
+		CancelledReservations: r.CancelledReservations,
+		NoShowReservations:    r.NoShowReservations,
+		RepeatNoShow:          r.NoShowReservations >= types.RepeatNoShowThreshold,
+		LastBookingDate:       r.LastBookingDate,
+	}, nil
+}
+
+//
+// ────────────────────────────────────────────────────────────────
+//   TABLE OCCUPANCY
+// ────────────────────────────────────────────────────────────────
+//
+
+func (q *ReportsQ) GetTableOccupancy(ctx context.Context, dateFrom, dateTo time.Time, slots int) ([]*types.TableOccupancy, error) {
+	if dateTo.Before(dateFrom) {
+		return nil, errors.New("invalid date range")
+	}
+
+	query := `
+		SELECT
+			t.number AS table_number,
+			COUNT(r.id) AS reservations
+		FROM tables t
+		LEFT JOIN reservations r
+			ON r.table_number = t.number
+			AND r.restaurant_id = t.restaurant_id
+			AND r.date >= $1::date
+			AND r.date <= $2::date
+			AND r.status IN ('confirmed', 'completed')
+			AND r.deleted_at IS NULL
+	`
+
+	scope, args := restaurantWhere(ctx, "t.restaurant_id", 3)
+	query += scope + " GROUP BY t.number ORDER BY t.number"
+
+	type result struct {
+		TableNumber  string `db:"table_number"`
+		Reservations int    `db:"reservations"`
+	}
+
+	var results []result
+	args = append([]interface{}{dateFrom.Format("2006-01-02"), dateTo.Format("2006-01-02")}, args...)
+	err := sqlx.SelectContext(ctx, q.db, &results, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// A range the restaurant is closed throughout has no rate to report
+	occupancy := make([]*types.TableOccupancy, len(results))
+	for i, r := range results {
+		occupancy[i] = &types.TableOccupancy{
+			TableNumber:  r.TableNumber,
+			Reservations: r.Reservations,
+		}
+		if slots > 0 {
+			occupancy[i].OccupancyRate = math.Round(float64(r.Reservations)/float64(slots)*10000) / 100
+		}
+	}
+
+	return occupancy, nil
+}
+
+//
+// ────────────────────────────────────────────────────────────────
+//   TODAY SNAPSHOT
+// ────────────────────────────────────────────────────────────────
+//
+
+// GetTodaySnapshot counts today's reservations per status. The date is taken from
+// the restaurant wall clock rather than CURRENT_DATE, so it follows the restaurant timezone
+// even when the database session uses another one. Cancelled reservations add no covers
+func (q *ReportsQ) GetTodaySnapshot(ctx context.Context) (*types.TodaySnapshot, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'confirmed') AS confirmed,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled,
+			COALESCE(SUM(guests) FILTER (WHERE status IN ('pending', 'confirmed', 'completed')), 0) AS covers
+		FROM reservations
+		WHERE date = $1::date
+		  AND deleted_at IS NULL
+	`
+
+	type result struct {
+		Pending   int `db:"pending"`
+		Confirmed int `db:"confirmed"`
+		Completed int `db:"completed"`
+		Cancelled int `db:"cancelled"`
+		Covers    int `db:"covers"`
+	}
+
+	today := restaurantNow(q.loc).Format("2006-01-02")
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+
+	var r result
+	err := sqlx.GetContext(ctx, q.db, &r, query+scope, append([]interface{}{today}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TodaySnapshot{
+		Date:      today,
+		Pending:   r.Pending,
+		Confirmed: r.Confirmed,
+		Completed: r.Completed,
+		Cancelled: r.Cancelled,
+		Covers:    r.Covers,
+	}, nil
+}
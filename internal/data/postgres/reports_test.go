@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +21,7 @@ func setupReportsTestDB(t *testing.T) (*ReportsQ, sqlmock.Sqlmock, func()) {
 	}
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	reportsQ := NewReportsQ(sqlxDB).(*ReportsQ)
+	reportsQ := NewReportsQ(sqlxDB, nil).(*ReportsQ)
 
 	teardown := func() {
 		db.Close()
@@ -29,8 +31,12 @@ func setupReportsTestDB(t *testing.T) (*ReportsQ, sqlmock.Sqlmock, func()) {
 }
 
 func TestReportsQ_GetMonthlyStatsList(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name    string
+		filters *types.MonthlyStatsFilters
 		mock    func(mock sqlmock.Sqlmock)
 		want    int
 		wantErr bool
@@ -47,6 +53,19 @@ func TestReportsQ_GetMonthlyStatsList(t *testing.T) {
 			want:    2,
 			wantErr: false,
 		},
+		{
+			name:    "month range and limit",
+			filters: &types.MonthlyStatsFilters{From: &from, To: &to, Limit: 24},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"month", "total_reservations", "completed_reservations", "cancelled_reservations", "revenue"}).
+					AddRow("2025-12", 10, 8, 1, 400.0)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND date >= \$1::date AND date < \$2::date GROUP BY.*ORDER BY month DESC LIMIT \$3`).
+					WithArgs("2025-01-01", "2026-01-01", 24).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
 		{
 			name: "empty result",
 			mock: func(mock sqlmock.Sqlmock) {
@@ -76,7 +95,7 @@ func TestReportsQ_GetMonthlyStatsList(t *testing.T) {
 			tt.mock(mock)
 
 			ctx := context.Background()
-			got, err := reportsQ.GetMonthlyStatsList(ctx)
+			got, err := reportsQ.GetMonthlyStatsList(ctx, tt.filters)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -100,6 +119,151 @@ func TestReportsQ_GetMonthlyStatsList(t *testing.T) {
 	}
 }
 
+func TestReportsQ_GetYearlyStats(t *testing.T) {
+	columns := []string{"year", "total_reservations", "completed_reservations", "cancelled_reservations", "revenue"}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "successful get yearly stats",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("2025", 120, 100, 10, 5000.0).
+					AddRow("2024", 80, 70, 5, 3500.0)
+				mock.ExpectQuery(`SELECT\s+TO_CHAR\(date, 'YYYY'\) AS year.*FROM reservations.*GROUP BY TO_CHAR\(date, 'YYYY'\)\s+ORDER BY year DESC`).
+					WillReturnRows(rows)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "empty result",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservations.*GROUP BY.*ORDER BY year DESC`).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservations.*GROUP BY.*ORDER BY year DESC`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportsQ, mock, teardown := setupReportsTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reportsQ.GetYearlyStats(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, got)
+				assert.Len(t, got, tt.want)
+				if tt.want > 0 {
+					assert.Equal(t, "2025", got[0].Year)
+					assert.Equal(t, 120, got[0].TotalReservations)
+					assert.Equal(t, 100, got[0].CompletedReservations)
+					assert.Equal(t, 10, got[0].CancelledReservations)
+					assert.Equal(t, 5000.0, got[0].Revenue)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportsQ_GetWeeklyStats(t *testing.T) {
+	dateFrom := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	dateTo := time.Date(2025, 12, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		mock    func(mock sqlmock.Sqlmock)
+		want    []*types.WeeklyStats
+		wantErr bool
+	}{
+		{
+			name: "successful get weekly stats",
+			from: dateFrom,
+			to:   dateTo,
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"week", "week_start", "total_reservations", "completed_reservations", "cancelled_reservations", "revenue"}).
+					AddRow("2025-49", "2025-12-01", 12, 8, 2, 400.0).
+					AddRow("2025-50", "2025-12-08", 9, 6, 1, 300.0)
+				mock.ExpectQuery(`SELECT\s+TO_CHAR\(date, 'IYYY-IW'\) AS week.*GROUP BY TO_CHAR\(date, 'IYYY-IW'\)`).
+					WithArgs("2025-12-01", "2025-12-14").
+					WillReturnRows(rows)
+			},
+			want: []*types.WeeklyStats{
+				{Week: "2025-49", WeekStart: "2025-12-01", TotalReservations: 12, CompletedReservations: 8, CancelledReservations: 2, Revenue: 400},
+				{Week: "2025-50", WeekStart: "2025-12-08", TotalReservations: 9, CompletedReservations: 6, CancelledReservations: 1, Revenue: 300},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid date range",
+			from:    dateTo,
+			to:      dateFrom,
+			mock:    func(mock sqlmock.Sqlmock) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "database error",
+			from: dateFrom,
+			to:   dateTo,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT\s+TO_CHAR\(date, 'IYYY-IW'\) AS week`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportsQ, mock, teardown := setupReportsTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reportsQ.GetWeeklyStats(ctx, tt.from, tt.to)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -114,26 +278,33 @@ func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 			month: "2025-12",
 			mock: func(mock sqlmock.Sqlmock) {
 				// Mock stats query
-				statsRows := sqlmock.NewRows([]string{"month", "total_reservations", "completed_reservations", "cancelled_reservations", "revenue"}).
-					AddRow("2025-12", 10, 8, 1, 400.0)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE date >= \$1::date AND date <= \$2::date.*GROUP BY`).
-					WithArgs("2025-12-01", "2025-12-31").
+				statsRows := sqlmock.NewRows([]string{"month", "total_reservations", "completed_reservations", "cancelled_reservations", "revenue", "average_party_size", "no_show_count"}).
+					AddRow("2025-12", 10, 8, 1, 400.0, 3.5, 1)
+				mock.ExpectQuery(`SELECT.*AVG\(guests\) FILTER \(WHERE status = 'completed'\).*FILTER \(WHERE status = 'no_show'\).*FROM reservations\s+WHERE date >= \$1::date\s+AND date < \(\$1::date \+ INTERVAL '1 month'\).*GROUP BY`).
+					WithArgs("2025-12-01").
 					WillReturnRows(statsRows)
 
+				// Mock ratings query
+				ratingsRows := sqlmock.NewRows([]string{"average_rating", "rating_count"}).
+					AddRow(4.25, 4)
+				mock.ExpectQuery(`SELECT\s+ROUND\(AVG\(f.rating\), 2\)::float8 AS average_rating,.*FROM reservation_feedback f\s+JOIN reservations ON reservations.id = f.reservation_id`).
+					WithArgs("2025-12-01").
+					WillReturnRows(ratingsRows)
+
 				// Mock popular tables query
-				popularTablesRows := sqlmock.NewRows([]string{"table_number", "count"}).
-					AddRow("T1", 5).
-					AddRow("T2", 3)
-				mock.ExpectQuery(`SELECT table_number, COUNT.*FROM reservations WHERE date >= \$1::date AND date <= \$2::date AND status = 'completed'.*GROUP BY table_number.*ORDER BY count DESC.*LIMIT 10`).
-					WithArgs("2025-12-01", "2025-12-31").
+				popularTablesRows := sqlmock.NewRows([]string{"table_number", "count", "average_rating"}).
+					AddRow("T1", 5, 4.5).
+					AddRow("T2", 3, nil)
+				mock.ExpectQuery(`SELECT\s+table_number,\s+COUNT.*AS average_rating\s+FROM reservations\s+LEFT JOIN reservation_feedback f ON f.reservation_id = reservations.id.*AND status = 'completed'\s+GROUP BY table_number\s+ORDER BY count DESC\s+LIMIT 10`).
+					WithArgs("2025-12-01").
 					WillReturnRows(popularTablesRows)
 
 				// Mock peak hours query
 				peakHoursRows := sqlmock.NewRows([]string{"hour", "count"}).
 					AddRow("19:00", 4).
 					AddRow("20:00", 3)
-				mock.ExpectQuery(`SELECT time AS hour, COUNT.*FROM reservations WHERE date >= \$1::date AND date <= \$2::date AND status = 'completed'.*GROUP BY time.*ORDER BY count DESC.*LIMIT 10`).
-					WithArgs("2025-12-01", "2025-12-31").
+				mock.ExpectQuery(`SELECT\s+TO_CHAR\(time, 'HH24:MI'\) AS hour,\s+COUNT.*FROM reservations.*AND status = 'completed'\s+GROUP BY TO_CHAR\(time, 'HH24:MI'\)\s+ORDER BY count DESC\s+LIMIT 10`).
+					WithArgs("2025-12-01").
 					WillReturnRows(peakHoursRows)
 			},
 			want: &types.DetailedMonthlyStats{
@@ -144,8 +315,12 @@ func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 					CancelledReservations: 1,
 					Revenue:               400.0,
 				},
+				AveragePartySize: 3.5,
+				NoShowCount:      1,
+				AverageRating:    func(v float64) *float64 { return &v }(4.25),
+				RatingCount:      4,
 				PopularTables: []types.PopularTable{
-					{TableNumber: "T1", Count: 5},
+					{TableNumber: "T1", Count: 5, AverageRating: func(v float64) *float64 { return &v }(4.5)},
 					{TableNumber: "T2", Count: 3},
 				},
 				PeakHours: []types.PeakHour{
@@ -161,14 +336,14 @@ func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 			mock:  func(mock sqlmock.Sqlmock) {},
 			want:  nil,
 			wantErr: true,
-			errMsg: "invalid month format, expected YYYY-MM",
+			errMsg: "invalid month format (expected YYYY-MM)",
 		},
 		{
 			name:  "month not found",
 			month: "2025-12",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE date >= \$1::date AND date <= \$2::date.*GROUP BY`).
-					WithArgs("2025-12-01", "2025-12-31").
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE date >= \$1::date.*GROUP BY`).
+					WithArgs("2025-12-01").
 					WillReturnError(sql.ErrNoRows)
 			},
 			want:    nil,
@@ -198,8 +373,12 @@ func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 				require.NotNil(t, got)
 				assert.Equal(t, tt.want.Month, got.Month)
 				assert.Equal(t, tt.want.TotalReservations, got.TotalReservations)
-				assert.Len(t, got.PopularTables, len(tt.want.PopularTables))
-				assert.Len(t, got.PeakHours, len(tt.want.PeakHours))
+				assert.Equal(t, tt.want.AveragePartySize, got.AveragePartySize)
+				assert.Equal(t, tt.want.NoShowCount, got.NoShowCount)
+				assert.Equal(t, tt.want.AverageRating, got.AverageRating)
+				assert.Equal(t, tt.want.RatingCount, got.RatingCount)
+				assert.Equal(t, tt.want.PopularTables, got.PopularTables)
+				assert.Equal(t, tt.want.PeakHours, got.PeakHours)
 			}
 
 			// Note: Complex query matching might not work perfectly with sqlmock
@@ -207,3 +386,257 @@ func TestReportsQ_GetDetailedMonthlyStats(t *testing.T) {
 	}
 }
 
+
+func TestReportsQ_GetUserStats(t *testing.T) {
+	userID := uuid.New()
+	lastBooking := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    *types.UserStats
+		wantErr bool
+	}{
+		{
+			name: "successful get user stats",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"total_reservations", "completed_reservations", "cancelled_reservations", "no_show_reservations", "last_booking_date"}).
+					AddRow(5, 3, 1, 1, lastBooking)
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnRows(rows)
+			},
+			want: &types.UserStats{
+				UserID:                userID,
+				TotalReservations:     5,
+				CompletedReservations: 3,
+				CancelledReservations: 1,
+				NoShowReservations:    1,
+				LastBookingDate:       &lastBooking,
+			},
+			wantErr: false,
+		},
+		{
+			name: "repeat no-show user is flagged",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"total_reservations", "completed_reservations", "cancelled_reservations", "no_show_reservations", "last_booking_date"}).
+					AddRow(6, 2, 1, 3, lastBooking)
+				mock.ExpectQuery(`SELECT.*FILTER \(WHERE status = 'no_show'\).*FROM reservations\s+WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnRows(rows)
+			},
+			want: &types.UserStats{
+				UserID:                userID,
+				TotalReservations:     6,
+				CompletedReservations: 2,
+				CancelledReservations: 1,
+				NoShowReservations:    3,
+				RepeatNoShow:          true,
+				LastBookingDate:       &lastBooking,
+			},
+			wantErr: false,
+		},
+		{
+			name: "user without reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"total_reservations", "completed_reservations", "cancelled_reservations", "no_show_reservations", "last_booking_date"}).
+					AddRow(0, 0, 0, 0, nil)
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnRows(rows)
+			},
+			want: &types.UserStats{
+				UserID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportsQ, mock, teardown := setupReportsTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reportsQ.GetUserStats(ctx, userID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, got)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportsQ_GetTableOccupancy(t *testing.T) {
+	dateFrom := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	dateTo := time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		slots   int
+		mock    func(mock sqlmock.Sqlmock)
+		want    []*types.TableOccupancy
+		wantErr bool
+	}{
+		{
+			name:  "successful get occupancy including unbooked tables",
+			from:  dateFrom,
+			to:    dateTo,
+			slots: 24,
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"table_number", "reservations"}).
+					AddRow("T1", 6).
+					AddRow("T2", 0)
+				mock.ExpectQuery(`SELECT.*FROM tables t\s+LEFT JOIN reservations r.*GROUP BY t.number`).
+					WithArgs("2025-12-01", "2025-12-02").
+					WillReturnRows(rows)
+			},
+			want: []*types.TableOccupancy{
+				{TableNumber: "T1", Reservations: 6, OccupancyRate: 25},
+				{TableNumber: "T2", Reservations: 0, OccupancyRate: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "closed throughout the range",
+			from: dateFrom,
+			to:   dateTo,
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"table_number", "reservations"}).
+					AddRow("T1", 2)
+				mock.ExpectQuery(`SELECT.*FROM tables t\s+LEFT JOIN reservations r.*GROUP BY t.number`).
+					WithArgs("2025-12-01", "2025-12-02").
+					WillReturnRows(rows)
+			},
+			want: []*types.TableOccupancy{
+				{TableNumber: "T1", Reservations: 2, OccupancyRate: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid date range",
+			from:    dateTo,
+			to:      dateFrom,
+			mock:    func(mock sqlmock.Sqlmock) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "database error",
+			from: dateFrom,
+			to:   dateTo,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM tables t\s+LEFT JOIN reservations r.*GROUP BY t.number`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportsQ, mock, teardown := setupReportsTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reportsQ.GetTableOccupancy(ctx, tt.from, tt.to, tt.slots)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportsQ_GetTodaySnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    *types.TodaySnapshot
+		wantErr bool
+	}{
+		{
+			name: "successful get today snapshot",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"pending", "confirmed", "completed", "cancelled", "covers"}).
+					AddRow(2, 5, 1, 3, 24)
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE date = \$1::date`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want: &types.TodaySnapshot{
+				Pending:   2,
+				Confirmed: 5,
+				Completed: 1,
+				Cancelled: 3,
+				Covers:    24,
+			},
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservations\s+WHERE date = \$1::date`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportsQ, mock, teardown := setupReportsTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reportsQ.GetTodaySnapshot(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, got)
+				_, err := time.Parse("2006-01-02", got.Date)
+				assert.NoError(t, err)
+				tt.want.Date = got.Date
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
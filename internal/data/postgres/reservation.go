@@ -9,32 +9,91 @@ import (
 	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// defaultReservationOrder is used when no or an unknown sort option is requested
+const defaultReservationOrder = "date DESC, time DESC"
+
+// bulkCreateBatchSize is the number of reservations inserted by a single multi-row INSERT.
+// It keeps the statement well below the PostgreSQL limit of 65535 parameters
+const bulkCreateBatchSize = 500
+
+// reservationSortOrders maps the supported sort options to ORDER BY clauses.
+// Only these whitelisted clauses are ever put into the query
+var reservationSortOrders = map[string]string{
+	"date_asc":     "date ASC, time ASC",
+	"date_desc":    defaultReservationOrder,
+	"created_asc":  "created_at ASC",
+	"created_desc": "created_at DESC",
+	"guests_asc":   "guests ASC, date DESC, time DESC",
+	"guests_desc":  "guests DESC, date DESC, time DESC",
+}
+
+// reservationWhenClauses maps the supported time frames to conditions on the reservation
+// date and time relative to now, which is passed as the restaurant wall clock in the
+// referenced parameter. Same-day reservations are split by their time
+var reservationWhenClauses = map[string]string{
+	"upcoming": "(date > $%[1]d::timestamp::date OR (date = $%[1]d::timestamp::date AND time >= $%[1]d::timestamp::time))",
+	"past":     "(date < $%[1]d::timestamp::date OR (date = $%[1]d::timestamp::date AND time < $%[1]d::timestamp::time))",
+}
+
+// reservationSearchClause matches the search term in the referenced parameter against the guest name,
+// phone and email, the table number, any merged table, the confirmation code and the date as YYYY-MM-DD,
+// so a partial date such as "2025-12" finds a whole month
+const reservationSearchClause = ` AND (guest_name ILIKE $%[1]d ESCAPE '\'
+		OR guest_phone ILIKE $%[1]d ESCAPE '\'
+		OR guest_email ILIKE $%[1]d ESCAPE '\'
+		OR table_number ILIKE $%[1]d ESCAPE '\'
+		OR confirmation_code ILIKE $%[1]d ESCAPE '\'
+		OR TO_CHAR(date, 'YYYY-MM-DD') LIKE $%[1]d ESCAPE '\'
+		OR EXISTS (
+			SELECT 1 FROM reservation_tables rt
+			WHERE rt.reservation_id = reservations.id AND rt.table_number ILIKE $%[1]d ESCAPE '\'
+		))`
+
 // ReservationQ implements data.ReservationQ interface
 type ReservationQ struct {
-	db *sqlx.DB
+	db  sqlxExt
+	loc *time.Location
+}
+
+// NewReservationQ creates a new ReservationQ instance. Reservation dates and times are
+// compared to the current time in loc, the restaurant timezone; nil means server local time
+func NewReservationQ(db sqlxExt, loc *time.Location) data.ReservationQ {
+	return &ReservationQ{db: db, loc: loc}
+}
+
+// restaurantNow returns the current time in loc, or in server local time when loc is nil
+func restaurantNow(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Now().In(loc)
 }
 
-// NewReservationQ creates a new ReservationQ instance
-func NewReservationQ(db *sqlx.DB) data.ReservationQ {
-	return &ReservationQ{db: db}
+// wallClock returns the current time in loc as a timestamp without time zone. Reservation
+// dates and times hold the restaurant wall clock, so they are compared to it rather than to
+// NOW(), CURRENT_DATE or LOCALTIME, which follow the timezone of the database session
+func wallClock(loc *time.Location) string {
+	return restaurantNow(loc).Format("2006-01-02 15:04:05")
 }
 
-// Create creates a new reservation
-func (q *ReservationQ) Create(ctx context.Context, reservation *types.Reservation) error {
+// Create creates a new reservation. A reservation without a status is created in initialStatus
+func (q *ReservationQ) Create(ctx context.Context, reservation *types.Reservation, initialStatus string) error {
 	query := `
 		INSERT INTO reservations (
 			id, user_id, guest_name, guest_phone, guest_email,
-			date, time, guests, table_number, status, special_requests, created_at
+			date, time, guests, table_number, status, special_requests, confirmation_code, recurrence_group_id, created_at
 		)
 		VALUES (
 			:id, :user_id, :guest_name, :guest_phone, :guest_email,
-			:date, :time, :guests, :table_number, :status, :special_requests, :created_at
+			:date, :time, :guests, :table_number, :status, :special_requests, :confirmation_code, :recurrence_group_id, :created_at
 		)
 	`
 
@@ -43,36 +102,185 @@ func (q *ReservationQ) Create(ctx context.Context, reservation *types.Reservatio
 	}
 
 	if reservation.Status == "" {
-		reservation.Status = "pending"
+		reservation.Status = initialStatus
 	}
 
 	if reservation.CreatedAt.IsZero() {
 		reservation.CreatedAt = time.Now()
 	}
 
-	_, err := q.db.NamedExecContext(ctx, query, reservation)
-	if err != nil {
-		return err
+	var arg interface{} = reservation
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query = `
+			INSERT INTO reservations (
+				id, user_id, guest_name, guest_phone, guest_email,
+				date, time, guests, table_number, status, special_requests, confirmation_code, recurrence_group_id, restaurant_id, created_at
+			)
+			VALUES (
+				:id, :user_id, :guest_name, :guest_phone, :guest_email,
+				:date, :time, :guests, :table_number, :status, :special_requests, :confirmation_code, :recurrence_group_id, :restaurant_id, :created_at
+			)
+		`
+		arg = restaurantReservation{Reservation: reservation, RestaurantID: restaurantID}
+	}
+
+	if len(reservation.TableNumbers) == 0 {
+		_, err := sqlx.NamedExecContext(ctx, q.db, query, arg)
+		return confirmationCodeConflict(err)
+	}
+
+	// Reservations spanning several tables are stored together with their table set
+	return inTx(ctx, q.db, func(tx sqlxExt) error {
+		if _, err := sqlx.NamedExecContext(ctx, tx, query, arg); err != nil {
+			return confirmationCodeConflict(err)
+		}
+
+		if err := insertReservationTables(ctx, tx, reservation.ID, reservation.TableNumbers); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// BulkCreate inserts the reservations in a single transaction using multi-row inserts
+// of up to bulkCreateBatchSize rows. Either all reservations are stored or none of them.
+// IDs, statuses and creation times are defaulted as in Create
+func (q *ReservationQ) BulkCreate(ctx context.Context, reservations []*types.Reservation, initialStatus string) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	columns := []string{
+		"id", "user_id", "guest_name", "guest_phone", "guest_email",
+		"date", "time", "guests", "table_number", "status", "special_requests", "confirmation_code", "created_at",
+	}
+	restaurantID, scoped := tenant.RestaurantFromContext(ctx)
+	if scoped {
+		columns = append(columns, "restaurant_id")
+	}
+
+	now := time.Now()
+	for _, reservation := range reservations {
+		if reservation.ID == uuid.Nil {
+			reservation.ID = uuid.New()
+		}
+		if reservation.Status == "" {
+			reservation.Status = initialStatus
+		}
+		if reservation.CreatedAt.IsZero() {
+			reservation.CreatedAt = now
+		}
+	}
+
+	return inTx(ctx, q.db, func(tx sqlxExt) error {
+		for start := 0; start < len(reservations); start += bulkCreateBatchSize {
+			batch := reservations[start:min(start+bulkCreateBatchSize, len(reservations))]
+
+			rows := make([]string, 0, len(batch))
+			args := make([]interface{}, 0, len(batch)*len(columns))
+			for _, reservation := range batch {
+				placeholders := make([]string, len(columns))
+				for i := range columns {
+					placeholders[i] = fmt.Sprintf("$%d", len(args)+i+1)
+				}
+				rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+
+				args = append(args,
+					reservation.ID, reservation.UserID, reservation.GuestName, reservation.GuestPhone, reservation.GuestEmail,
+					reservation.Date, reservation.Time, reservation.Guests, reservation.TableNumber, reservation.Status,
+					reservation.SpecialRequests, reservation.ConfirmationCode, reservation.CreatedAt,
+				)
+				if scoped {
+					args = append(args, restaurantID)
+				}
+			}
+
+			query := "INSERT INTO reservations (" + strings.Join(columns, ", ") + ") VALUES " + strings.Join(rows, ", ")
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return confirmationCodeConflict(err)
+			}
+		}
+
+		for _, reservation := range reservations {
+			if len(reservation.TableNumbers) > 0 {
+				if err := insertReservationTables(ctx, tx, reservation.ID, reservation.TableNumbers); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetTables replaces the merged table set of a reservation.
+// Passing a single table or none turns it back into a single-table reservation
+func (q *ReservationQ) SetTables(ctx context.Context, id uuid.UUID, tableNumbers []string) error {
+	return inTx(ctx, q.db, func(tx sqlxExt) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reservation_tables WHERE reservation_id = $1`, id); err != nil {
+			return err
+		}
+
+		if len(tableNumbers) > 1 {
+			if err := insertReservationTables(ctx, tx, id, tableNumbers); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// insertReservationTables links the reservation to every table of its merged table set
+func insertReservationTables(ctx context.Context, tx sqlxExt, id uuid.UUID, tableNumbers []string) error {
+	query := `
+		INSERT INTO reservation_tables (reservation_id, restaurant_id, table_number)
+		SELECT id, restaurant_id, $2
+		FROM reservations
+		WHERE id = $1
+	`
+
+	for _, tableNumber := range tableNumbers {
+		if _, err := tx.ExecContext(ctx, query, id, tableNumber); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// confirmationCodeConflict turns a duplicate confirmation code into data.ErrConflict
+// so that the caller can retry with a new code
+func confirmationCodeConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_reservations_confirmation_code" {
+		return fmt.Errorf("confirmation code %w", data.ErrConflict)
+	}
+	return err
+}
+
 // GetByID retrieves a reservation by ID
 func (q *ReservationQ) GetByID(ctx context.Context, id uuid.UUID) (*types.Reservation, error) {
 	query := `
 		SELECT id, user_id, guest_name, guest_phone, guest_email,
 		       date, time, guests, table_number, status, special_requests,
-		       created_at, updated_at
+		       confirmation_code, staff_notes, recurrence_group_id, created_at, updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = reservations.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
 		FROM reservations
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
 	var reservation types.Reservation
-	err := q.db.GetContext(ctx, &reservation, query, id)
+	err := sqlx.GetContext(ctx, q.db, &reservation, query+scope, append([]interface{}{id}, args...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("reservation not found")
+			return nil, fmt.Errorf("reservation %w", data.ErrNotFound)
 		}
 		return nil, err
 	}
@@ -80,18 +288,102 @@ func (q *ReservationQ) GetByID(ctx context.Context, id uuid.UUID) (*types.Reserv
 	return &reservation, nil
 }
 
+// GetByRecurrenceGroup retrieves the reservations created together by a recurring booking, ordered by date and time
+func (q *ReservationQ) GetByRecurrenceGroup(ctx context.Context, groupID uuid.UUID) ([]*types.Reservation, error) {
+	query := `
+		SELECT id, user_id, guest_name, guest_phone, guest_email,
+		       date, time, guests, table_number, status, special_requests,
+		       confirmation_code, staff_notes, recurrence_group_id, created_at, updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = reservations.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
+		FROM reservations
+		WHERE recurrence_group_id = $1 AND deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	var reservations []*types.Reservation
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query+scope+" ORDER BY date, time", append([]interface{}{groupID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetByConfirmationCode retrieves a reservation by its confirmation code
+func (q *ReservationQ) GetByConfirmationCode(ctx context.Context, code string) (*types.Reservation, error) {
+	query := `
+		SELECT id, user_id, guest_name, guest_phone, guest_email,
+		       date, time, guests, table_number, status, special_requests,
+		       confirmation_code, created_at, updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = reservations.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
+		FROM reservations
+		WHERE confirmation_code = $1 AND deleted_at IS NULL
+	`
+
+	var reservation types.Reservation
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	err := sqlx.GetContext(ctx, q.db, &reservation, query+scope, append([]interface{}{code}, args...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("reservation %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// GetByPhoneAndDate retrieves the pending and confirmed reservations made under a guest phone on a date
+func (q *ReservationQ) GetByPhoneAndDate(ctx context.Context, phone string, date string) ([]*types.Reservation, error) {
+	query := `
+		SELECT id, user_id, guest_name, guest_phone, guest_email,
+		       date, TO_CHAR(time, 'HH24:MI') AS time, guests, table_number, status, special_requests,
+		       created_at, updated_at
+		FROM reservations
+		WHERE guest_phone = $1
+		  AND date = $2::date
+		  AND status IN ('pending', 'confirmed')
+		  AND deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	query += scope + " ORDER BY time"
+
+	reservations := make([]*types.Reservation, 0)
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query, append([]interface{}{phone, date}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
 // GetAll retrieves all reservations with optional filters
 func (q *ReservationQ) GetAll(ctx context.Context, userID *uuid.UUID, filters *types.ReservationFilters) ([]*types.Reservation, error) {
 	query := `
 		SELECT id, user_id, guest_name, guest_phone, guest_email,
 		       date, time, guests, table_number, status, special_requests,
-		       created_at, updated_at
+		       staff_notes, recurrence_group_id, created_at, updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = reservations.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
 		FROM reservations
-		WHERE 1=1
+		WHERE deleted_at IS NULL
 	`
 
-	args := []interface{}{}
-	argPos := 1
+	scope, args := restaurantScope(ctx, "restaurant_id", 1)
+	query += scope
+	argPos := len(args) + 1
 
 	// Filter by user ID if provided (for regular users)
 	if userID != nil {
@@ -115,18 +407,49 @@ func (q *ReservationQ) GetAll(ctx context.Context, userID *uuid.UUID, filters *t
 		}
 
 		if filters.Search != nil && *filters.Search != "" {
-			searchTerm := "%" + *filters.Search + "%"
-			query += fmt.Sprintf(" AND (guest_name ILIKE $%d OR guest_phone ILIKE $%d OR guest_email ILIKE $%d)",
-				argPos, argPos, argPos)
-			args = append(args, searchTerm)
+			query += fmt.Sprintf(reservationSearchClause, argPos)
+			args = append(args, containsPattern(*filters.Search))
 			argPos++
 		}
+
+		if filters.TableNumber != nil && *filters.TableNumber != "" {
+			query += fmt.Sprintf(" AND table_number = $%d", argPos)
+			args = append(args, *filters.TableNumber)
+			argPos++
+		}
+
+		if filters.MinGuests != nil {
+			query += fmt.Sprintf(" AND guests >= $%d", argPos)
+			args = append(args, *filters.MinGuests)
+			argPos++
+		}
+
+		if filters.Guests != nil {
+			query += fmt.Sprintf(" AND guests = $%d", argPos)
+			args = append(args, *filters.Guests)
+			argPos++
+		}
+
+		if filters.When != nil {
+			if clause, ok := reservationWhenClauses[*filters.When]; ok {
+				query += " AND " + fmt.Sprintf(clause, argPos)
+				args = append(args, wallClock(q.loc))
+				argPos++
+			}
+		}
+	}
+
+	orderBy := defaultReservationOrder
+	if filters != nil && filters.Sort != nil {
+		if order, ok := reservationSortOrders[*filters.Sort]; ok {
+			orderBy = order
+		}
 	}
 
-	query += " ORDER BY date DESC, time DESC"
+	query += " ORDER BY " + orderBy
 
 	var reservations []*types.Reservation
-	err := q.db.SelectContext(ctx, &reservations, query, args...)
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -139,14 +462,21 @@ func (q *ReservationQ) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*ty
 	query := `
 		SELECT id, user_id, guest_name, guest_phone, guest_email,
 		       date, time, guests, table_number, status, special_requests,
-		       created_at, updated_at
+		       created_at, updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = reservations.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
 		FROM reservations
-		WHERE user_id = $1
-		ORDER BY date DESC, time DESC
+		WHERE user_id = $1 AND deleted_at IS NULL
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope + " ORDER BY date DESC, time DESC"
+
 	var reservations []*types.Reservation
-	err := q.db.SelectContext(ctx, &reservations, query, userID)
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query, append([]interface{}{userID}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,8 +484,10 @@ func (q *ReservationQ) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*ty
 	return reservations, nil
 }
 
-// Update updates a reservation's information
-func (q *ReservationQ) Update(ctx context.Context, id uuid.UUID, reservation *types.Reservation) error {
+// Update updates a reservation's information. When expectedUpdatedAt is set, the update
+// is only applied if the reservation was not modified since then, otherwise ErrConflict is returned.
+// The new modification time as stored by the database is returned
+func (q *ReservationQ) Update(ctx context.Context, id uuid.UUID, reservation *types.Reservation, expectedUpdatedAt *time.Time) (time.Time, error) {
 	setParts := []string{}
 	args := []interface{}{}
 	argPos := 1
@@ -202,50 +534,123 @@ func (q *ReservationQ) Update(ctx context.Context, id uuid.UUID, reservation *ty
 		argPos++
 	}
 
-	if reservation.SpecialRequests != nil {
+	// A non-nil empty value clears the special requests
+	if reservation.SpecialRequests != nil && *reservation.SpecialRequests == "" {
+		setParts = append(setParts, "special_requests = NULL")
+	} else if reservation.SpecialRequests != nil {
 		setParts = append(setParts, fmt.Sprintf("special_requests = $%d", argPos))
 		args = append(args, *reservation.SpecialRequests)
 		argPos++
 	}
 
 	if len(setParts) == 0 {
-		return errors.New("no fields to update")
+		return time.Time{}, errors.New("no fields to update")
 	}
 
 	query := fmt.Sprintf(`
 		UPDATE reservations
 		SET %s, updated_at = NOW()
-		WHERE id = $%d
+		WHERE id = $%d AND deleted_at IS NULL
 	`, strings.Join(setParts, ", "), argPos)
-
 	args = append(args, id)
+	argPos++
 
-	result, err := q.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return err
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", argPos)
+	query += scope
+	args = append(args, scopeArgs...)
+	argPos += len(scopeArgs)
+
+	if expectedUpdatedAt != nil {
+		query += fmt.Sprintf(" AND updated_at = $%d", argPos)
+		args = append(args, *expectedUpdatedAt)
 	}
+	query += " RETURNING updated_at"
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+	var updatedAt time.Time
+	err := sqlx.GetContext(ctx, q.db, &updatedAt, query, args...)
+	if err == nil {
+		return updatedAt, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, err
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("reservation not found")
+	if expectedUpdatedAt == nil {
+		return time.Time{}, fmt.Errorf("reservation %w", data.ErrNotFound)
 	}
 
-	return nil
+	// No rows matched the version check, find out whether the reservation is gone or was modified
+	var exists bool
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM reservations WHERE id = $1 AND deleted_at IS NULL)`
+	if err := sqlx.GetContext(ctx, q.db, &exists, existsQuery, id); err != nil {
+		return time.Time{}, err
+	}
+
+	if !exists {
+		return time.Time{}, fmt.Errorf("reservation %w", data.ErrNotFound)
+	}
+
+	return time.Time{}, fmt.Errorf("reservation was modified concurrently: %w", data.ErrConflict)
 }
 
-// UpdateStatus updates only the status of a reservation
-func (q *ReservationQ) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+// UpdateStatus moves a reservation from fromStatus to status and returns the updated reservation.
+// The update only applies while the reservation is still in fromStatus, so concurrent status
+// changes cannot both pass the transition check
+func (q *ReservationQ) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, status string) (*types.Reservation, error) {
 	query := `
 		UPDATE reservations
 		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		WHERE id = $2 AND status = $3 AND deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 4)
+	query += scope + `
+		RETURNING id, user_id, guest_name, guest_phone, guest_email,
+		          date, time, guests, table_number, status, special_requests,
+		          confirmation_code, staff_notes, recurrence_group_id, created_at, updated_at,
+		          ARRAY(
+		              SELECT rt.table_number FROM reservation_tables rt
+		              WHERE rt.reservation_id = reservations.id
+		              ORDER BY rt.table_number
+		          ) AS table_numbers
+	`
+
+	var reservation types.Reservation
+	err := sqlx.GetContext(ctx, q.db, &reservation, query, append([]interface{}{status, id, fromStatus}, args...)...)
+	if err == nil {
+		return &reservation, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// No rows matched the status check, find out whether the reservation is gone or its status changed
+	var exists bool
+	existsScope, existsArgs := restaurantScope(ctx, "restaurant_id", 2)
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM reservations WHERE id = $1 AND deleted_at IS NULL` + existsScope + `)`
+	if err := sqlx.GetContext(ctx, q.db, &exists, existsQuery, append([]interface{}{id}, existsArgs...)...); err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("reservation %w", data.ErrNotFound)
+	}
+
+	return nil, fmt.Errorf("reservation is no longer in status %s: %w", fromStatus, data.ErrConflict)
+}
+
+// UpdateStaffNotes replaces the staff notes of a reservation, a nil value clears them.
+// Notes are not part of the guest-facing reservation, so updated_at is left untouched
+// and concurrent guest edits are not rejected as conflicts
+func (q *ReservationQ) UpdateStaffNotes(ctx context.Context, id uuid.UUID, notes *string) error {
+	query := `
+		UPDATE reservations
+		SET staff_notes = $1
+		WHERE id = $2 AND deleted_at IS NULL
 	`
 
-	result, err := q.db.ExecContext(ctx, query, status, id)
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{notes, id}, args...)...)
 	if err != nil {
 		return err
 	}
@@ -256,17 +661,76 @@ func (q *ReservationQ) UpdateStatus(ctx context.Context, id uuid.UUID, status st
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("reservation not found")
+		return fmt.Errorf("reservation %w", data.ErrNotFound)
 	}
 
 	return nil
 }
 
-// Delete deletes a reservation by ID
+// BulkUpdateStatus applies the status changes in a single transaction and records them
+// in the status history. Either all changes are applied or none of them
+func (q *ReservationQ) BulkUpdateStatus(ctx context.Context, changes []*types.StatusChange) error {
+	updateQuery := `
+		UPDATE reservations
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status = $3 AND deleted_at IS NULL
+	`
+
+	historyQuery := `
+		INSERT INTO reservation_status_history (
+			id, reservation_id, from_status, to_status, changed_by, changed_at
+		)
+		VALUES (
+			:id, :reservation_id, :from_status, :to_status, :changed_by, :changed_at
+		)
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 4)
+	updateQuery += scope
+
+	return inTx(ctx, q.db, func(tx sqlxExt) error {
+		for _, change := range changes {
+			result, err := tx.ExecContext(ctx, updateQuery, append([]interface{}{change.ToStatus, change.ReservationID, change.FromStatus}, args...)...)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			// The reservation was changed or deleted after the caller read it
+			if rowsAffected == 0 {
+				return fmt.Errorf("reservation %s is no longer in status %s", change.ReservationID, change.FromStatus)
+			}
+
+			if change.ID == uuid.Nil {
+				change.ID = uuid.New()
+			}
+			if change.ChangedAt.IsZero() {
+				change.ChangedAt = time.Now()
+			}
+
+			if _, err := sqlx.NamedExecContext(ctx, tx, historyQuery, change); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete soft-deletes a reservation by ID
 func (q *ReservationQ) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM reservations WHERE id = $1`
+	query := `
+		UPDATE reservations
+		SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
 
-	result, err := q.db.ExecContext(ctx, query, id)
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{id}, args...)...)
 	if err != nil {
 		return err
 	}
@@ -277,28 +741,272 @@ func (q *ReservationQ) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("reservation not found")
+		return fmt.Errorf("reservation %w", data.ErrNotFound)
 	}
 
 	return nil
 }
 
-// CheckTableAvailability checks if a table is available at a specific date and time
-func (q *ReservationQ) CheckTableAvailability(ctx context.Context, tableNumber string, date string, time string) (bool, error) {
+// HardDelete permanently removes a reservation by ID, including soft-deleted ones,
+// and returns the removed reservation
+func (q *ReservationQ) HardDelete(ctx context.Context, id uuid.UUID) (*types.Reservation, error) {
+	query := `
+		DELETE FROM reservations
+		WHERE id = $1
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope + `
+		RETURNING id, user_id, guest_name, guest_phone, guest_email,
+		          date, time, guests, table_number, status, special_requests,
+		          created_at, updated_at, deleted_at
+	`
+
+	var reservation types.Reservation
+	err := sqlx.GetContext(ctx, q.db, &reservation, query, append([]interface{}{id}, args...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("reservation %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// GetByTableNumber retrieves the reservations holding a table, alone or merged with others,
+// between two dates inclusive, ordered by date and time
+func (q *ReservationQ) GetByTableNumber(ctx context.Context, tableNumber string, dateFrom, dateTo time.Time) ([]*types.Reservation, error) {
+	query := `
+		SELECT r.id, r.user_id, r.guest_name, r.guest_phone, r.guest_email,
+		       r.date, r.time, r.guests, r.table_number, r.status, r.special_requests,
+		       r.confirmation_code, r.created_at, r.updated_at,
+		       ARRAY(
+		           SELECT rt.table_number FROM reservation_tables rt
+		           WHERE rt.reservation_id = r.id
+		           ORDER BY rt.table_number
+		       ) AS table_numbers
+		FROM reservations r
+		WHERE (
+		        r.table_number = $1
+		        OR EXISTS (
+		            SELECT 1 FROM reservation_tables rt
+		            WHERE rt.reservation_id = r.id AND rt.table_number = $1
+		        )
+		      )
+		  AND r.date BETWEEN $2::date AND $3::date
+		  AND r.deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "r.restaurant_id", 4)
+	query += scope + " ORDER BY r.date, r.time"
+
+	reservations := make([]*types.Reservation, 0)
+	args = append([]interface{}{tableNumber, dateFrom.Format("2006-01-02"), dateTo.Format("2006-01-02")}, args...)
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// CountActiveByUser counts the pending and confirmed reservations of a user that have not started yet
+func (q *ReservationQ) CountActiveByUser(ctx context.Context, userID uuid.UUID) (int, error) {
 	query := `
-		SELECT COUNT(*) 
+		SELECT COUNT(*)
 		FROM reservations
-		WHERE table_number = $1
-		  AND date = $2::date
-		  AND time = $3::time
+		WHERE user_id = $1
 		  AND status IN ('pending', 'confirmed')
+		  AND deleted_at IS NULL
+		  AND (date + time) >= $2::timestamp
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	var count int
+	err := sqlx.GetContext(ctx, q.db, &count, query+scope, append([]interface{}{userID, wallClock(q.loc)}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountUpcomingByUser counts the reservations of a user that have not started yet and were not cancelled.
+// It uses the same time frame as the "upcoming" listing filter
+func (q *ReservationQ) CountUpcomingByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reservations
+		WHERE user_id = $1
+		  AND status <> 'cancelled'
+		  AND deleted_at IS NULL
+		  AND ` + fmt.Sprintf(reservationWhenClauses["upcoming"], 2)
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
 	var count int
-	err := q.db.GetContext(ctx, &count, query, tableNumber, date, time)
+	err := sqlx.GetContext(ctx, q.db, &count, query+scope, append([]interface{}{userID, wallClock(q.loc)}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkPastAsCompleted marks confirmed reservations whose date and time have passed
+// as completed and returns the affected reservations.
+// The status changes are recorded in the status history as part of the same statement
+func (q *ReservationQ) MarkPastAsCompleted(ctx context.Context) ([]types.CompletedReservation, error) {
+	query := `
+		WITH completed AS (
+			UPDATE reservations
+			SET status = 'completed', updated_at = NOW()
+			WHERE status = 'confirmed'
+			  AND deleted_at IS NULL
+			  AND (date + time) < $1::timestamp
+			RETURNING id, restaurant_id, user_id, date
+		), history AS (
+			INSERT INTO reservation_status_history (reservation_id, from_status, to_status, changed_at)
+			SELECT id, 'confirmed', 'completed', NOW()
+			FROM completed
+		)
+		SELECT id, restaurant_id, user_id, date FROM completed
+	`
+
+	var completed []types.CompletedReservation
+	err := sqlx.SelectContext(ctx, q.db, &completed, query, wallClock(q.loc))
+	if err != nil {
+		return nil, err
+	}
+
+	return completed, nil
+}
+
+// CheckTableAvailability checks if a table is available at a specific date and time
+func (q *ReservationQ) CheckTableAvailability(ctx context.Context, tableNumber string, date string, time string) (bool, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reservations r
+		WHERE (
+		        r.table_number = $1
+		        OR EXISTS (
+		            SELECT 1 FROM reservation_tables rt
+		            WHERE rt.reservation_id = r.id AND rt.table_number = $1
+		        )
+		      )
+		  AND r.date = $2::date
+		  AND r.time = $3::time
+		  AND r.status IN ('pending', 'confirmed')
+		  AND r.deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "r.restaurant_id", 4)
+	var count int
+	err := sqlx.GetContext(ctx, q.db, &count, query+scope, append([]interface{}{tableNumber, date, time}, args...)...)
 	if err != nil {
 		return false, err
 	}
 
 	return count == 0, nil
 }
+
+// CountConcurrentAt counts the pending and confirmed reservations across all tables that overlap a reservation
+// starting at a date and time. Every reservation is seated for window from its start, so two overlap when
+// they start less than window apart
+func (q *ReservationQ) CountConcurrentAt(ctx context.Context, date string, clock string, window time.Duration, excludeID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reservations
+		WHERE date = $1::date
+		  AND ABS(EXTRACT(EPOCH FROM time - $2::time)) < $3
+		  AND id <> $4
+		  AND status IN ('pending', 'confirmed')
+		  AND deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 5)
+	var count int
+	err := sqlx.GetContext(ctx, q.db, &count, query+scope, append([]interface{}{date, clock, window.Seconds(), excludeID}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetBookedTimes returns the times (HH:MM) at which a table is held by an active reservation on a date
+func (q *ReservationQ) GetBookedTimes(ctx context.Context, tableNumber string, date string) ([]string, error) {
+	query := `
+		SELECT DISTINCT TO_CHAR(r.time, 'HH24:MI') AS time
+		FROM reservations r
+		WHERE (
+		        r.table_number = $1
+		        OR EXISTS (
+		            SELECT 1 FROM reservation_tables rt
+		            WHERE rt.reservation_id = r.id AND rt.table_number = $1
+		        )
+		      )
+		  AND r.date = $2::date
+		  AND r.status IN ('pending', 'confirmed')
+		  AND r.deleted_at IS NULL
+	`
+
+	scope, args := restaurantScope(ctx, "r.restaurant_id", 3)
+	query += scope + " ORDER BY time"
+
+	times := make([]string, 0)
+	err := sqlx.SelectContext(ctx, q.db, &times, query, append([]interface{}{tableNumber, date}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// GetDeleted retrieves all soft-deleted reservations
+func (q *ReservationQ) GetDeleted(ctx context.Context) ([]*types.Reservation, error) {
+	query := `
+		SELECT id, user_id, guest_name, guest_phone, guest_email,
+		       date, time, guests, table_number, status, special_requests,
+		       created_at, updated_at, deleted_at
+		FROM reservations
+		WHERE deleted_at IS NOT NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 1)
+	query += scope + " ORDER BY deleted_at DESC"
+
+	var reservations []*types.Reservation
+	err := sqlx.SelectContext(ctx, q.db, &reservations, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// Restore undoes a soft delete of a reservation
+func (q *ReservationQ) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE reservations
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{id}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted reservation %w", data.ErrNotFound)
+	}
+
+	return nil
+}
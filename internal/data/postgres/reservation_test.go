@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,7 +23,7 @@ func setupReservationTestDB(t *testing.T) (*ReservationQ, sqlmock.Sqlmock, func(
 	}
 
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	reservationQ := NewReservationQ(sqlxDB).(*ReservationQ)
+	reservationQ := NewReservationQ(sqlxDB, nil).(*ReservationQ)
 
 	teardown := func() {
 		db.Close()
@@ -40,12 +42,13 @@ func TestReservationQ_Create(t *testing.T) {
 		reservation *types.Reservation
 		mock        func(mock sqlmock.Sqlmock)
 		wantErr     bool
+		conflict    bool
 	}{
 		{
 			name: "successful create",
 			reservation: &types.Reservation{
 				ID:           reservationID,
-				UserID:       userID,
+				UserID:       &userID,
 				GuestName:    "John Doe",
 				GuestPhone:   "+1234567890",
 				GuestEmail:   "john@example.com",
@@ -70,6 +73,8 @@ func TestReservationQ_Create(t *testing.T) {
 						"T1",
 						"pending",
 						nil, // special_requests
+						nil, // confirmation_code
+						nil, // recurrence_group_id
 						sqlmock.AnyArg(), // created_at
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -80,7 +85,7 @@ func TestReservationQ_Create(t *testing.T) {
 			name: "create with auto-generated ID and default status",
 			reservation: &types.Reservation{
 				ID:          uuid.Nil,
-				UserID:      userID,
+				UserID:      &userID,
 				GuestName:   "Jane Doe",
 				GuestPhone:  "+1234567890",
 				GuestEmail:  "jane@example.com",
@@ -104,17 +109,74 @@ func TestReservationQ_Create(t *testing.T) {
 						"T2",
 						"pending", // default status
 						nil,       // special_requests
+						nil,       // confirmation_code
+						nil,       // recurrence_group_id
 						sqlmock.AnyArg(), // created_at
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			wantErr: false,
 		},
+		{
+			name: "create spanning merged tables",
+			reservation: &types.Reservation{
+				ID:           reservationID,
+				UserID:       &userID,
+				GuestName:    "John Doe",
+				GuestPhone:   "+1234567890",
+				GuestEmail:   "john@example.com",
+				Date:         time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				Time:         "19:00",
+				Guests:       10,
+				TableNumber:  "T1",
+				TableNumbers: []string{"T1", "T2"},
+				Status:       "pending",
+				CreatedAt:    createdAt,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO reservations`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO reservation_tables`).
+					WithArgs(reservationID, "T1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO reservation_tables`).
+					WithArgs(reservationID, "T2").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "merged tables insert error rolls back",
+			reservation: &types.Reservation{
+				ID:           reservationID,
+				UserID:       &userID,
+				GuestName:    "John Doe",
+				GuestPhone:   "+1234567890",
+				GuestEmail:   "john@example.com",
+				Date:         time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				Time:         "19:00",
+				Guests:       10,
+				TableNumber:  "T1",
+				TableNumbers: []string{"T1", "T2"},
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO reservations`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO reservation_tables`).
+					WithArgs(reservationID, "T1").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "database error",
 			reservation: &types.Reservation{
 				ID:          reservationID,
-				UserID:      userID,
+				UserID:      &userID,
 				GuestName:   "John Doe",
 				GuestPhone:  "+1234567890",
 				GuestEmail:  "john@example.com",
@@ -129,6 +191,26 @@ func TestReservationQ_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "duplicate confirmation code",
+			reservation: &types.Reservation{
+				ID:          reservationID,
+				UserID:      &userID,
+				GuestName:   "John Doe",
+				GuestPhone:  "+1234567890",
+				GuestEmail:  "john@example.com",
+				Date:        time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				Time:        "19:00",
+				Guests:      4,
+				TableNumber: "T1",
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservations`).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_reservations_confirmation_code"})
+			},
+			wantErr:  true,
+			conflict: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -139,10 +221,13 @@ func TestReservationQ_Create(t *testing.T) {
 			tt.mock(mock)
 
 			ctx := context.Background()
-			err := reservationQ.Create(ctx, tt.reservation)
+			err := reservationQ.Create(ctx, tt.reservation, types.ReservationStatusPending)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.conflict {
+					assert.ErrorIs(t, err, data.ErrConflict)
+				}
 			} else {
 				assert.NoError(t, err)
 				// Verify that ID was generated if it was nil
@@ -160,6 +245,80 @@ func TestReservationQ_Create(t *testing.T) {
 	}
 }
 
+func TestReservationQ_SetTables(t *testing.T) {
+	reservationID := uuid.New()
+
+	tests := []struct {
+		name         string
+		tableNumbers []string
+		mock         func(mock sqlmock.Sqlmock)
+		wantErr      bool
+	}{
+		{
+			name:         "replace merged tables",
+			tableNumbers: []string{"T3", "T4"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`INSERT INTO reservation_tables`).
+					WithArgs(reservationID, "T3").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO reservation_tables`).
+					WithArgs(reservationID, "T4").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:         "single table clears merged set",
+			tableNumbers: []string{"T3"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:         "database error",
+			tableNumbers: []string{"T3", "T4"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reservation_tables WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			err := reservationQ.SetTables(ctx, reservationID, tt.tableNumbers)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestReservationQ_GetByID(t *testing.T) {
 	reservationID := uuid.New()
 	userID := uuid.New()
@@ -167,12 +326,13 @@ func TestReservationQ_GetByID(t *testing.T) {
 	updatedAt := time.Now()
 
 	tests := []struct {
-		name    string
-		id      uuid.UUID
-		mock    func(mock sqlmock.Sqlmock)
-		want    *types.Reservation
-		wantErr bool
-		errMsg  string
+		name     string
+		id       uuid.UUID
+		mock     func(mock sqlmock.Sqlmock)
+		want     *types.Reservation
+		wantErr  bool
+		errMsg   string
+		notFound bool
 	}{
 		{
 			name: "successful get",
@@ -180,13 +340,13 @@ func TestReservationQ_GetByID(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT id, user_id, guest_name, guest_phone, guest_email, date, time, guests, table_number, status, special_requests, created_at, updated_at FROM reservations WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, user_id, guest_name, guest_phone, guest_email, date, time, guests, table_number, status, special_requests, confirmation_code, staff_notes, recurrence_group_id, created_at, updated_at, ARRAY\(.*\) AS table_numbers FROM reservations WHERE id = \$1`).
 					WithArgs(reservationID).
 					WillReturnRows(rows)
 			},
 			want: &types.Reservation{
 				ID:           reservationID,
-				UserID:       userID,
+				UserID:       &userID,
 				GuestName:    "John Doe",
 				GuestPhone:   "+1234567890",
 				GuestEmail:   "john@example.com",
@@ -204,13 +364,14 @@ func TestReservationQ_GetByID(t *testing.T) {
 			name: "reservation not found",
 			id:   reservationID,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, user_id, guest_name, guest_phone, guest_email, date, time, guests, table_number, status, special_requests, created_at, updated_at FROM reservations WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, user_id, guest_name, guest_phone, guest_email, date, time, guests, table_number, status, special_requests, confirmation_code, staff_notes, recurrence_group_id, created_at, updated_at, ARRAY\(.*\) AS table_numbers FROM reservations WHERE id = \$1`).
 					WithArgs(reservationID).
 					WillReturnError(sql.ErrNoRows)
 			},
-			want:    nil,
-			wantErr: true,
-			errMsg:  "reservation not found",
+			want:     nil,
+			wantErr:  true,
+			errMsg:   "reservation not found",
+			notFound: true,
 		},
 	}
 
@@ -229,6 +390,9 @@ func TestReservationQ_GetByID(t *testing.T) {
 				if tt.errMsg != "" {
 					assert.EqualError(t, err, tt.errMsg)
 				}
+				if tt.notFound {
+					assert.ErrorIs(t, err, data.ErrNotFound)
+				}
 				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, err)
@@ -265,7 +429,7 @@ func TestReservationQ_GetAll(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE 1=1 ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL ORDER BY date DESC, time DESC`).
 					WillReturnRows(rows)
 			},
 			want:    1,
@@ -278,7 +442,7 @@ func TestReservationQ_GetAll(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE 1=1 AND user_id = \$1 ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND user_id = \$1 ORDER BY date DESC, time DESC`).
 					WithArgs(userID).
 					WillReturnRows(rows)
 			},
@@ -294,7 +458,7 @@ func TestReservationQ_GetAll(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "confirmed", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE 1=1 AND status = \$1 ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND status = \$1 ORDER BY date DESC, time DESC`).
 					WithArgs("confirmed").
 					WillReturnRows(rows)
 			},
@@ -309,7 +473,7 @@ func TestReservationQ_GetAll(t *testing.T) {
 			},
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"})
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE 1=1 AND date = \$1::date ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND date = \$1::date ORDER BY date DESC, time DESC`).
 					WithArgs("2025-12-25").
 					WillReturnRows(rows)
 			},
@@ -325,13 +489,186 @@ func TestReservationQ_GetAll(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE 1=1 AND.*ILIKE.*ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND.*ILIKE.*ORDER BY date DESC, time DESC`).
 					WithArgs("%John%").
 					WillReturnRows(rows)
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name:   "search by partial date with user filter",
+			userID: &userID,
+			filters: &types.ReservationFilters{
+				Search: stringPtr("2025-12"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND user_id = \$1 AND \(guest_name ILIKE \$2.*confirmation_code ILIKE \$2.*TO_CHAR\(date, 'YYYY-MM-DD'\) LIKE \$2.*\) ORDER BY date DESC, time DESC`).
+					WithArgs(userID, "%2025-12%").
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "search matches wildcard characters literally",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				Search: stringPtr("100%"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"})
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND \(guest_name ILIKE \$1 ESCAPE '\\' OR guest_phone ILIKE \$1 ESCAPE '\\' OR guest_email ILIKE \$1 ESCAPE '\\' OR table_number ILIKE \$1 ESCAPE '\\' OR confirmation_code ILIKE \$1 ESCAPE '\\' OR TO_CHAR\(date, 'YYYY-MM-DD'\) LIKE \$1 ESCAPE '\\' OR EXISTS \( SELECT 1 FROM reservation_tables rt WHERE rt.reservation_id = reservations.id AND rt.table_number ILIKE \$1 ESCAPE '\\' \)\) ORDER BY date DESC, time DESC`).
+					WithArgs(`%100\%%`).
+					WillReturnRows(rows)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:   "get all with table number and min guests filters",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				TableNumber: stringPtr("T1"),
+				MinGuests:   intPtr(4),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "confirmed", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND table_number = \$1 AND guests >= \$2 ORDER BY date DESC, time DESC`).
+					WithArgs("T1", 4).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get all combining status, table number and min guests filters",
+			userID: &userID,
+			filters: &types.ReservationFilters{
+				Status:      stringPtr("confirmed"),
+				TableNumber: stringPtr("T1"),
+				MinGuests:   intPtr(2),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "confirmed", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND user_id = \$1 AND status = \$2 AND table_number = \$3 AND guests >= \$4 ORDER BY date DESC, time DESC`).
+					WithArgs(userID, "confirmed", "T1", 2).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get all with exact guests filter",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				Guests: intPtr(4),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "confirmed", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND guests = \$1 ORDER BY date DESC, time DESC`).
+					WithArgs(4).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get upcoming reservations",
+			userID: &userID,
+			filters: &types.ReservationFilters{
+				When: stringPtr("upcoming"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "confirmed", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND user_id = \$1 AND \(date > \$2::timestamp::date OR \(date = \$2::timestamp::date AND time >= \$2::timestamp::time\)\) ORDER BY date DESC, time DESC`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get past reservations",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				When: stringPtr("past"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "completed", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL AND \(date < \$1::timestamp::date OR \(date = \$1::timestamp::date AND time < \$1::timestamp::time\)\) ORDER BY date DESC, time DESC`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "unknown time frame is ignored",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				When: stringPtr("tomorrow"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"})
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL ORDER BY date DESC, time DESC$`).
+					WillReturnRows(rows)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:   "get all sorted by date ascending",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				Sort: stringPtr("date_asc"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL ORDER BY date ASC, time ASC$`).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get all sorted by guests descending",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				Sort: stringPtr("guests_desc"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL ORDER BY guests DESC, date DESC, time DESC$`).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:   "get all with invalid sort falls back to default",
+			userID: nil,
+			filters: &types.ReservationFilters{
+				Sort: stringPtr("guests; DROP TABLE reservations"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NULL ORDER BY date DESC, time DESC$`).
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -376,7 +713,7 @@ func TestReservationQ_GetByUserID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"}).
 					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "pending", nil, createdAt, updatedAt).
 					AddRow(uuid.New(), userID, "Jane Doe", "+1234567891", "jane@example.com", time.Date(2025, 12, 26, 0, 0, 0, 0, time.UTC), "20:00", 2, "T2", "confirmed", nil, createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE user_id = \$1 ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE user_id = \$1 AND deleted_at IS NULL ORDER BY date DESC, time DESC`).
 					WithArgs(userID).
 					WillReturnRows(rows)
 			},
@@ -388,7 +725,7 @@ func TestReservationQ_GetByUserID(t *testing.T) {
 			userID: userID,
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at"})
-				mock.ExpectQuery(`SELECT.*FROM reservations WHERE user_id = \$1 ORDER BY date DESC, time DESC`).
+				mock.ExpectQuery(`SELECT.*FROM reservations WHERE user_id = \$1 AND deleted_at IS NULL ORDER BY date DESC, time DESC`).
 					WithArgs(userID).
 					WillReturnRows(rows)
 			},
@@ -421,6 +758,14 @@ func TestReservationQ_GetByUserID(t *testing.T) {
 
 func TestReservationQ_Update(t *testing.T) {
 	reservationID := uuid.New()
+	updatedAt := time.Now()
+	newUpdatedAt := updatedAt.Add(time.Minute)
+	returned := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"updated_at"}).AddRow(newUpdatedAt)
+	}
+	noRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"updated_at"})
+	}
 
 	tests := []struct {
 		name        string
@@ -429,6 +774,8 @@ func TestReservationQ_Update(t *testing.T) {
 		mock        func(mock sqlmock.Sqlmock)
 		wantErr     bool
 		errMsg      string
+		expected    *time.Time
+		conflict    bool
 	}{
 		{
 			name: "successful update single field",
@@ -438,8 +785,8 @@ func TestReservationQ_Update(t *testing.T) {
 			},
 			mock: func(mock sqlmock.Sqlmock) {
 				// The query is built dynamically, so we use a more flexible pattern
-				mock.ExpectExec(`UPDATE reservations`).
-					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`UPDATE reservations`).
+					WillReturnRows(returned())
 			},
 			wantErr: false,
 		},
@@ -450,23 +797,99 @@ func TestReservationQ_Update(t *testing.T) {
 				GuestName: "Updated Name",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE reservations`).
-					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`UPDATE reservations`).
+					WillReturnRows(noRows())
 			},
 			wantErr: true,
 			errMsg:  "reservation not found",
 		},
 		{
-			name: "no fields to update",
+			name: "successful update with matching version",
 			id:   reservationID,
-			reservation: &types.Reservation{},
+			reservation: &types.Reservation{
+				GuestName: "Updated Name",
+			},
+			expected: &updatedAt,
 			mock: func(mock sqlmock.Sqlmock) {
-				// No database call expected
+				mock.ExpectQuery(`UPDATE reservations SET guest_name = \$1, updated_at = NOW\(\) WHERE id = \$2 AND deleted_at IS NULL AND updated_at = \$3 RETURNING updated_at`).
+					WithArgs("Updated Name", reservationID, updatedAt).
+					WillReturnRows(returned())
 			},
-			wantErr: true,
-			errMsg:  "no fields to update",
+			wantErr: false,
 		},
-	}
+		{
+			name: "set special requests",
+			id:   reservationID,
+			reservation: &types.Reservation{
+				SpecialRequests: stringPtr("Window seat"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET special_requests = \$1, updated_at = NOW\(\) WHERE id = \$2 AND deleted_at IS NULL RETURNING updated_at`).
+					WithArgs("Window seat", reservationID).
+					WillReturnRows(returned())
+			},
+			wantErr: false,
+		},
+		{
+			name: "clear special requests",
+			id:   reservationID,
+			reservation: &types.Reservation{
+				SpecialRequests: stringPtr(""),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET special_requests = NULL, updated_at = NOW\(\) WHERE id = \$1 AND deleted_at IS NULL RETURNING updated_at`).
+					WithArgs(reservationID).
+					WillReturnRows(returned())
+			},
+			wantErr: false,
+		},
+		{
+			name: "reservation modified concurrently",
+			id:   reservationID,
+			reservation: &types.Reservation{
+				GuestName: "Updated Name",
+			},
+			expected: &updatedAt,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET guest_name = \$1, updated_at = NOW\(\) WHERE id = \$2 AND deleted_at IS NULL AND updated_at = \$3 RETURNING updated_at`).
+					WithArgs("Updated Name", reservationID, updatedAt).
+					WillReturnRows(noRows())
+				mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM reservations WHERE id = \$1 AND deleted_at IS NULL\)`).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			wantErr:  true,
+			conflict: true,
+		},
+		{
+			name: "versioned update of missing reservation",
+			id:   reservationID,
+			reservation: &types.Reservation{
+				GuestName: "Updated Name",
+			},
+			expected: &updatedAt,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations`).
+					WithArgs("Updated Name", reservationID, updatedAt).
+					WillReturnRows(noRows())
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			wantErr: true,
+			errMsg:  "reservation not found",
+		},
+		{
+			name: "no fields to update",
+			id:   reservationID,
+			reservation: &types.Reservation{},
+			mock: func(mock sqlmock.Sqlmock) {
+				// No database call expected
+			},
+			wantErr: true,
+			errMsg:  "no fields to update",
+		},
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -476,15 +899,19 @@ func TestReservationQ_Update(t *testing.T) {
 			tt.mock(mock)
 
 			ctx := context.Background()
-			err := reservationQ.Update(ctx, tt.id, tt.reservation)
+			got, err := reservationQ.Update(ctx, tt.id, tt.reservation, tt.expected)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				if tt.errMsg != "" {
 					assert.EqualError(t, err, tt.errMsg)
 				}
+				if tt.conflict {
+					assert.ErrorIs(t, err, data.ErrConflict)
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, newUpdatedAt, got)
 			}
 
 			// Note: ExpectationsWereMet might fail for Update due to dynamic query building
@@ -495,33 +922,268 @@ func TestReservationQ_Update(t *testing.T) {
 
 func TestReservationQ_UpdateStatus(t *testing.T) {
 	reservationID := uuid.New()
+	userID := uuid.New()
+	updatedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		fromStatus string
+		status     string
+		mock       func(mock sqlmock.Sqlmock)
+		want       *types.Reservation
+		wantErr    bool
+		errMsg     string
+		conflict   bool
+	}{
+		{
+			name:       "successful update",
+			id:         reservationID,
+			fromStatus: "pending",
+			status:     "confirmed",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "updated_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "confirmed", updatedAt)
+				mock.ExpectQuery(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2 AND status = \$3 AND deleted_at IS NULL RETURNING id, user_id, .*, staff_notes, recurrence_group_id, created_at, updated_at, ARRAY\(.*\) AS table_numbers`).
+					WithArgs("confirmed", reservationID, "pending").
+					WillReturnRows(rows)
+			},
+			want: &types.Reservation{
+				ID:          reservationID,
+				UserID:      &userID,
+				GuestName:   "John Doe",
+				GuestPhone:  "+1234567890",
+				GuestEmail:  "john@example.com",
+				Date:        time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				Time:        "19:00",
+				Guests:      4,
+				TableNumber: "T1",
+				Status:      "confirmed",
+				UpdatedAt:   updatedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "reservation not found",
+			id:         reservationID,
+			fromStatus: "pending",
+			status:     "confirmed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+					WithArgs("confirmed", reservationID, "pending").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM reservations WHERE id = \$1 AND deleted_at IS NULL\)`).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			wantErr: true,
+			errMsg:  "reservation not found",
+		},
+		{
+			name:       "status changed concurrently",
+			id:         reservationID,
+			fromStatus: "pending",
+			status:     "confirmed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2 AND status = \$3`).
+					WithArgs("confirmed", reservationID, "pending").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			wantErr:  true,
+			conflict: true,
+		},
+		{
+			name:       "database error",
+			id:         reservationID,
+			fromStatus: "pending",
+			status:     "confirmed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+					WithArgs("confirmed", reservationID, "pending").
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reservationQ.UpdateStatus(ctx, tt.id, tt.fromStatus, tt.status)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+				if tt.conflict {
+					assert.ErrorIs(t, err, data.ErrConflict)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_BulkCreate(t *testing.T) {
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	newReservations := func(n int) []*types.Reservation {
+		reservations := make([]*types.Reservation, n)
+		for i := range reservations {
+			reservations[i] = &types.Reservation{
+				GuestName:   "Guest",
+				GuestPhone:  "+380501234567",
+				GuestEmail:  "guest@example.com",
+				Date:        date,
+				Time:        "19:00",
+				Guests:      2,
+				TableNumber: "T1",
+				Status:      "completed",
+			}
+		}
+		return reservations
+	}
+
+	t.Run("inserts all rows in one statement", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		reservations := newReservations(2)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reservations \(id, user_id, .*, created_at\) VALUES \(\$1, .*, \$13\), \(\$14, .*, \$26\)$`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := reservationQ.BulkCreate(context.Background(), reservations, types.ReservationStatusPending)
+		assert.NoError(t, err)
+		for _, reservation := range reservations {
+			assert.NotEqual(t, uuid.Nil, reservation.ID)
+			assert.False(t, reservation.CreatedAt.IsZero())
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("splits large imports into batches", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reservations`).WillReturnResult(sqlmock.NewResult(0, bulkCreateBatchSize))
+		mock.ExpectExec(`INSERT INTO reservations .* VALUES \(\$1, [^()]*\)$`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := reservationQ.BulkCreate(context.Background(), newReservations(bulkCreateBatchSize+1), types.ReservationStatusPending)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stores merged table sets", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		reservations := newReservations(1)
+		reservations[0].ID = uuid.New()
+		reservations[0].TableNumbers = []string{"T1", "T2"}
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reservations`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO reservation_tables`).WithArgs(reservations[0].ID, "T1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO reservation_tables`).WithArgs(reservations[0].ID, "T2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := reservationQ.BulkCreate(context.Background(), reservations, types.ReservationStatusPending)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creates reservations without a status in the initial status", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		reservations := newReservations(2)
+		reservations[1].Status = ""
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reservations`).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := reservationQ.BulkCreate(context.Background(), reservations, types.ReservationStatusConfirmed)
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", reservations[0].Status)
+		assert.Equal(t, types.ReservationStatusConfirmed, reservations[1].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reservations`).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := reservationQ.BulkCreate(context.Background(), newReservations(3), types.ReservationStatusPending)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to insert", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		assert.NoError(t, reservationQ.BulkCreate(context.Background(), nil, types.ReservationStatusPending))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReservationQ_UpdateStaffNotes(t *testing.T) {
+	reservationID := uuid.New()
+	notes := "VIP, allergic to nuts"
 
 	tests := []struct {
 		name    string
-		id      uuid.UUID
-		status  string
+		notes   *string
 		mock    func(mock sqlmock.Sqlmock)
 		wantErr bool
 		errMsg  string
 	}{
 		{
-			name:   "successful update",
-			id:     reservationID,
-			status: "confirmed",
+			name:  "successful update",
+			notes: &notes,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
-					WithArgs("confirmed", reservationID).
+				mock.ExpectExec(`UPDATE reservations SET staff_notes = \$1 WHERE id = \$2`).
+					WithArgs(&notes, reservationID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
 		},
 		{
-			name:   "reservation not found",
-			id:     reservationID,
-			status: "confirmed",
+			name:  "clear notes",
+			notes: nil,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
-					WithArgs("confirmed", reservationID).
+				mock.ExpectExec(`UPDATE reservations SET staff_notes = \$1 WHERE id = \$2`).
+					WithArgs(nil, reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "reservation not found",
+			notes: &notes,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reservations SET staff_notes = \$1 WHERE id = \$2`).
+					WithArgs(&notes, reservationID).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			wantErr: true,
@@ -537,7 +1199,7 @@ func TestReservationQ_UpdateStatus(t *testing.T) {
 			tt.mock(mock)
 
 			ctx := context.Background()
-			err := reservationQ.UpdateStatus(ctx, tt.id, tt.status)
+			err := reservationQ.UpdateStaffNotes(ctx, reservationID, tt.notes)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -564,10 +1226,10 @@ func TestReservationQ_Delete(t *testing.T) {
 		errMsg  string
 	}{
 		{
-			name: "successful delete",
+			name: "successful soft delete",
 			id:   reservationID,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`DELETE FROM reservations WHERE id = \$1`).
+				mock.ExpectExec(`UPDATE reservations SET deleted_at = NOW\(\) WHERE id = \$1 AND deleted_at IS NULL`).
 					WithArgs(reservationID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -577,7 +1239,7 @@ func TestReservationQ_Delete(t *testing.T) {
 			name: "reservation not found",
 			id:   reservationID,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`DELETE FROM reservations WHERE id = \$1`).
+				mock.ExpectExec(`UPDATE reservations SET deleted_at = NOW\(\) WHERE id = \$1 AND deleted_at IS NULL`).
 					WithArgs(reservationID).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -610,44 +1272,128 @@ func TestReservationQ_Delete(t *testing.T) {
 	}
 }
 
-func TestReservationQ_CheckTableAvailability(t *testing.T) {
+func TestReservationQ_HardDelete(t *testing.T) {
+	userID := uuid.New()
+	reservationID := uuid.New()
+	createdAt := time.Now()
+	updatedAt := time.Now()
+	testDate := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
-		name         string
-		tableNumber  string
-		date         string
-		time         string
-		mock         func(mock sqlmock.Sqlmock)
-		want         bool
-		wantErr      bool
+		name    string
+		id      uuid.UUID
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errMsg  string
 	}{
 		{
-			name:        "table available",
-			tableNumber: "T1",
-			date:        "2025-12-25",
-			time:        "19:00",
+			name: "successful hard delete",
+			id:   reservationID,
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
-				mock.ExpectQuery(`SELECT COUNT.*FROM reservations WHERE table_number = \$1 AND date = \$2::date AND time = \$3::time AND status IN`).
-					WithArgs("T1", "2025-12-25", "19:00").
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at", "deleted_at"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "pending", nil, createdAt, updatedAt, nil)
+				mock.ExpectQuery(`DELETE FROM reservations WHERE id = \$1 RETURNING`).
+					WithArgs(reservationID).
 					WillReturnRows(rows)
 			},
-			want:    true,
 			wantErr: false,
 		},
 		{
-			name:        "table not available",
-			tableNumber: "T1",
-			date:        "2025-12-25",
-			time:        "19:00",
+			name: "reservation not found",
+			id:   reservationID,
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-				mock.ExpectQuery(`SELECT COUNT.*FROM reservations WHERE table_number = \$1 AND date = \$2::date AND time = \$3::time AND status IN`).
-					WithArgs("T1", "2025-12-25", "19:00").
-					WillReturnRows(rows)
+				mock.ExpectQuery(`DELETE FROM reservations WHERE id = \$1 RETURNING`).
+					WithArgs(reservationID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+			errMsg:  "reservation not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reservationQ.HardDelete(ctx, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, got)
+				assert.Equal(t, &userID, got.UserID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetDeleted(t *testing.T) {
+	userID := uuid.New()
+	reservationID := uuid.New()
+	createdAt := time.Now()
+	updatedAt := time.Now()
+	deletedAt := time.Now()
+	testDate := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	reservationQ, mock, teardown := setupReservationTestDB(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "guest_email", "date", "time", "guests", "table_number", "status", "special_requests", "created_at", "updated_at", "deleted_at"}).
+		AddRow(reservationID, userID, "John Doe", "+1234567890", "john@example.com", testDate, "19:00", 4, "T1", "cancelled", nil, createdAt, updatedAt, deletedAt)
+	mock.ExpectQuery(`SELECT.*FROM reservations WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`).
+		WillReturnRows(rows)
+
+	got, err := reservationQ.GetDeleted(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.NotNil(t, got[0].DeletedAt)
+	assert.Equal(t, reservationID, got[0].ID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationQ_Restore(t *testing.T) {
+	reservationID := uuid.New()
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "successful restore",
+			id:   reservationID,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reservations SET deleted_at = NULL WHERE id = \$1 AND deleted_at IS NOT NULL`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
-			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "reservation not deleted",
+			id:   reservationID,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reservations SET deleted_at = NULL WHERE id = \$1 AND deleted_at IS NOT NULL`).
+					WithArgs(reservationID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+			errMsg:  "deleted reservation not found",
+		},
 	}
 
 	for _, tt := range tests {
@@ -658,7 +1404,683 @@ func TestReservationQ_CheckTableAvailability(t *testing.T) {
 			tt.mock(mock)
 
 			ctx := context.Background()
-			got, err := reservationQ.CheckTableAvailability(ctx, tt.tableNumber, tt.date, tt.time)
+			err := reservationQ.Restore(ctx, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_BulkUpdateStatus(t *testing.T) {
+	reservationID1 := uuid.New()
+	reservationID2 := uuid.New()
+	adminID := uuid.New()
+
+	newChanges := func() []*types.StatusChange {
+		return []*types.StatusChange{
+			{ReservationID: reservationID1, FromStatus: "pending", ToStatus: "confirmed", ChangedBy: &adminID},
+			{ReservationID: reservationID2, FromStatus: "pending", ToStatus: "confirmed", ChangedBy: &adminID},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "all changes applied",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				for _, id := range []uuid.UUID{reservationID1, reservationID2} {
+					mock.ExpectExec(`UPDATE reservations SET status = \$1, updated_at = NOW\(\) WHERE id = \$2 AND status = \$3 AND deleted_at IS NULL`).
+						WithArgs("confirmed", id, "pending").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO reservation_status_history`).
+						WithArgs(sqlmock.AnyArg(), id, "pending", "confirmed", &adminID, sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "rolls back when a reservation changed concurrently",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE reservations SET status = \$1`).
+					WithArgs("confirmed", reservationID1, "pending").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO reservation_status_history`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE reservations SET status = \$1`).
+					WithArgs("confirmed", reservationID2, "pending").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "rolls back on database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE reservations SET status = \$1`).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			err := reservationQ.BulkUpdateStatus(ctx, newChanges())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetByTableNumber(t *testing.T) {
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "reservations in range",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "date", "time", "guests", "table_number", "status"}).
+					AddRow(uuid.New(), uuid.New(), "John Doe", "+1234567890", time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "confirmed").
+					AddRow(uuid.New(), uuid.New(), "Jane Doe", "+1234567891", time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "18:00", 8, "T2", "pending")
+				mock.ExpectQuery(`FROM reservations r WHERE \( r.table_number = \$1 OR EXISTS \(.*rt.table_number = \$1.*\) AND r.date BETWEEN \$2::date AND \$3::date AND r.deleted_at IS NULL ORDER BY r.date, r.time`).
+					WithArgs("T1", "2025-12-01", "2025-12-31").
+					WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "no reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM reservations r WHERE`).
+					WithArgs("T1", "2025-12-01", "2025-12-31").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: 0,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM reservations r WHERE`).
+					WithArgs("T1", "2025-12-01", "2025-12-31").
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.GetByTableNumber(context.Background(), "T1", from, to)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+				assert.Len(t, got, tt.want)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_CountActiveByUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "counts upcoming active reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE user_id = \$1 AND status IN \('pending', 'confirmed'\) AND deleted_at IS NULL AND \(date \+ time\) >= \$2::timestamp`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE user_id = \$1`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.CountActiveByUser(context.Background(), userID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetByRecurrenceGroup(t *testing.T) {
+	groupID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	testDate := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "returns occurrences in order",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "guest_name", "date", "time", "table_number", "status", "recurrence_group_id", "created_at", "updated_at"}).
+					AddRow(firstID, "Acme Corp", testDate, "12:00:00", "T1", "confirmed", groupID, createdAt, createdAt).
+					AddRow(secondID, "Acme Corp", testDate.AddDate(0, 0, 7), "12:00:00", "T1", "confirmed", groupID, createdAt, createdAt)
+				mock.ExpectQuery(`SELECT .* FROM reservations WHERE recurrence_group_id = \$1 AND deleted_at IS NULL ORDER BY date, time`).
+					WithArgs(groupID).
+					WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .* FROM reservations WHERE recurrence_group_id = \$1`).
+					WithArgs(groupID).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.GetByRecurrenceGroup(context.Background(), groupID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got, tt.want)
+				assert.Equal(t, firstID, got[0].ID)
+				require.NotNil(t, got[0].RecurrenceGroupID)
+				assert.Equal(t, groupID, *got[0].RecurrenceGroupID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_CountUpcomingByUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "counts upcoming reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE user_id = \$1 AND status <> 'cancelled' AND deleted_at IS NULL AND \(date > \$2::timestamp::date OR \(date = \$2::timestamp::date AND time >= \$2::timestamp::time\)\)`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want: 3,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE user_id = \$1`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.CountUpcomingByUser(context.Background(), userID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_MarkPastAsCompleted(t *testing.T) {
+	reservationID := uuid.New()
+	userID := uuid.New()
+	date := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    []types.CompletedReservation
+		wantErr bool
+	}{
+		{
+			name: "marks past reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "restaurant_id", "user_id", "date"}).
+					AddRow(reservationID, types.DefaultRestaurantID, userID, date)
+				mock.ExpectQuery(`WITH completed AS \( UPDATE reservations SET status = 'completed'.*WHERE status = 'confirmed'.*AND \(date \+ time\) < \$1::timestamp.*RETURNING id, restaurant_id, user_id, date.*INSERT INTO reservation_status_history.*SELECT id, restaurant_id, user_id, date FROM completed`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want: []types.CompletedReservation{
+				{ID: reservationID, RestaurantID: types.DefaultRestaurantID, UserID: &userID, Date: date},
+			},
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WITH completed AS`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reservationQ.MarkPastAsCompleted(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetByConfirmationCode(t *testing.T) {
+	reservationID := uuid.New()
+	userID := uuid.New()
+	code := "K7M2QX"
+
+	tests := []struct {
+		name     string
+		mock     func(mock sqlmock.Sqlmock)
+		wantErr  bool
+		notFound bool
+	}{
+		{
+			name: "successful get",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "date", "time", "guests", "table_number", "status", "confirmation_code"}).
+					AddRow(reservationID, userID, "John Doe", "+1234567890", time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "confirmed", code)
+				mock.ExpectQuery(`SELECT id, user_id, .*confirmation_code, created_at, updated_at, ARRAY\(.*\) AS table_numbers FROM reservations WHERE confirmation_code = \$1 AND deleted_at IS NULL`).
+					WithArgs(code).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "unknown code",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM reservations WHERE confirmation_code = \$1`).
+					WithArgs(code).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr:  true,
+			notFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.GetByConfirmationCode(context.Background(), code)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.notFound {
+					assert.ErrorIs(t, err, data.ErrNotFound)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, got)
+				assert.Equal(t, reservationID, got.ID)
+				require.NotNil(t, got.ConfirmationCode)
+				assert.Equal(t, code, *got.ConfirmationCode)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetByPhoneAndDate(t *testing.T) {
+	phone := "+1234567890"
+	date := "2025-12-25"
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "active reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "guest_name", "guest_phone", "date", "time", "guests", "table_number", "status"}).
+					AddRow(uuid.New(), uuid.New(), "John Doe", phone, time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "19:00", 4, "T1", "pending").
+					AddRow(uuid.New(), uuid.New(), "John Doe", phone, time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), "20:00", 4, "T2", "confirmed")
+				mock.ExpectQuery(`SELECT .*TO_CHAR\(time, 'HH24:MI'\) AS time.* FROM reservations WHERE guest_phone = \$1 AND date = \$2::date AND status IN \('pending', 'confirmed'\) AND deleted_at IS NULL`).
+					WithArgs(phone, date).
+					WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "no reservations",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM reservations WHERE guest_phone = \$1`).
+					WithArgs(phone, date).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: 0,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM reservations WHERE guest_phone = \$1`).
+					WithArgs(phone, date).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.GetByPhoneAndDate(context.Background(), phone, date)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.want)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_CheckTableAvailability(t *testing.T) {
+	tests := []struct {
+		name         string
+		tableNumber  string
+		date         string
+		time         string
+		mock         func(mock sqlmock.Sqlmock)
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:        "table available",
+			tableNumber: "T1",
+			date:        "2025-12-25",
+			time:        "19:00",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
+				mock.ExpectQuery(`SELECT COUNT.*FROM reservations r WHERE \( r.table_number = \$1 OR EXISTS \(.*reservation_tables rt.*\) \) AND r.date = \$2::date AND r.time = \$3::time AND r.status IN`).
+					WithArgs("T1", "2025-12-25", "19:00").
+					WillReturnRows(rows)
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:        "table not available",
+			tableNumber: "T1",
+			date:        "2025-12-25",
+			time:        "19:00",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(`SELECT COUNT.*FROM reservations r WHERE \( r.table_number = \$1 OR EXISTS \(.*reservation_tables rt.*\) \) AND r.date = \$2::date AND r.time = \$3::time AND r.status IN`).
+					WithArgs("T1", "2025-12-25", "19:00").
+					WillReturnRows(rows)
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := reservationQ.CheckTableAvailability(ctx, tt.tableNumber, tt.date, tt.time)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_CountConcurrentAt(t *testing.T) {
+	excludeID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "counts active reservations overlapping the slot",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE date = \$1::date AND ABS\(EXTRACT\(EPOCH FROM time - \$2::time\)\) < \$3 AND id <> \$4 AND status IN \('pending', 'confirmed'\) AND deleted_at IS NULL`).
+					WithArgs("2025-12-25", "19:00", float64(1800), excludeID).
+					WillReturnRows(rows)
+			},
+			want: 3,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reservations WHERE date = \$1::date`).
+					WithArgs("2025-12-25", "19:00", float64(1800), excludeID).
+					WillReturnError(sql.ErrConnDone)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.CountConcurrentAt(context.Background(), "2025-12-25", "19:00", 30*time.Minute, excludeID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationQ_GetBookedTimes(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableNumber string
+		date        string
+		mock        func(mock sqlmock.Sqlmock)
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "booked times",
+			tableNumber: "T1",
+			date:        "2025-12-25",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"time"}).AddRow("18:00").AddRow("20:30")
+				mock.ExpectQuery(`SELECT DISTINCT TO_CHAR\(r.time, 'HH24:MI'\) AS time FROM reservations r WHERE \( r.table_number = \$1 OR EXISTS \(.*reservation_tables rt.*\) \) AND r.date = \$2::date AND r.status IN`).
+					WithArgs("T1", "2025-12-25").
+					WillReturnRows(rows)
+			},
+			want: []string{"18:00", "20:30"},
+		},
+		{
+			name:        "no reservations",
+			tableNumber: "T1",
+			date:        "2025-12-25",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT TO_CHAR`).
+					WithArgs("T1", "2025-12-25").
+					WillReturnRows(sqlmock.NewRows([]string{"time"}))
+			},
+			want: []string{},
+		},
+		{
+			name:        "database error",
+			tableNumber: "T1",
+			date:        "2025-12-25",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT TO_CHAR`).
+					WithArgs("T1", "2025-12-25").
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationQ, mock, teardown := setupReservationTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := reservationQ.GetBookedTimes(context.Background(), tt.tableNumber, tt.date)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// RestaurantQ implements data.RestaurantQ interface
+type RestaurantQ struct {
+	db sqlxExt
+}
+
+// NewRestaurantQ creates a new RestaurantQ instance
+func NewRestaurantQ(db sqlxExt) data.RestaurantQ {
+	return &RestaurantQ{db: db}
+}
+
+// GetByID retrieves a restaurant by ID
+func (q *RestaurantQ) GetByID(ctx context.Context, id uuid.UUID) (*types.Restaurant, error) {
+	query := `
+		SELECT id, slug, name, created_at
+		FROM restaurants
+		WHERE id = $1
+	`
+
+	var restaurant types.Restaurant
+	err := sqlx.GetContext(ctx, q.db, &restaurant, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("restaurant %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &restaurant, nil
+}
+
+// GetBySlug retrieves a restaurant by its slug
+func (q *RestaurantQ) GetBySlug(ctx context.Context, slug string) (*types.Restaurant, error) {
+	query := `
+		SELECT id, slug, name, created_at
+		FROM restaurants
+		WHERE slug = $1
+	`
+
+	var restaurant types.Restaurant
+	err := sqlx.GetContext(ctx, q.db, &restaurant, query, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("restaurant %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &restaurant, nil
+}
+
+// GetAll retrieves all restaurants ordered by name
+func (q *RestaurantQ) GetAll(ctx context.Context) ([]*types.Restaurant, error) {
+	query := `
+		SELECT id, slug, name, created_at
+		FROM restaurants
+		ORDER BY name
+	`
+
+	restaurants := make([]*types.Restaurant, 0)
+	err := sqlx.SelectContext(ctx, q.db, &restaurants, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return restaurants, nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+)
+
+// restaurantScope returns the condition limiting column to the restaurant ctx is scoped to,
+// passing the restaurant as the argPos-th query argument. Unscoped contexts get no condition
+// and no argument, so single-restaurant deployments run the plain queries
+func restaurantScope(ctx context.Context, column string, argPos int) (string, []interface{}) {
+	return restaurantCondition(ctx, "AND", column, argPos)
+}
+
+// restaurantWhere is restaurantScope for queries without a WHERE clause of their own
+func restaurantWhere(ctx context.Context, column string, argPos int) (string, []interface{}) {
+	return restaurantCondition(ctx, "WHERE", column, argPos)
+}
+
+func restaurantCondition(ctx context.Context, keyword, column string, argPos int) (string, []interface{}) {
+	restaurantID, ok := tenant.RestaurantFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" %s %s = $%d", keyword, column, argPos), []interface{}{restaurantID}
+}
+
+// restaurantTable binds a table together with the restaurant it is created for
+type restaurantTable struct {
+	*types.Table
+	RestaurantID uuid.UUID `db:"restaurant_id"`
+}
+
+// restaurantReservation binds a reservation together with the restaurant it is created for
+type restaurantReservation struct {
+	*types.Reservation
+	RestaurantID uuid.UUID `db:"restaurant_id"`
+}
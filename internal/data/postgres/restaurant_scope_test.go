@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestaurantScope(t *testing.T) {
+	restaurantID := uuid.New()
+
+	scope, args := restaurantScope(context.Background(), "restaurant_id", 2)
+	assert.Empty(t, scope)
+	assert.Empty(t, args)
+
+	ctx := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	scope, args = restaurantScope(ctx, "r.restaurant_id", 2)
+	assert.Equal(t, " AND r.restaurant_id = $2", scope)
+	assert.Equal(t, []interface{}{restaurantID}, args)
+
+	scope, args = restaurantWhere(ctx, "restaurant_id", 1)
+	assert.Equal(t, " WHERE restaurant_id = $1", scope)
+	assert.Equal(t, []interface{}{restaurantID}, args)
+}
+
+func TestTableQ_ScopedToRestaurant(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	t.Run("get all", func(t *testing.T) {
+		tableQ, mock, teardown := setupTableTestDB(t)
+		defer teardown()
+
+		rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "status", "created_at", "updated_at"}).
+			AddRow(uuid.New(), "T1", 4, true, "main", types.TableStatusActive, time.Now(), time.Now())
+		mock.ExpectQuery(`FROM tables WHERE restaurant_id = \$1 ORDER BY number`).
+			WithArgs(restaurantID).
+			WillReturnRows(rows)
+
+		tables, err := tableQ.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, tables, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create", func(t *testing.T) {
+		tableQ, mock, teardown := setupTableTestDB(t)
+		defer teardown()
+
+		table := &types.Table{Number: "T1", Capacity: 4, IsAvailable: true, Location: "main"}
+
+		mock.ExpectExec(`INSERT INTO tables \(id, number, capacity, is_available, location, status, restaurant_id, created_at, updated_at\)`).
+			WithArgs(sqlmock.AnyArg(), "T1", 4, true, "main", types.TableStatusActive, restaurantID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		require.NoError(t, tableQ.Create(ctx, table))
+		assert.NotEqual(t, uuid.Nil, table.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update outside the restaurant", func(t *testing.T) {
+		tableQ, mock, teardown := setupTableTestDB(t)
+		defer teardown()
+
+		id := uuid.New()
+		mock.ExpectExec(`UPDATE tables SET status = \$1, updated_at = NOW\(\) WHERE id = \$2 AND restaurant_id = \$3`).
+			WithArgs(types.TableStatusRetired, id, restaurantID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := tableQ.UpdateStatus(ctx, id, types.TableStatusRetired)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReservationQ_ScopedToRestaurant(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	t.Run("get by id", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		id := uuid.New()
+		mock.ExpectQuery(`FROM reservations WHERE id = \$1 AND deleted_at IS NULL AND restaurant_id = \$2`).
+			WithArgs(id, restaurantID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := reservationQ.GetByID(ctx, id)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		reservation := &types.Reservation{
+			GuestName:   "John Doe",
+			GuestPhone:  "+380501234567",
+			GuestEmail:  "john@example.com",
+			Date:        time.Now(),
+			Time:        "19:00",
+			Guests:      2,
+			TableNumber: "T1",
+		}
+
+		mock.ExpectExec(`INSERT INTO reservations`).
+			WithArgs(sqlmock.AnyArg(), nil, "John Doe", "+380501234567", "john@example.com",
+				sqlmock.AnyArg(), "19:00", 2, "T1", "pending", nil, nil, nil, restaurantID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		require.NoError(t, reservationQ.Create(ctx, reservation, types.ReservationStatusPending))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("table availability", func(t *testing.T) {
+		reservationQ, mock, teardown := setupReservationTestDB(t)
+		defer teardown()
+
+		// Table numbers repeat across restaurants, so only this restaurant's reservations hold T1
+		mock.ExpectQuery(`SELECT COUNT.*FROM reservations r WHERE .*r.table_number = \$1.*AND r.deleted_at IS NULL AND r.restaurant_id = \$4`).
+			WithArgs("T1", "2025-12-25", "19:00", restaurantID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		available, err := reservationQ.CheckTableAvailability(ctx, "T1", "2025-12-25", "19:00")
+		require.NoError(t, err)
+		assert.True(t, available)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
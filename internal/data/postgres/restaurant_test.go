@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRestaurantTestDB(t *testing.T) (*RestaurantQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	restaurantQ := NewRestaurantQ(sqlxDB).(*RestaurantQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return restaurantQ, mock, teardown
+}
+
+func TestRestaurantQ_GetBySlug(t *testing.T) {
+	restaurantID := uuid.New()
+	createdAt := time.Now()
+
+	tests := []struct {
+		name        string
+		slug        string
+		mock        func(mock sqlmock.Sqlmock)
+		want        *types.Restaurant
+		wantErr     bool
+		errNotFound bool
+	}{
+		{
+			name: "successful get",
+			slug: "downtown",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "slug", "name", "created_at"}).
+					AddRow(restaurantID, "downtown", "Downtown Bistro", createdAt)
+				mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants WHERE slug = \$1`).
+					WithArgs("downtown").
+					WillReturnRows(rows)
+			},
+			want: &types.Restaurant{
+				ID:        restaurantID,
+				Slug:      "downtown",
+				Name:      "Downtown Bistro",
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "restaurant not found",
+			slug: "missing",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants WHERE slug = \$1`).
+					WithArgs("missing").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+		{
+			name: "database error",
+			slug: "downtown",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants WHERE slug = \$1`).
+					WithArgs("downtown").
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restaurantQ, mock, teardown := setupRestaurantTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := restaurantQ.GetBySlug(context.Background(), tt.slug)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestaurantQ_GetByID(t *testing.T) {
+	restaurantID := uuid.New()
+
+	t.Run("restaurant not found", func(t *testing.T) {
+		restaurantQ, mock, teardown := setupRestaurantTestDB(t)
+		defer teardown()
+
+		mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants WHERE id = \$1`).
+			WithArgs(restaurantID).
+			WillReturnError(sql.ErrNoRows)
+
+		got, err := restaurantQ.GetByID(context.Background(), restaurantID)
+
+		assert.ErrorIs(t, err, data.ErrNotFound)
+		assert.Nil(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRestaurantQ_GetAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "several restaurants",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "slug", "name", "created_at"}).
+					AddRow(types.DefaultRestaurantID, "default", "Default restaurant", time.Now()).
+					AddRow(uuid.New(), "downtown", "Downtown Bistro", time.Now())
+				mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants ORDER BY name`).
+					WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, slug, name, created_at FROM restaurants`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restaurantQ, mock, teardown := setupRestaurantTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := restaurantQ.GetAll(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, got, tt.want)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
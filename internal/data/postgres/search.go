@@ -0,0 +1,12 @@
+package postgres
+
+import "strings"
+
+// likeEscaper escapes the characters that have a special meaning in LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern builds an ILIKE pattern matching values that contain term literally.
+// It must be used together with ESCAPE '\' in the query
+func containsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
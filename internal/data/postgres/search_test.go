@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{name: "plain term", term: "John", want: "%John%"},
+		{name: "percent sign", term: "100%", want: `%100\%%`},
+		{name: "underscore", term: "a_b", want: `%a\_b%`},
+		{name: "backslash", term: `a\b`, want: `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, containsPattern(tt.term))
+		})
+	}
+}
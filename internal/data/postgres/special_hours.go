@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// specialHoursColumns lists the special_hours columns, with times formatted as HH:MM
+const specialHoursColumns = `id, date, TO_CHAR(open_time, 'HH24:MI') AS open_time, TO_CHAR(close_time, 'HH24:MI') AS close_time,
+		       note, created_at, updated_at`
+
+// SpecialHoursQ implements data.SpecialHoursQ interface
+type SpecialHoursQ struct {
+	db sqlxExt
+}
+
+// NewSpecialHoursQ creates a new SpecialHoursQ instance
+func NewSpecialHoursQ(db sqlxExt) data.SpecialHoursQ {
+	return &SpecialHoursQ{db: db}
+}
+
+// restaurantSpecialHours binds special hours together with the restaurant they are created for
+type restaurantSpecialHours struct {
+	*types.SpecialHours
+	RestaurantID uuid.UUID `db:"restaurant_id"`
+}
+
+// Create overrides the business hours of a date
+func (q *SpecialHoursQ) Create(ctx context.Context, hours *types.SpecialHours) error {
+	query := `
+		INSERT INTO special_hours (id, date, open_time, close_time, note, created_at, updated_at)
+		VALUES (:id, :date, :open_time, :close_time, :note, :created_at, :updated_at)
+	`
+
+	if hours.ID == uuid.Nil {
+		hours.ID = uuid.New()
+	}
+
+	now := time.Now()
+	if hours.CreatedAt.IsZero() {
+		hours.CreatedAt = now
+	}
+	if hours.UpdatedAt.IsZero() {
+		hours.UpdatedAt = now
+	}
+
+	var err error
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query = `
+			INSERT INTO special_hours (id, date, open_time, close_time, note, restaurant_id, created_at, updated_at)
+			VALUES (:id, :date, :open_time, :close_time, :note, :restaurant_id, :created_at, :updated_at)
+		`
+		_, err = sqlx.NamedExecContext(ctx, q.db, query, restaurantSpecialHours{SpecialHours: hours, RestaurantID: restaurantID})
+	} else {
+		_, err = sqlx.NamedExecContext(ctx, q.db, query, hours)
+	}
+
+	return specialHoursDateConflict(err)
+}
+
+// GetByID retrieves special hours by ID
+func (q *SpecialHoursQ) GetByID(ctx context.Context, id uuid.UUID) (*types.SpecialHours, error) {
+	query := `SELECT ` + specialHoursColumns + ` FROM special_hours WHERE id = $1`
+	args := []interface{}{id}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope
+	args = append(args, scopeArgs...)
+
+	var hours types.SpecialHours
+	if err := sqlx.GetContext(ctx, q.db, &hours, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("special hours %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &hours, nil
+}
+
+// GetByDate retrieves the special hours of the date
+func (q *SpecialHoursQ) GetByDate(ctx context.Context, date string) (*types.SpecialHours, error) {
+	query := `SELECT ` + specialHoursColumns + ` FROM special_hours WHERE date = $1`
+	args := []interface{}{date}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope
+	args = append(args, scopeArgs...)
+
+	var hours types.SpecialHours
+	if err := sqlx.GetContext(ctx, q.db, &hours, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("special hours %w", data.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &hours, nil
+}
+
+// GetInRange retrieves the special hours of the dates from through to, ordered by date
+func (q *SpecialHoursQ) GetInRange(ctx context.Context, from, to time.Time) ([]*types.SpecialHours, error) {
+	query := `SELECT ` + specialHoursColumns + ` FROM special_hours WHERE date BETWEEN $1 AND $2`
+	args := []interface{}{from.Format("2006-01-02"), to.Format("2006-01-02")}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 3)
+	query += scope + " ORDER BY date"
+	args = append(args, scopeArgs...)
+
+	hours := make([]*types.SpecialHours, 0)
+	if err := sqlx.SelectContext(ctx, q.db, &hours, query, args...); err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// Update saves the date, times and note of existing special hours and sets their UpdatedAt
+func (q *SpecialHoursQ) Update(ctx context.Context, hours *types.SpecialHours) error {
+	query := `
+		UPDATE special_hours
+		SET date = $1, open_time = $2, close_time = $3, note = $4
+		WHERE id = $5
+	`
+	args := []interface{}{hours.Date, hours.OpenTime, hours.CloseTime, hours.Note, hours.ID}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 6)
+	query += scope + " RETURNING updated_at"
+	args = append(args, scopeArgs...)
+
+	if err := sqlx.GetContext(ctx, q.db, &hours.UpdatedAt, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("special hours %w", data.ErrNotFound)
+		}
+		return specialHoursDateConflict(err)
+	}
+
+	return nil
+}
+
+// Delete removes special hours by ID
+func (q *SpecialHoursQ) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM special_hours WHERE id = $1`
+	args := []interface{}{id}
+
+	scope, scopeArgs := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope
+	args = append(args, scopeArgs...)
+
+	result, err := q.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("special hours %w", data.ErrNotFound)
+	}
+
+	return nil
+}
+
+// specialHoursDateConflict turns a second override of the same date into data.ErrConflict
+func specialHoursDateConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_special_hours_restaurant_date" {
+		return fmt.Errorf("special hours date %w", data.ErrConflict)
+	}
+	return err
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSpecialHoursTestDB(t *testing.T) (*SpecialHoursQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	specialHoursQ := NewSpecialHoursQ(sqlxDB).(*SpecialHoursQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return specialHoursQ, mock, teardown
+}
+
+func TestSpecialHoursQ_Create(t *testing.T) {
+	date := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errConflict bool
+	}{
+		{
+			name: "successful create",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO special_hours`).
+					WithArgs(sqlmock.AnyArg(), date, "18:00", "23:30", "New Year's Eve", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "date already has special hours",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO special_hours`).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_special_hours_restaurant_date"})
+			},
+			wantErr:     true,
+			errConflict: true,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO special_hours`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			hours := &types.SpecialHours{Date: date, OpenTime: "18:00", CloseTime: "23:30", Note: "New Year's Eve"}
+			err := specialHoursQ.Create(context.Background(), hours)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errConflict, errors.Is(err, data.ErrConflict))
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, hours.ID)
+				assert.False(t, hours.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSpecialHoursQ_GetByDate(t *testing.T) {
+	columns := []string{"id", "date", "open_time", "close_time", "note", "created_at", "updated_at"}
+
+	t.Run("date with special hours", func(t *testing.T) {
+		specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+		defer teardown()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(uuid.New(), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), "18:00", "23:30", "", time.Now(), time.Now())
+		mock.ExpectQuery(`SELECT id, date, TO_CHAR\(open_time, 'HH24:MI'\) AS open_time, .* FROM special_hours WHERE date = \$1`).
+			WithArgs("2025-12-31").
+			WillReturnRows(rows)
+
+		hours, err := specialHoursQ.GetByDate(context.Background(), "2025-12-31")
+
+		require.NoError(t, err)
+		assert.Equal(t, "18:00", hours.OpenTime)
+		assert.Equal(t, "23:30", hours.CloseTime)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("date without special hours", func(t *testing.T) {
+		specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+		defer teardown()
+
+		mock.ExpectQuery(`FROM special_hours WHERE date = \$1`).
+			WithArgs("2025-12-30").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		hours, err := specialHoursQ.GetByDate(context.Background(), "2025-12-30")
+
+		assert.True(t, errors.Is(err, data.ErrNotFound))
+		assert.Nil(t, hours)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSpecialHoursQ_GetInRange(t *testing.T) {
+	specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"id", "date", "open_time", "close_time", "note", "created_at", "updated_at"}).
+		AddRow(uuid.New(), time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), "12:00", "18:00", "Christmas Eve", time.Now(), time.Now()).
+		AddRow(uuid.New(), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), "18:00", "23:30", "New Year's Eve", time.Now(), time.Now())
+	mock.ExpectQuery(`FROM special_hours WHERE date BETWEEN \$1 AND \$2 ORDER BY date`).
+		WithArgs("2025-12-01", "2025-12-31").
+		WillReturnRows(rows)
+
+	hours, err := specialHoursQ.GetInRange(context.Background(), time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	require.Len(t, hours, 2)
+	assert.Equal(t, "Christmas Eve", hours[0].Note)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpecialHoursQ_Update(t *testing.T) {
+	hours := &types.SpecialHours{
+		ID:        uuid.New(),
+		Date:      time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		OpenTime:  "17:00",
+		CloseTime: "23:30",
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errNotFound bool
+		errConflict bool
+	}{
+		{
+			name: "successful update",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE special_hours SET date = \$1, open_time = \$2, close_time = \$3, note = \$4 WHERE id = \$5 RETURNING updated_at`).
+					WithArgs(hours.Date, "17:00", "23:30", "", hours.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+			},
+		},
+		{
+			name: "special hours not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE special_hours`).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}))
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+		{
+			name: "date already has special hours",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE special_hours`).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_special_hours_restaurant_date"})
+			},
+			wantErr:     true,
+			errConflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := specialHoursQ.Update(context.Background(), hours)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+				assert.Equal(t, tt.errConflict, errors.Is(err, data.ErrConflict))
+			} else {
+				require.NoError(t, err)
+				assert.False(t, hours.UpdatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSpecialHoursQ_Delete(t *testing.T) {
+	specialHoursQ, mock, teardown := setupSpecialHoursTestDB(t)
+	defer teardown()
+
+	id := uuid.New()
+	mock.ExpectExec(`DELETE FROM special_hours WHERE id = \$1`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := specialHoursQ.Delete(context.Background(), id)
+
+	assert.True(t, errors.Is(err, data.ErrNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
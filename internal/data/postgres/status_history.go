@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// StatusHistoryQ implements data.StatusHistoryQ interface
+type StatusHistoryQ struct {
+	db sqlxExt
+}
+
+// NewStatusHistoryQ creates a new StatusHistoryQ instance
+func NewStatusHistoryQ(db sqlxExt) data.StatusHistoryQ {
+	return &StatusHistoryQ{db: db}
+}
+
+// Create records a reservation status change
+func (q *StatusHistoryQ) Create(ctx context.Context, change *types.StatusChange) error {
+	query := `
+		INSERT INTO reservation_status_history (
+			id, reservation_id, from_status, to_status, changed_by, changed_at
+		)
+		VALUES (
+			:id, :reservation_id, :from_status, :to_status, :changed_by, :changed_at
+		)
+	`
+
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now()
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, q.db, query, change)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetByReservationID retrieves the status history of a reservation ordered from oldest to newest
+func (q *StatusHistoryQ) GetByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*types.StatusChange, error) {
+	query := `
+		SELECT id, reservation_id, from_status, to_status, changed_by, changed_at
+		FROM reservation_status_history
+		WHERE reservation_id = $1
+		ORDER BY changed_at ASC
+	`
+
+	history := []*types.StatusChange{}
+	err := sqlx.SelectContext(ctx, q.db, &history, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStatusHistoryTestDB(t *testing.T) (*StatusHistoryQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	statusHistoryQ := NewStatusHistoryQ(sqlxDB).(*StatusHistoryQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return statusHistoryQ, mock, teardown
+}
+
+func TestStatusHistoryQ_Create(t *testing.T) {
+	reservationID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		change  *types.StatusChange
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "successful create",
+			change: &types.StatusChange{
+				ReservationID: reservationID,
+				FromStatus:    "pending",
+				ToStatus:      "confirmed",
+				ChangedBy:     &userID,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_status_history`).
+					WithArgs(sqlmock.AnyArg(), reservationID, "pending", "confirmed", &userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			change: &types.StatusChange{
+				ReservationID: reservationID,
+				FromStatus:    "confirmed",
+				ToStatus:      "cancelled",
+				ChangedBy:     &userID,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO reservation_status_history`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusHistoryQ, mock, teardown := setupStatusHistoryTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			err := statusHistoryQ.Create(ctx, tt.change)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, tt.change.ID)
+				assert.False(t, tt.change.ChangedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStatusHistoryQ_GetByReservationID(t *testing.T) {
+	reservationID := uuid.New()
+	userID := uuid.New()
+	changedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "history with entries",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "reservation_id", "from_status", "to_status", "changed_by", "changed_at"}).
+					AddRow(uuid.New(), reservationID, "pending", "confirmed", userID, changedAt).
+					AddRow(uuid.New(), reservationID, "confirmed", "completed", nil, changedAt.Add(time.Hour))
+				mock.ExpectQuery(`SELECT id, reservation_id, from_status, to_status, changed_by, changed_at FROM reservation_status_history WHERE reservation_id = \$1 ORDER BY changed_at ASC`).
+					WithArgs(reservationID).
+					WillReturnRows(rows)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "empty history",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "reservation_id", "from_status", "to_status", "changed_by", "changed_at"})
+				mock.ExpectQuery(`SELECT.*FROM reservation_status_history WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnRows(rows)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM reservation_status_history WHERE reservation_id = \$1`).
+					WithArgs(reservationID).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusHistoryQ, mock, teardown := setupStatusHistoryTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := statusHistoryQ.GetByReservationID(ctx, reservationID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, got, tt.want)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
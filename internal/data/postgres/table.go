@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 
 	"github.com/google/uuid"
@@ -16,25 +17,29 @@ import (
 
 // TableQ implements data.TableQ interface
 type TableQ struct {
-	db *sqlx.DB
+	db sqlxExt
 }
 
 // NewTableQ creates a new TableQ instance
-func NewTableQ(db *sqlx.DB) data.TableQ {
+func NewTableQ(db sqlxExt) data.TableQ {
 	return &TableQ{db: db}
 }
 
 // Create creates a new table
 func (q *TableQ) Create(ctx context.Context, table *types.Table) error {
 	query := `
-		INSERT INTO tables (id, number, capacity, is_available, location, created_at, updated_at)
-		VALUES (:id, :number, :capacity, :is_available, :location, :created_at, :updated_at)
+		INSERT INTO tables (id, number, capacity, is_available, location, status, created_at, updated_at)
+		VALUES (:id, :number, :capacity, :is_available, :location, :status, :created_at, :updated_at)
 	`
 
 	if table.ID == uuid.Nil {
 		table.ID = uuid.New()
 	}
 
+	if table.Status == "" {
+		table.Status = types.TableStatusActive
+	}
+
 	if table.CreatedAt.IsZero() {
 		table.CreatedAt = time.Now()
 	}
@@ -43,7 +48,16 @@ func (q *TableQ) Create(ctx context.Context, table *types.Table) error {
 		table.UpdatedAt = time.Now()
 	}
 
-	_, err := q.db.NamedExecContext(ctx, query, table)
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query = `
+			INSERT INTO tables (id, number, capacity, is_available, location, status, restaurant_id, created_at, updated_at)
+			VALUES (:id, :number, :capacity, :is_available, :location, :status, :restaurant_id, :created_at, :updated_at)
+		`
+		_, err := sqlx.NamedExecContext(ctx, q.db, query, restaurantTable{Table: table, RestaurantID: restaurantID})
+		return err
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, q.db, query, table)
 	if err != nil {
 		return err
 	}
@@ -54,16 +68,17 @@ func (q *TableQ) Create(ctx context.Context, table *types.Table) error {
 // GetByID retrieves a table by ID
 func (q *TableQ) GetByID(ctx context.Context, id uuid.UUID) (*types.Table, error) {
 	query := `
-		SELECT id, number, capacity, is_available, location, created_at, updated_at
+		SELECT id, number, capacity, is_available, location, status, created_at, updated_at
 		FROM tables
 		WHERE id = $1
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
 	var table types.Table
-	err := q.db.GetContext(ctx, &table, query, id)
+	err := sqlx.GetContext(ctx, q.db, &table, query+scope, append([]interface{}{id}, args...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("table not found")
+			return nil, fmt.Errorf("table %w", data.ErrNotFound)
 		}
 		return nil, err
 	}
@@ -74,16 +89,17 @@ func (q *TableQ) GetByID(ctx context.Context, id uuid.UUID) (*types.Table, error
 // GetByNumber retrieves a table by table number
 func (q *TableQ) GetByNumber(ctx context.Context, number string) (*types.Table, error) {
 	query := `
-		SELECT id, number, capacity, is_available, location, created_at, updated_at
+		SELECT id, number, capacity, is_available, location, status, created_at, updated_at
 		FROM tables
 		WHERE number = $1
 	`
 
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
 	var table types.Table
-	err := q.db.GetContext(ctx, &table, query, number)
+	err := sqlx.GetContext(ctx, q.db, &table, query+scope, append([]interface{}{number}, args...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("table not found")
+			return nil, fmt.Errorf("table %w", data.ErrNotFound)
 		}
 		return nil, err
 	}
@@ -94,13 +110,35 @@ func (q *TableQ) GetByNumber(ctx context.Context, number string) (*types.Table,
 // GetAll retrieves all tables
 func (q *TableQ) GetAll(ctx context.Context) ([]*types.Table, error) {
 	query := `
-		SELECT id, number, capacity, is_available, location, created_at, updated_at
+		SELECT id, number, capacity, is_available, location, status, created_at, updated_at
 		FROM tables
-		ORDER BY number
 	`
 
+	scope, args := restaurantWhere(ctx, "restaurant_id", 1)
+	query += scope + " ORDER BY number"
+
 	var tables []*types.Table
-	err := q.db.SelectContext(ctx, &tables, query)
+	err := sqlx.SelectContext(ctx, q.db, &tables, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// GetByLocation retrieves all tables in a specific location
+func (q *TableQ) GetByLocation(ctx context.Context, location string) ([]*types.Table, error) {
+	query := `
+		SELECT id, number, capacity, is_available, location, status, created_at, updated_at
+		FROM tables
+		WHERE location = $1
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	query += scope + " ORDER BY number"
+
+	var tables []*types.Table
+	err := sqlx.SelectContext(ctx, q.db, &tables, query, append([]interface{}{location}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,13 +149,15 @@ func (q *TableQ) GetAll(ctx context.Context) ([]*types.Table, error) {
 // GetAvailable retrieves available tables with optional filters
 func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabilityFilters) ([]*types.Table, error) {
 	query := `
-		SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.created_at, t.updated_at
+		SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.status, t.created_at, t.updated_at
 		FROM tables t
 		WHERE t.is_available = true
+		  AND t.status = 'active'
 	`
 
-	args := []interface{}{}
-	argPos := 1
+	scope, args := restaurantScope(ctx, "t.restaurant_id", 1)
+	query += scope
+	argPos := len(args) + 1
 
 	// Filter by minimum capacity if provided
 	if filters != nil && filters.Guests != nil {
@@ -126,16 +166,38 @@ func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabil
 		argPos++
 	}
 
+	// Filter by maximum capacity if provided
+	if filters != nil && filters.MaxCapacity != nil {
+		query += fmt.Sprintf(" AND t.capacity <= $%d", argPos)
+		args = append(args, *filters.MaxCapacity)
+		argPos++
+	}
+
+	// Filter by location if provided
+	if filters != nil && filters.Location != nil {
+		query += fmt.Sprintf(" AND t.location = $%d", argPos)
+		args = append(args, *filters.Location)
+		argPos++
+	}
+
 	// Filter by date and time if provided (check for conflicting reservations)
 	if filters != nil && filters.Date != nil && filters.Time != nil {
 		query += fmt.Sprintf(`
-			AND t.number NOT IN (
-				SELECT r.table_number
+			AND NOT EXISTS (
+				SELECT 1
 				FROM reservations r
-				WHERE r.table_number = t.number
+				WHERE r.restaurant_id = t.restaurant_id
+				  AND (
+				        r.table_number = t.number
+				        OR EXISTS (
+				            SELECT 1 FROM reservation_tables rt
+				            WHERE rt.reservation_id = r.id AND rt.table_number = t.number
+				        )
+				      )
 				  AND r.date = $%d::date
 				  AND r.time = $%d::time
 				  AND r.status IN ('pending', 'confirmed')
+				  AND r.deleted_at IS NULL
 			)
 		`, argPos, argPos+1)
 		args = append(args, filters.Date.Format("2006-01-02"), *filters.Time)
@@ -143,12 +205,20 @@ func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabil
 	} else if filters != nil && filters.Date != nil {
 		// Only date filter - exclude tables with any reservation on that date
 		query += fmt.Sprintf(`
-			AND t.number NOT IN (
-				SELECT r.table_number
+			AND NOT EXISTS (
+				SELECT 1
 				FROM reservations r
-				WHERE r.table_number = t.number
+				WHERE r.restaurant_id = t.restaurant_id
+				  AND (
+				        r.table_number = t.number
+				        OR EXISTS (
+				            SELECT 1 FROM reservation_tables rt
+				            WHERE rt.reservation_id = r.id AND rt.table_number = t.number
+				        )
+				      )
 				  AND r.date = $%d::date
 				  AND r.status IN ('pending', 'confirmed')
+				  AND r.deleted_at IS NULL
 			)
 		`, argPos)
 		args = append(args, filters.Date.Format("2006-01-02"))
@@ -158,7 +228,7 @@ func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabil
 	query += " ORDER BY t.number"
 
 	var tables []*types.Table
-	err := q.db.SelectContext(ctx, &tables, query, args...)
+	err := sqlx.SelectContext(ctx, q.db, &tables, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +236,26 @@ func (q *TableQ) GetAvailable(ctx context.Context, filters *types.TableAvailabil
 	return tables, nil
 }
 
+// GetCapacitySummary aggregates table and seat counts
+func (q *TableQ) GetCapacitySummary(ctx context.Context) (*types.CapacitySummary, error) {
+	query := `
+		SELECT
+			COUNT(*) AS total_tables,
+			COALESCE(SUM(capacity), 0) AS total_seats,
+			COUNT(*) FILTER (WHERE is_available = true AND status = 'active') AS available_tables,
+			COALESCE(SUM(capacity) FILTER (WHERE is_available = true AND status = 'active'), 0) AS available_seats
+		FROM tables
+	`
+
+	scope, args := restaurantWhere(ctx, "restaurant_id", 1)
+	var summary types.CapacitySummary
+	if err := sqlx.GetContext(ctx, q.db, &summary, query+scope, args...); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
 // UpdateAvailability updates the availability status of a table
 func (q *TableQ) UpdateAvailability(ctx context.Context, id uuid.UUID, isAvailable bool) error {
 	query := `
@@ -174,7 +264,8 @@ func (q *TableQ) UpdateAvailability(ctx context.Context, id uuid.UUID, isAvailab
 		WHERE id = $2
 	`
 
-	result, err := q.db.ExecContext(ctx, query, isAvailable, id)
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{isAvailable, id}, args...)...)
 	if err != nil {
 		return err
 	}
@@ -185,7 +276,65 @@ func (q *TableQ) UpdateAvailability(ctx context.Context, id uuid.UUID, isAvailab
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("table not found")
+		return fmt.Errorf("table %w", data.ErrNotFound)
+	}
+
+	return nil
+}
+
+// BulkUpdateAvailability updates the availability status of several tables in one transaction
+func (q *TableQ) BulkUpdateAvailability(ctx context.Context, ids []uuid.UUID, isAvailable bool) error {
+	query := `
+		UPDATE tables
+		SET is_available = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	query += scope
+
+	return inTx(ctx, q.db, func(tx sqlxExt) error {
+		for _, id := range ids {
+			result, err := tx.ExecContext(ctx, query, append([]interface{}{isAvailable, id}, args...)...)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if rowsAffected == 0 {
+				return fmt.Errorf("table %s %w", id, data.ErrNotFound)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateStatus updates the service status of a table
+func (q *TableQ) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	query := `
+		UPDATE tables
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 3)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{status, id}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("table %w", data.ErrNotFound)
 	}
 
 	return nil
@@ -201,7 +350,13 @@ func (q *TableQ) Update(ctx context.Context, id uuid.UUID, table *types.Table) e
 	`
 
 	table.ID = id
-	result, err := q.db.NamedExecContext(ctx, query, table)
+	var arg interface{} = table
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query += " AND restaurant_id = :restaurant_id"
+		arg = restaurantTable{Table: table, RestaurantID: restaurantID}
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, q.db, query, arg)
 	if err != nil {
 		return err
 	}
@@ -212,7 +367,7 @@ func (q *TableQ) Update(ctx context.Context, id uuid.UUID, table *types.Table) e
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("table not found")
+		return fmt.Errorf("table %w", data.ErrNotFound)
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -60,6 +61,7 @@ func TestTableQ_Create(t *testing.T) {
 						4,
 						true,
 						"main",
+						"active",
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
 					).
@@ -84,6 +86,7 @@ func TestTableQ_Create(t *testing.T) {
 						2,
 						true,
 						"terrace",
+						"active",
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
 					).
@@ -136,7 +139,7 @@ func TestTableQ_GetByID(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
 					AddRow(tableID, "T1", 4, true, "main", createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables WHERE id = \$1`).
 					WithArgs(tableID).
 					WillReturnRows(rows)
 			},
@@ -155,7 +158,7 @@ func TestTableQ_GetByID(t *testing.T) {
 			name: "table not found",
 			id:   tableID,
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables WHERE id = \$1`).
 					WithArgs(tableID).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -212,7 +215,7 @@ func TestTableQ_GetByNumber(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
 					AddRow(tableID, "T1", 4, true, "main", createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables WHERE number = \$1`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables WHERE number = \$1`).
 					WithArgs("T1").
 					WillReturnRows(rows)
 			},
@@ -231,7 +234,7 @@ func TestTableQ_GetByNumber(t *testing.T) {
 			name:   "table not found",
 			number: "T999",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables WHERE number = \$1`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables WHERE number = \$1`).
 					WithArgs("T999").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -286,7 +289,7 @@ func TestTableQ_GetAll(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
 					AddRow(tableID1, "T1", 4, true, "main", createdAt, updatedAt).
 					AddRow(tableID2, "T2", 2, true, "terrace", createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables ORDER BY number`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables ORDER BY number`).
 					WillReturnRows(rows)
 			},
 			want:    2,
@@ -296,7 +299,7 @@ func TestTableQ_GetAll(t *testing.T) {
 			name: "empty result",
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"})
-				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, created_at, updated_at FROM tables ORDER BY number`).
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables ORDER BY number`).
 					WillReturnRows(rows)
 			},
 			want:    0,
@@ -326,6 +329,122 @@ func TestTableQ_GetAll(t *testing.T) {
 	}
 }
 
+func TestTableQ_GetByLocation(t *testing.T) {
+	tableID := uuid.New()
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		location string
+		mock     func(mock sqlmock.Sqlmock)
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "tables in location",
+			location: "terrace",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
+					AddRow(tableID, "T2", 2, true, "terrace", createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT id, number, capacity, is_available, location, status, created_at, updated_at FROM tables WHERE location = \$1 ORDER BY number`).
+					WithArgs("terrace").
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:     "database error",
+			location: "terrace",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT.*FROM tables WHERE location = \$1`).
+					WithArgs("terrace").
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableQ, mock, teardown := setupTableTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			got, err := tableQ.GetByLocation(ctx, tt.location)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.want)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTableQ_UpdateStatus(t *testing.T) {
+	tableID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "successful update",
+			status: "maintenance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE tables SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+					WithArgs("maintenance", tableID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:   "table not found",
+			status: "retired",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE tables SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+					WithArgs("retired", tableID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+			errMsg:  "table not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableQ, mock, teardown := setupTableTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			err := tableQ.UpdateStatus(ctx, tableID, tt.status)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestTableQ_UpdateAvailability(t *testing.T) {
 	tableID := uuid.New()
 
@@ -386,6 +505,121 @@ func TestTableQ_UpdateAvailability(t *testing.T) {
 	}
 }
 
+func TestTableQ_GetCapacitySummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    *types.CapacitySummary
+		wantErr bool
+	}{
+		{
+			name: "aggregates tables",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"total_tables", "total_seats", "available_tables", "available_seats"}).
+					AddRow(10, 42, 7, 30)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) AS total_tables, COALESCE\(SUM\(capacity\), 0\) AS total_seats`).
+					WillReturnRows(rows)
+			},
+			want: &types.CapacitySummary{TotalTables: 10, TotalSeats: 42, AvailableTables: 7, AvailableSeats: 30},
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) AS total_tables`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableQ, mock, teardown := setupTableTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			got, err := tableQ.GetCapacitySummary(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTableQ_BulkUpdateAvailability(t *testing.T) {
+	tableID1 := uuid.New()
+	tableID2 := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "all tables updated",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				for _, id := range []uuid.UUID{tableID1, tableID2} {
+					mock.ExpectExec(`UPDATE tables SET is_available = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+						WithArgs(false, id).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "rolls back when a table does not exist",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE tables SET is_available = \$1`).
+					WithArgs(false, tableID1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE tables SET is_available = \$1`).
+					WithArgs(false, tableID2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: data.ErrNotFound,
+		},
+		{
+			name: "rolls back on database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE tables SET is_available = \$1`).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableQ, mock, teardown := setupTableTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := tableQ.BulkUpdateAvailability(context.Background(), []uuid.UUID{tableID1, tableID2}, false)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestTableQ_GetAvailable(t *testing.T) {
 	tableID1 := uuid.New()
 	tableID2 := uuid.New()
@@ -408,7 +642,7 @@ func TestTableQ_GetAvailable(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
 					AddRow(tableID1, "T1", 4, true, "main", createdAt, updatedAt).
 					AddRow(tableID2, "T2", 2, true, "terrace", createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.created_at, t.updated_at FROM tables t WHERE t.is_available = true ORDER BY t.number`).
+				mock.ExpectQuery(`SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.status, t.created_at, t.updated_at FROM tables t WHERE t.is_available = true AND t.status = 'active' ORDER BY t.number`).
 					WillReturnRows(rows)
 			},
 			want:    2,
@@ -422,13 +656,46 @@ func TestTableQ_GetAvailable(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
 					AddRow(tableID1, "T1", 4, true, "main", createdAt, updatedAt)
-				mock.ExpectQuery(`SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.created_at, t.updated_at FROM tables t WHERE t.is_available = true AND t.capacity >= \$1 ORDER BY t.number`).
+				mock.ExpectQuery(`SELECT DISTINCT t.id, t.number, t.capacity, t.is_available, t.location, t.status, t.created_at, t.updated_at FROM tables t WHERE t.is_available = true AND t.status = 'active' AND t.capacity >= \$1 ORDER BY t.number`).
 					WithArgs(4).
 					WillReturnRows(rows)
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "get available with min and max capacity filters",
+			filters: &types.TableAvailabilityFilters{
+				Guests:      intPtr(2),
+				MaxCapacity: intPtr(4),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
+					AddRow(tableID1, "T1", 4, true, "main", createdAt, updatedAt).
+					AddRow(tableID2, "T2", 2, true, "terrace", createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT DISTINCT.*FROM tables t WHERE t.is_available = true AND t.status = 'active' AND t.capacity >= \$1 AND t.capacity <= \$2 ORDER BY t.number`).
+					WithArgs(2, 4).
+					WillReturnRows(rows)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "get available with guests and location filters",
+			filters: &types.TableAvailabilityFilters{
+				Guests:   intPtr(2),
+				Location: stringPtr("terrace"),
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "number", "capacity", "is_available", "location", "created_at", "updated_at"}).
+					AddRow(tableID2, "T2", 2, true, "terrace", createdAt, updatedAt)
+				mock.ExpectQuery(`SELECT DISTINCT.*FROM tables t WHERE t.is_available = true AND t.status = 'active' AND t.capacity >= \$1 AND t.location = \$2 ORDER BY t.number`).
+					WithArgs(2, "terrace").
+					WillReturnRows(rows)
+			},
+			want:    1,
+			wantErr: false,
+		},
 		{
 			name: "get available with date and time filter",
 			filters: &types.TableAvailabilityFilters{
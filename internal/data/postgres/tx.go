@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sqlxExt is what query objects run their statements on, either the database itself
+// or a transaction in progress
+type sqlxExt interface {
+	sqlx.ExtContext
+}
+
+var (
+	_ sqlxExt = (*sqlx.DB)(nil)
+	_ sqlxExt = (*sqlx.Tx)(nil)
+)
+
+// txBeginner is implemented by *sqlx.DB but not by *sqlx.Tx
+type txBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
+// inTx runs fn in a transaction begun on db, which is committed when fn succeeds and rolled back otherwise.
+// When db already is a transaction, fn joins it and committing or rolling back is left to whoever began it
+func inTx(ctx context.Context, db sqlxExt, fn func(tx sqlxExt) error) error {
+	beginner, ok := db.(txBeginner)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := beginner.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/data"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
@@ -14,11 +17,11 @@ import (
 
 // UserQ implements data.UserQ interface
 type UserQ struct {
-	db *sqlx.DB
+	db sqlxExt
 }
 
 // NewUserQ creates a new UserQ instance
-func NewUserQ(db *sqlx.DB) data.UserQ {
+func NewUserQ(db sqlxExt) data.UserQ {
 	return &UserQ{db: db}
 }
 
@@ -34,12 +37,9 @@ func (q *UserQ) Create(ctx context.Context, user *types.User) error {
 	}
 
 	// Set default photo if not provided
-	if user.Photo == nil || *user.Photo == "" {
-		defaultPhoto := types.DefaultUserPhoto
-		user.Photo = &defaultPhoto
-	}
+	types.NormalizeUser(user)
 
-	_, err := q.db.NamedExecContext(ctx, query, user)
+	_, err := sqlx.NamedExecContext(ctx, q.db, query, user)
 	if err != nil {
 		return err
 	}
@@ -56,19 +56,15 @@ func (q *UserQ) GetByID(ctx context.Context, id uuid.UUID) (*types.User, error)
 	`
 
 	var user types.User
-	err := q.db.GetContext(ctx, &user, query, id)
+	err := sqlx.GetContext(ctx, q.db, &user, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+			return nil, fmt.Errorf("user %w", data.ErrNotFound)
 		}
 		return nil, err
 	}
 
-	// Set default photo if not set
-	if user.Photo == nil || *user.Photo == "" {
-		defaultPhoto := types.DefaultUserPhoto
-		user.Photo = &defaultPhoto
-	}
+	types.NormalizeUser(&user)
 
 	return &user, nil
 }
@@ -82,34 +78,126 @@ func (q *UserQ) GetByEmail(ctx context.Context, email string) (*types.User, erro
 	`
 
 	var user types.User
-	err := q.db.GetContext(ctx, &user, query, email)
+	err := sqlx.GetContext(ctx, q.db, &user, query, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+			return nil, fmt.Errorf("user %w", data.ErrNotFound)
 		}
 
 		return nil, err
 	}
 
-	// Set default photo if not set
-	if user.Photo == nil || *user.Photo == "" {
-		defaultPhoto := types.DefaultUserPhoto
-		user.Photo = &defaultPhoto
-	}
+	types.NormalizeUser(&user)
 
 	return &user, nil
 }
 
-// Update updates a user's information
+// GetAll retrieves a page of users ordered by creation date, optionally filtered
+// by a search over name and email, together with the total number of matches.
+// The deleted user system account is not listed
+func (q *UserQ) GetAll(ctx context.Context, limit, offset int, search *string) ([]*types.User, int, error) {
+	where := "WHERE id <> $1"
+	args := []interface{}{types.DeletedUserID}
+
+	if search != nil && *search != "" {
+		where += ` AND (name ILIKE $2 ESCAPE '\' OR email ILIKE $2 ESCAPE '\')`
+		args = append(args, containsPattern(*search))
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users %s", where)
+	if err := sqlx.GetContext(ctx, q.db, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, email, password, name, phone, photo, role, created_at
+		FROM users
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	users := []*types.User{}
+	if err := sqlx.SelectContext(ctx, q.db, &users, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	for _, user := range users {
+		types.NormalizeUser(user)
+	}
+
+	return users, total, nil
+}
+
+// Update updates the given fields of a user. Empty email and name and nil phone and photo are left unchanged
 func (q *UserQ) Update(ctx context.Context, id uuid.UUID, user *types.User) error {
+	setParts := []string{}
+	args := []interface{}{}
+	argPos := 1
+
+	if user.Email != "" {
+		setParts = append(setParts, fmt.Sprintf("email = $%d", argPos))
+		args = append(args, user.Email)
+		argPos++
+	}
+
+	if user.Name != "" {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argPos))
+		args = append(args, user.Name)
+		argPos++
+	}
+
+	if user.Phone != nil {
+		setParts = append(setParts, fmt.Sprintf("phone = $%d", argPos))
+		args = append(args, *user.Phone)
+		argPos++
+	}
+
+	if user.Photo != nil {
+		setParts = append(setParts, fmt.Sprintf("photo = $%d", argPos))
+		args = append(args, *user.Photo)
+		argPos++
+	}
+
+	if len(setParts) == 0 {
+		return errors.New("no fields to update")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE users
+		SET %s
+		WHERE id = $%d
+	`, strings.Join(setParts, ", "), argPos)
+	args = append(args, id)
+
+	result, err := q.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user %w", data.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UpdateRole changes the role of a user
+func (q *UserQ) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
 	query := `
 		UPDATE users
-		SET email = :email, name = :name, phone = :phone, photo = :photo
-		WHERE id = :id
+		SET role = $1
+		WHERE id = $2
 	`
 
-	user.ID = id
-	result, err := q.db.NamedExecContext(ctx, query, user)
+	result, err := q.db.ExecContext(ctx, query, role, id)
 	if err != nil {
 		return err
 	}
@@ -120,8 +208,74 @@ func (q *UserQ) Update(ctx context.Context, id uuid.UUID, user *types.User) erro
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("user not found")
+		return fmt.Errorf("user %w", data.ErrNotFound)
 	}
 
 	return nil
 }
+
+// Delete removes a user. Their pending and confirmed reservations are cancelled and
+// all of their reservations are reassigned to the deleted user system account.
+// It returns the distinct dates of the cancelled reservations
+func (q *UserQ) Delete(ctx context.Context, id uuid.UUID) ([]time.Time, error) {
+	cancelQuery := `
+		WITH cancelled AS (
+			UPDATE reservations r
+			SET status = 'cancelled', updated_at = NOW()
+			FROM reservations prev
+			WHERE r.id = prev.id
+			  AND r.user_id = $1
+			  AND r.status IN ('pending', 'confirmed')
+			  AND r.deleted_at IS NULL
+			RETURNING r.id, prev.status AS from_status, r.date
+		), history AS (
+			INSERT INTO reservation_status_history (reservation_id, from_status, to_status, changed_at)
+			SELECT id, from_status, 'cancelled', NOW()
+			FROM cancelled
+		)
+		SELECT DISTINCT date FROM cancelled
+	`
+
+	reassignQuery := `
+		UPDATE reservations
+		SET user_id = $2
+		WHERE user_id = $1
+	`
+
+	deleteQuery := `
+		DELETE FROM users
+		WHERE id = $1
+	`
+
+	var dates []time.Time
+	err := inTx(ctx, q.db, func(tx sqlxExt) error {
+		if err := sqlx.SelectContext(ctx, tx, &dates, cancelQuery, id); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, reassignQuery, id, types.DeletedUserID); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, deleteQuery, id)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("user %w", data.ErrNotFound)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dates, nil
+}
@@ -383,14 +383,66 @@ func TestUserQ_Update(t *testing.T) {
 					WithArgs(
 						"updated@example.com",
 						"Updated User",
-						stringPtr("+9876543210"),
-						stringPtr("https://example.com/new-photo.jpg"),
+						"+9876543210",
+						"https://example.com/new-photo.jpg",
 						userID,
 					).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
 		},
+		{
+			name: "name only",
+			id:   userID,
+			user: &types.User{Name: "Updated User"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET name = \$1 WHERE id = \$2`).
+					WithArgs("Updated User", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "email only",
+			id:   userID,
+			user: &types.User{Email: "updated@example.com"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET email = \$1 WHERE id = \$2`).
+					WithArgs("updated@example.com", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "phone cleared",
+			id:   userID,
+			user: &types.User{Phone: stringPtr("")},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET phone = \$1 WHERE id = \$2`).
+					WithArgs("", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "photo only",
+			id:   userID,
+			user: &types.User{Photo: stringPtr("https://example.com/new-photo.jpg")},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET photo = \$1 WHERE id = \$2`).
+					WithArgs("https://example.com/new-photo.jpg", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "no fields",
+			id:      userID,
+			user:    &types.User{},
+			mock:    func(mock sqlmock.Sqlmock) {},
+			wantErr: true,
+			errMsg:  "no fields to update",
+		},
 		{
 			name: "user not found",
 			id:   userID,
@@ -399,14 +451,8 @@ func TestUserQ_Update(t *testing.T) {
 				Name:  "Updated User",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE users SET email = \$1, name = \$2, phone = \$3, photo = \$4 WHERE id = \$5`).
-					WithArgs(
-						"updated@example.com",
-						"Updated User",
-						nil,
-						nil,
-						userID,
-					).
+				mock.ExpectExec(`UPDATE users SET email = \$1, name = \$2 WHERE id = \$3`).
+					WithArgs("updated@example.com", "Updated User", userID).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			wantErr: true,
@@ -420,7 +466,7 @@ func TestUserQ_Update(t *testing.T) {
 				Name:  "Updated User",
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE users SET email = \$1, name = \$2, phone = \$3, photo = \$4 WHERE id = \$5`).
+				mock.ExpectExec(`UPDATE users SET email = \$1, name = \$2 WHERE id = \$3`).
 					WillReturnError(sql.ErrConnDone)
 			},
 			wantErr: true,
@@ -450,3 +496,257 @@ func TestUserQ_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestUserQ_UpdateRole(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "successful update",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET role = \$1 WHERE id = \$2`).
+					WithArgs("admin", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET role = \$1 WHERE id = \$2`).
+					WithArgs("admin", userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+			errMsg:  "user not found",
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE users SET role = \$1 WHERE id = \$2`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userQ, mock, teardown := setupUserTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := userQ.UpdateRole(context.Background(), userID, "admin")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserQ_GetAll(t *testing.T) {
+	userID := uuid.New()
+	createdAt := time.Now()
+	columns := []string{"id", "email", "password", "name", "phone", "photo", "role", "created_at"}
+
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		search    *string
+		mock      func(mock sqlmock.Sqlmock)
+		wantCount int
+		wantTotal int
+		wantErr   bool
+	}{
+		{
+			name:   "first page without search",
+			limit:  20,
+			offset: 0,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE id <> \$1`).
+					WithArgs(types.DeletedUserID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT id, email, password, name, phone, photo, role, created_at\s+FROM users\s+WHERE id <> \$1\s+ORDER BY created_at DESC\s+LIMIT \$2 OFFSET \$3`).
+					WithArgs(types.DeletedUserID, 20, 0).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(userID, "test@example.com", "hashed", "Test User", nil, nil, "user", createdAt))
+			},
+			wantCount: 1,
+			wantTotal: 1,
+			wantErr:   false,
+		},
+		{
+			name:   "search by name or email",
+			limit:  10,
+			offset: 10,
+			search: stringPtr("john"),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE id <> \$1 AND \(name ILIKE \$2 ESCAPE '\\' OR email ILIKE \$2 ESCAPE '\\'\)`).
+					WithArgs(types.DeletedUserID, "%john%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+				mock.ExpectQuery(`FROM users\s+WHERE id <> \$1 AND \(name ILIKE \$2 ESCAPE '\\' OR email ILIKE \$2 ESCAPE '\\'\)\s+ORDER BY created_at DESC\s+LIMIT \$3 OFFSET \$4`).
+					WithArgs(types.DeletedUserID, "%john%", 10, 10).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(userID, "john@example.com", "hashed", "John Doe", nil, nil, "user", createdAt))
+			},
+			wantCount: 1,
+			wantTotal: 11,
+			wantErr:   false,
+		},
+		{
+			name:  "no users",
+			limit: 20,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`FROM users\s+WHERE id <> \$1\s+ORDER BY created_at DESC`).
+					WithArgs(types.DeletedUserID, 20, 0).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantCount: 0,
+			wantTotal: 0,
+			wantErr:   false,
+		},
+		{
+			name:  "database error",
+			limit: 20,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userQ, mock, teardown := setupUserTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			users, total, err := userQ.GetAll(ctx, tt.limit, tt.offset, tt.search)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// An empty page is serialized as [] rather than null
+				assert.NotNil(t, users)
+				assert.Len(t, users, tt.wantCount)
+				assert.Equal(t, tt.wantTotal, total)
+				for _, user := range users {
+					require.NotNil(t, user.Photo)
+					assert.Equal(t, types.DefaultUserPhoto, *user.Photo)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserQ_Delete(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mock      func(mock sqlmock.Sqlmock)
+		wantDates []time.Time
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "successful delete",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`WITH cancelled AS \(\s*UPDATE reservations r\s+SET status = 'cancelled'.*INSERT INTO reservation_status_history.*SELECT DISTINCT date FROM cancelled`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"date"}).
+						AddRow(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)).
+						AddRow(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)))
+				mock.ExpectExec(`UPDATE reservations\s+SET user_id = \$2\s+WHERE user_id = \$1`).
+					WithArgs(userID, types.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+				mock.ExpectExec(`DELETE FROM users\s+WHERE id = \$1`).
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantDates: []time.Time{
+				time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`WITH cancelled AS`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"date"}))
+				mock.ExpectExec(`UPDATE reservations\s+SET user_id = \$2`).
+					WithArgs(userID, types.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM users`).
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errMsg:  "user not found",
+		},
+		{
+			name: "database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`WITH cancelled AS`).
+					WithArgs(userID).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userQ, mock, teardown := setupUserTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			ctx := context.Background()
+			dates, err := userQ.Delete(ctx, userID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.EqualError(t, err, tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantDates, dates)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
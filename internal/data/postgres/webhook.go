@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// WebhookQ implements data.WebhookQ interface
+type WebhookQ struct {
+	db sqlxExt
+}
+
+// NewWebhookQ creates a new WebhookQ instance
+func NewWebhookQ(db sqlxExt) data.WebhookQ {
+	return &WebhookQ{db: db}
+}
+
+// restaurantWebhook binds a webhook together with the restaurant it is registered for
+type restaurantWebhook struct {
+	*types.Webhook
+	RestaurantID uuid.UUID `db:"restaurant_id"`
+}
+
+// Create registers a new webhook
+func (q *WebhookQ) Create(ctx context.Context, webhook *types.Webhook) error {
+	query := `
+		INSERT INTO webhooks (id, url, secret, created_at)
+		VALUES (:id, :url, :secret, :created_at)
+	`
+
+	if webhook.ID == uuid.Nil {
+		webhook.ID = uuid.New()
+	}
+
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now()
+	}
+
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		query = `
+			INSERT INTO webhooks (id, url, secret, restaurant_id, created_at)
+			VALUES (:id, :url, :secret, :restaurant_id, :created_at)
+		`
+		_, err := sqlx.NamedExecContext(ctx, q.db, query, restaurantWebhook{Webhook: webhook, RestaurantID: restaurantID})
+		return err
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, q.db, query, webhook)
+	return err
+}
+
+// GetAll retrieves all webhooks ordered by registration time
+func (q *WebhookQ) GetAll(ctx context.Context) ([]*types.Webhook, error) {
+	query := `
+		SELECT id, url, secret, created_at
+		FROM webhooks
+	`
+
+	scope, args := restaurantWhere(ctx, "restaurant_id", 1)
+	webhooks := make([]*types.Webhook, 0)
+	err := sqlx.SelectContext(ctx, q.db, &webhooks, query+scope+" ORDER BY created_at", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes a webhook by ID
+func (q *WebhookQ) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	scope, args := restaurantScope(ctx, "restaurant_id", 2)
+	result, err := q.db.ExecContext(ctx, query+scope, append([]interface{}{id}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook %w", data.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWebhookTestDB(t *testing.T) (*WebhookQ, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	webhookQ := NewWebhookQ(sqlxDB).(*WebhookQ)
+
+	teardown := func() {
+		db.Close()
+	}
+
+	return webhookQ, mock, teardown
+}
+
+func TestWebhookQ_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *types.Webhook
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name:    "successful create",
+			webhook: &types.Webhook{URL: "https://pos.example.com/hooks", Secret: "s3cret"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO webhooks`).
+					WithArgs(sqlmock.AnyArg(), "https://pos.example.com/hooks", "s3cret", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:    "database error",
+			webhook: &types.Webhook{URL: "https://pos.example.com/hooks", Secret: "s3cret"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO webhooks`).
+					WillReturnError(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookQ, mock, teardown := setupWebhookTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := webhookQ.Create(context.Background(), tt.webhook)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, tt.webhook.ID)
+				assert.False(t, tt.webhook.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookQ_GetAll(t *testing.T) {
+	webhookQ, mock, teardown := setupWebhookTestDB(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"id", "url", "secret", "created_at"}).
+		AddRow(uuid.New(), "https://pos.example.com/hooks", "s3cret", time.Now()).
+		AddRow(uuid.New(), "https://sms.example.com/hooks", "other", time.Now())
+	mock.ExpectQuery(`SELECT id, url, secret, created_at FROM webhooks ORDER BY created_at`).
+		WillReturnRows(rows)
+
+	webhooks, err := webhookQ.GetAll(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.Equal(t, "https://pos.example.com/hooks", webhooks[0].URL)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookQ_ScopedToRestaurant(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := tenant.WithRestaurant(context.Background(), restaurantID)
+
+	t.Run("create", func(t *testing.T) {
+		webhookQ, mock, teardown := setupWebhookTestDB(t)
+		defer teardown()
+
+		mock.ExpectExec(`INSERT INTO webhooks \(id, url, secret, restaurant_id, created_at\)`).
+			WithArgs(sqlmock.AnyArg(), "https://pos.example.com/hooks", "s3cret", restaurantID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := webhookQ.Create(ctx, &types.Webhook{URL: "https://pos.example.com/hooks", Secret: "s3cret"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		webhookQ, mock, teardown := setupWebhookTestDB(t)
+		defer teardown()
+
+		mock.ExpectQuery(`SELECT id, url, secret, created_at FROM webhooks WHERE restaurant_id = \$1 ORDER BY created_at`).
+			WithArgs(restaurantID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "created_at"}))
+
+		webhooks, err := webhookQ.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, webhooks)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		webhookQ, mock, teardown := setupWebhookTestDB(t)
+		defer teardown()
+
+		id := uuid.New()
+		mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1 AND restaurant_id = \$2`).
+			WithArgs(id, restaurantID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, webhookQ.Delete(ctx, id))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookQ_Delete(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errNotFound bool
+	}{
+		{
+			name: "successful delete",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "webhook not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr:     true,
+			errNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookQ, mock, teardown := setupWebhookTestDB(t)
+			defer teardown()
+
+			tt.mock(mock)
+
+			err := webhookQ.Delete(context.Background(), id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errNotFound, errors.Is(err, data.ErrNotFound))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
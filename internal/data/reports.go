@@ -2,15 +2,35 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
 )
 
 // ReportsQ defines methods for reports-related database operations
 type ReportsQ interface {
-	// GetMonthlyStatsList retrieves a list of all months with available statistics
-	GetMonthlyStatsList(ctx context.Context) ([]*types.MonthlyStats, error)
+	// GetMonthlyStatsList retrieves the most recent months with available statistics,
+	// optionally bounded by the filters. A zero limit returns every matching month
+	GetMonthlyStatsList(ctx context.Context, filters *types.MonthlyStatsFilters) ([]*types.MonthlyStats, error)
+
+	// GetYearlyStats retrieves statistics aggregated per year
+	GetYearlyStats(ctx context.Context) ([]*types.YearlyStats, error)
+
+	// GetWeeklyStats retrieves statistics aggregated per ISO week for the given date range (inclusive)
+	GetWeeklyStats(ctx context.Context, dateFrom, dateTo time.Time) ([]*types.WeeklyStats, error)
 
 	// GetDetailedMonthlyStats retrieves detailed statistics for a specific month
 	GetDetailedMonthlyStats(ctx context.Context, month string) (*types.DetailedMonthlyStats, error)
+
+	// GetUserStats retrieves reservation statistics for a specific user
+	GetUserStats(ctx context.Context, userID uuid.UUID) (*types.UserStats, error)
+
+	// GetTableOccupancy retrieves per-table occupancy statistics for the given date range (inclusive).
+	// slots is the number of bookable slots a single table has over the range, the denominator of the rates
+	GetTableOccupancy(ctx context.Context, dateFrom, dateTo time.Time, slots int) ([]*types.TableOccupancy, error)
+
+	// GetTodaySnapshot retrieves reservation counts by status and expected covers for the current date
+	// in the server timezone
+	GetTodaySnapshot(ctx context.Context) (*types.TodaySnapshot, error)
 }
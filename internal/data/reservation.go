@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/google/uuid"
@@ -9,12 +10,27 @@ import (
 
 // ReservationQ defines methods for reservation-related database operations
 type ReservationQ interface {
-	// Create creates a new reservation
-	Create(ctx context.Context, reservation *types.Reservation) error
+	// Create creates a new reservation. A confirmation code that is already taken results in ErrConflict.
+	// A reservation without a status is created in initialStatus, the configured status of new reservations
+	Create(ctx context.Context, reservation *types.Reservation, initialStatus string) error
+
+	// BulkCreate inserts the reservations in a single transaction. Either all of them are stored or none.
+	// Reservations without a status are created in initialStatus
+	BulkCreate(ctx context.Context, reservations []*types.Reservation, initialStatus string) error
+
+	// SetTables replaces the merged table set of a reservation.
+	// Passing a single table or none turns it back into a single-table reservation
+	SetTables(ctx context.Context, id uuid.UUID, tableNumbers []string) error
 
 	// GetByID retrieves a reservation by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*types.Reservation, error)
 
+	// GetByConfirmationCode retrieves a reservation by its confirmation code
+	GetByConfirmationCode(ctx context.Context, code string) (*types.Reservation, error)
+
+	// GetByPhoneAndDate retrieves the pending and confirmed reservations made under a guest phone on a date
+	GetByPhoneAndDate(ctx context.Context, phone string, date string) ([]*types.Reservation, error)
+
 	// GetAll retrieves all reservations with optional filters
 	// Admin sees all reservations, users see only their own
 	GetAll(ctx context.Context, userID *uuid.UUID, filters *types.ReservationFilters) ([]*types.Reservation, error)
@@ -22,15 +38,62 @@ type ReservationQ interface {
 	// GetByUserID retrieves all reservations for a specific user
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*types.Reservation, error)
 
-	// Update updates a reservation's information
-	Update(ctx context.Context, id uuid.UUID, reservation *types.Reservation) error
+	// GetByRecurrenceGroup retrieves the reservations created together by a recurring booking, ordered by date and time
+	GetByRecurrenceGroup(ctx context.Context, groupID uuid.UUID) ([]*types.Reservation, error)
+
+	// GetByTableNumber retrieves the reservations holding a table, alone or merged with others,
+	// between two dates inclusive, ordered by date and time
+	GetByTableNumber(ctx context.Context, tableNumber string, dateFrom, dateTo time.Time) ([]*types.Reservation, error)
+
+	// CountActiveByUser counts the pending and confirmed reservations of a user that have not started yet
+	CountActiveByUser(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// CountUpcomingByUser counts the reservations of a user that have not started yet and were not cancelled
+	CountUpcomingByUser(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Update updates a reservation's information. Zero-valued fields are left unchanged, except that
+	// a non-nil empty SpecialRequests clears them. When expectedUpdatedAt is set, the update
+	// is only applied if the reservation was not modified since then, otherwise ErrConflict is returned.
+	// The new modification time as stored by the database is returned
+	Update(ctx context.Context, id uuid.UUID, reservation *types.Reservation, expectedUpdatedAt *time.Time) (time.Time, error)
 
-	// UpdateStatus updates only the status of a reservation
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	// UpdateStatus moves a reservation from fromStatus to status and returns the updated reservation.
+	// ErrNotFound is returned when no active reservation has the ID and ErrConflict when
+	// the reservation is no longer in fromStatus
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, status string) (*types.Reservation, error)
 
-	// Delete deletes a reservation by ID
+	// UpdateStaffNotes replaces the staff notes of a reservation, a nil value clears them
+	UpdateStaffNotes(ctx context.Context, id uuid.UUID, notes *string) error
+
+	// BulkUpdateStatus applies the status changes in a single transaction and records them
+	// in the status history. Either all changes are applied or none of them
+	BulkUpdateStatus(ctx context.Context, changes []*types.StatusChange) error
+
+	// Delete soft-deletes a reservation by ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// HardDelete permanently removes a reservation by ID, including soft-deleted ones,
+	// and returns the removed reservation
+	HardDelete(ctx context.Context, id uuid.UUID) (*types.Reservation, error)
+
+	// GetDeleted retrieves all soft-deleted reservations
+	GetDeleted(ctx context.Context) ([]*types.Reservation, error)
+
+	// Restore undoes a soft delete of a reservation
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// MarkPastAsCompleted marks confirmed reservations whose date and time have passed
+	// as completed and returns the affected reservations
+	MarkPastAsCompleted(ctx context.Context) ([]types.CompletedReservation, error)
+
 	// CheckTableAvailability checks if a table is available at a specific date and time
 	CheckTableAvailability(ctx context.Context, tableNumber string, date string, time string) (bool, error)
+
+	// CountConcurrentAt counts the pending and confirmed reservations across all tables that are seated while
+	// a reservation starting at the date and time is. Every reservation is seated for window from its start,
+	// so two overlap when they start less than window apart. The reservation excludeID, if any, is not counted
+	CountConcurrentAt(ctx context.Context, date string, clock string, window time.Duration, excludeID uuid.UUID) (int, error)
+
+	// GetBookedTimes returns the times (HH:MM) at which a table is held by an active reservation on a date
+	GetBookedTimes(ctx context.Context, tableNumber string, date string) ([]string, error)
 }
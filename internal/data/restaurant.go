@@ -0,0 +1,20 @@
+package data
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// RestaurantQ defines methods for restaurant-related database operations
+type RestaurantQ interface {
+	// GetByID retrieves a restaurant by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*types.Restaurant, error)
+
+	// GetBySlug retrieves a restaurant by its slug
+	GetBySlug(ctx context.Context, slug string) (*types.Restaurant, error)
+
+	// GetAll retrieves all restaurants ordered by name
+	GetAll(ctx context.Context) ([]*types.Restaurant, error)
+}
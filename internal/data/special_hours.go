@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// SpecialHoursQ defines methods for special hours database operations
+type SpecialHoursQ interface {
+	// Create overrides the business hours of a date. A date that already has special hours results in ErrConflict
+	Create(ctx context.Context, hours *types.SpecialHours) error
+
+	// GetByID retrieves special hours by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*types.SpecialHours, error)
+
+	// GetByDate retrieves the special hours of the "YYYY-MM-DD" date.
+	// It returns ErrNotFound when the weekly business hours apply
+	GetByDate(ctx context.Context, date string) (*types.SpecialHours, error)
+
+	// GetInRange retrieves the special hours of the dates from through to, ordered by date
+	GetInRange(ctx context.Context, from, to time.Time) ([]*types.SpecialHours, error)
+
+	// Update saves the date, times and note of existing special hours and sets their UpdatedAt.
+	// Moving them onto a date that already has special hours results in ErrConflict
+	Update(ctx context.Context, hours *types.SpecialHours) error
+
+	// Delete removes special hours by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+}
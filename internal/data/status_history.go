@@ -0,0 +1,17 @@
+package data
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// StatusHistoryQ defines methods for reservation status history database operations
+type StatusHistoryQ interface {
+	// Create records a reservation status change
+	Create(ctx context.Context, change *types.StatusChange) error
+
+	// GetByReservationID retrieves the status history of a reservation ordered from oldest to newest
+	GetByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*types.StatusChange, error)
+}
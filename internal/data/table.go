@@ -21,12 +21,25 @@ type TableQ interface {
 	// GetAll retrieves all tables
 	GetAll(ctx context.Context) ([]*types.Table, error)
 
+	// GetByLocation retrieves all tables in a specific location
+	GetByLocation(ctx context.Context, location string) ([]*types.Table, error)
+
 	// GetAvailable retrieves available tables with optional filters
 	GetAvailable(ctx context.Context, filters *types.TableAvailabilityFilters) ([]*types.Table, error)
 
+	// GetCapacitySummary aggregates table and seat counts
+	GetCapacitySummary(ctx context.Context) (*types.CapacitySummary, error)
+
 	// UpdateAvailability updates the availability status of a table
 	UpdateAvailability(ctx context.Context, id uuid.UUID, isAvailable bool) error
 
+	// BulkUpdateAvailability updates the availability status of several tables in one transaction.
+	// Nothing is changed when any of the tables does not exist
+	BulkUpdateAvailability(ctx context.Context, ids []uuid.UUID, isAvailable bool) error
+
+	// UpdateStatus updates the service status of a table
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+
 	// Update updates a table's information
 	Update(ctx context.Context, id uuid.UUID, table *types.Table) error
 }
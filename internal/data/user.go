@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/google/uuid"
@@ -18,6 +19,17 @@ type UserQ interface {
 	// GetByEmail retrieves a user by email
 	GetByEmail(ctx context.Context, email string) (*types.User, error)
 
-	// Update updates a user's information
+	// GetAll retrieves a page of users ordered by creation date, optionally filtered
+	// by a search over name and email, together with the total number of matches.
+	// The deleted user system account is not listed
+	GetAll(ctx context.Context, limit, offset int, search *string) ([]*types.User, int, error)
+	// Update updates the given fields of a user. Empty email and name and nil phone and photo
+	// are left unchanged, so callers pass only the fields that changed
 	Update(ctx context.Context, id uuid.UUID, user *types.User) error
+	// UpdateRole changes the role of a user
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	// Delete removes a user. Their pending and confirmed reservations are cancelled and
+	// all of their reservations are reassigned to the deleted user system account.
+	// It returns the distinct dates of the cancelled reservations
+	Delete(ctx context.Context, id uuid.UUID) ([]time.Time, error)
 }
@@ -0,0 +1,20 @@
+package data
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// WebhookQ defines methods for webhook-related database operations
+type WebhookQ interface {
+	// Create registers a new webhook
+	Create(ctx context.Context, webhook *types.Webhook) error
+
+	// GetAll retrieves all webhooks of the restaurant ordered by registration time
+	GetAll(ctx context.Context) ([]*types.Webhook, error)
+
+	// Delete removes a webhook by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,570 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported languages as BCP 47 primary language subtags
+const (
+	English   = "en"
+	Ukrainian = "uk"
+)
+
+// DefaultLanguage is used when the client accepts none of the supported languages
+const DefaultLanguage = English
+
+// Key identifies a user-facing message in the catalog
+type Key string
+
+// Message keys
+const (
+	InternalError              Key = "internal_error"
+	ValidationError            Key = "validation_error"
+	InvalidRequestBody         Key = "invalid_request_body"
+	Unauthorized               Key = "unauthorized"
+	Forbidden                  Key = "forbidden"
+	NotFound                   Key = "not_found"
+	EmailRequired              Key = "email_required"
+	InvalidCredentials         Key = "invalid_credentials"
+	InvalidReservationID       Key = "invalid_reservation_id"
+	InvalidTableID             Key = "invalid_table_id"
+	InvalidUserID              Key = "invalid_user_id"
+	InvalidWebhookID           Key = "invalid_webhook_id"
+	InvalidRecurrenceGroupID   Key = "invalid_recurrence_group_id"
+	InvalidBlockedDateID       Key = "invalid_blocked_date_id"
+	InvalidSpecialHoursID      Key = "invalid_special_hours_id"
+	InvalidMonthFormat         Key = "invalid_month_format"
+	ReservationNotFound        Key = "reservation_not_found"
+	DeletedReservationNotFound Key = "deleted_reservation_not_found"
+	TableNotFound              Key = "table_not_found"
+	UserNotFound               Key = "user_not_found"
+	MonthlyStatsNotFound       Key = "monthly_stats_not_found"
+	RestaurantNotFound         Key = "restaurant_not_found"
+	WebhookNotFound            Key = "webhook_not_found"
+	RecurrenceGroupNotFound    Key = "recurrence_group_not_found"
+	BlockedDateNotFound        Key = "blocked_date_not_found"
+	SpecialHoursNotFound       Key = "special_hours_not_found"
+	FeedbackNotFound           Key = "feedback_not_found"
+	ReservationModified        Key = "reservation_modified"
+	DuplicateReservation       Key = "duplicate_reservation"
+	DuplicateFeedback          Key = "duplicate_feedback"
+	TooManyActiveReservations  Key = "too_many_active_reservations"
+	SlotFull                   Key = "slot_full"
+	DateBlocked                Key = "date_blocked"
+	RequestInProgress          Key = "request_in_progress"
+	TooManyLookupAttempts      Key = "too_many_lookup_attempts"
+	TooManyConfirmationResends Key = "too_many_confirmation_resends"
+	SystemAccountUndeletable   Key = "system_account_undeletable"
+)
+
+// Validation detail keys. Their messages may contain fmt verbs filled in by Msg
+const (
+	InvalidDateFormat                Key = "invalid_date_format"
+	InvalidTimeFormat                Key = "invalid_time_format"
+	DateMissing                      Key = "date_missing"
+	TimeMissing                      Key = "time_missing"
+	GuestsNotPositive                Key = "guests_not_positive"
+	InvalidStatus                    Key = "invalid_status"
+	ToDateBeforeFrom                 Key = "to_date_before_from"
+	StartDateMissing                 Key = "start_date_missing"
+	EndDateMissing                   Key = "end_date_missing"
+	EndDateBeforeStart               Key = "end_date_before_start"
+	DateRangeTooLong                 Key = "date_range_too_long"
+	DateRangeTooLongWeeks            Key = "date_range_too_long_weeks"
+	TableNumberMissing               Key = "table_number_missing"
+	GuestNameMissing                 Key = "guest_name_missing"
+	GuestPhoneMissing                Key = "guest_phone_missing"
+	GuestEmailMissing                Key = "guest_email_missing"
+	GuestNameEmpty                   Key = "guest_name_empty"
+	GuestPhoneEmpty                  Key = "guest_phone_empty"
+	GuestEmailEmpty                  Key = "guest_email_empty"
+	TableNumberEmpty                 Key = "table_number_empty"
+	NameEmpty                        Key = "name_empty"
+	EmailEmpty                       Key = "email_empty"
+	FromMonthAfterTo                 Key = "from_month_after_to"
+	FromDateMissing                  Key = "from_date_missing"
+	ToDateMissing                    Key = "to_date_missing"
+	RecurringTooManyOccurrences      Key = "recurring_too_many_occurrences"
+	RecurringTooFewOccurrences       Key = "recurring_too_few_occurrences"
+	RecurringUntilTooEarly           Key = "recurring_until_too_early"
+	RecurringFrequencyWeekly         Key = "recurring_frequency_weekly"
+	RecurringCountAndUntil           Key = "recurring_count_and_until"
+	RecurringEndMissing              Key = "recurring_end_missing"
+	ActiveReservationsLimit          Key = "active_reservations_limit"
+	ActiveReservationsLimitRecurring Key = "active_reservations_limit_recurring"
+	DateBlockedDetail                Key = "date_blocked_detail"
+	TableBookedByRow                 Key = "table_booked_by_row"
+	TableBookedAtTime                Key = "table_booked_at_time"
+	TableIDsWithLocation             Key = "table_ids_with_location"
+	TableIDsOrLocationMissing        Key = "table_ids_or_location_missing"
+	TableBookedNextFree              Key = "table_booked_next_free"
+	TableBookedNoFreeSlots           Key = "table_booked_no_free_slots"
+	StaffNotesTooLong                Key = "staff_notes_too_long"
+	SpecialHoursExist                Key = "special_hours_exist"
+	ReasonTooLong                    Key = "reason_too_long"
+	ReasonMissing                    Key = "reason_missing"
+	RatingOutOfRange                 Key = "rating_out_of_range"
+	PhotoURLInvalid                  Key = "photo_url_invalid"
+	PhoneMissing                     Key = "phone_missing"
+	OpenTimeMissing                  Key = "open_time_missing"
+	CloseTimeMissing                 Key = "close_time_missing"
+	CloseTimeBeforeOpen              Key = "close_time_before_open"
+	NoteTooLong                      Key = "note_too_long"
+	RowNotImported                   Key = "row_not_imported"
+	MaxGuestsBelowGuests             Key = "max_guests_below_guests"
+	ConfirmationCodeMissing          Key = "confirmation_code_missing"
+	InvalidConfirmationCode          Key = "invalid_confirmation_code"
+	FeedbackNotCompleted             Key = "feedback_not_completed"
+	ConfirmationNotResendable        Key = "confirmation_not_resendable"
+	CommentTooLong                   Key = "comment_too_long"
+	AvailabilityMissing              Key = "availability_missing"
+	BulkTablesTooMany                Key = "bulk_tables_too_many"
+	BulkReservationsTooMany          Key = "bulk_reservations_too_many"
+	ImportTooManyRows                Key = "import_too_many_rows"
+	BlockedRangeTooLong              Key = "blocked_range_too_long"
+	ImportRowsMissing                Key = "import_rows_missing"
+	BulkIDsMissing                   Key = "bulk_ids_missing"
+	WebhookURLInvalid                Key = "webhook_url_invalid"
+	IdempotencyKeyTooLong            Key = "idempotency_key_too_long"
+	DuplicatePhone                   Key = "duplicate_phone"
+	BulkListedTwice                  Key = "bulk_listed_twice"
+	BulkNotApplied                   Key = "bulk_not_applied"
+	TableNumberNotFound              Key = "table_number_not_found"
+	CombinedCapacityTooSmall         Key = "combined_capacity_too_small"
+	TableUnderMaintenance            Key = "table_under_maintenance"
+	TableRetired                     Key = "table_retired"
+	SlotBoundary                     Key = "slot_boundary"
+	BookingTooFarAhead               Key = "booking_too_far_ahead"
+	BookingTooLate                   Key = "booking_too_late"
+	PartyTooSmall                    Key = "party_too_small"
+	PartyTooLarge                    Key = "party_too_large"
+	RestaurantClosedOn               Key = "restaurant_closed_on"
+	OutsideOpeningHours              Key = "outside_opening_hours"
+	PhotoTooLarge                    Key = "photo_too_large"
+	PhotoMissing                     Key = "photo_missing"
+	PhotoFormat                      Key = "photo_format"
+	StatusUnchanged                  Key = "status_unchanged"
+	StatusFinal                      Key = "status_final"
+	StatusTransitionNotAllowed       Key = "status_transition_not_allowed"
+	PartiesSeatedOnDate              Key = "parties_seated_on_date"
+	PartiesSeatedLimit               Key = "parties_seated_limit"
+	PartiesSeated                    Key = "parties_seated"
+	OccurrenceBreaksPolicy           Key = "occurrence_breaks_policy"
+	OccurrenceClosed                 Key = "occurrence_closed"
+	OccurrenceTableBooked            Key = "occurrence_table_booked"
+	OccurrenceDuplicate              Key = "occurrence_duplicate"
+	OnDate                           Key = "on_date"
+	OnMondays                        Key = "on_mondays"
+	OnTuesdays                       Key = "on_tuesdays"
+	OnWednesdays                     Key = "on_wednesdays"
+	OnThursdays                      Key = "on_thursdays"
+	OnFridays                        Key = "on_fridays"
+	OnSaturdays                      Key = "on_saturdays"
+	OnSundays                        Key = "on_sundays"
+	BodyTooLarge                     Key = "body_too_large"
+	BodyMalformed                    Key = "body_malformed"
+	BodyInvalidField                 Key = "body_invalid_field"
+	BodyInvalidValue                 Key = "body_invalid_value"
+	BodyUnknownField                 Key = "body_unknown_field"
+	BodyEmpty                        Key = "body_empty"
+	BodyMultipleObjects              Key = "body_multiple_objects"
+	EmailMissing                     Key = "email_missing"
+	InvalidEmailFormat               Key = "invalid_email_format"
+	PasswordMissing                  Key = "password_missing"
+	NameMissing                      Key = "name_missing"
+	InvalidPhoneFormat               Key = "invalid_phone_format"
+	EmailTaken                       Key = "email_taken"
+	PasswordTooShort                 Key = "password_too_short"
+	PasswordNeedsDigit               Key = "password_needs_digit"
+	PasswordNeedsUpper               Key = "password_needs_upper"
+	PasswordNeedsSpecial             Key = "password_needs_special"
+	CSVGuestsNotNumber               Key = "csv_guests_not_number"
+	CSVHeaderMissing                 Key = "csv_header_missing"
+	CSVUnknownColumn                 Key = "csv_unknown_column"
+	CSVRowInvalid                    Key = "csv_row_invalid"
+	CSVMalformedAt                   Key = "csv_malformed_at"
+	CSVMalformed                     Key = "csv_malformed"
+)
+
+var catalog = map[string]map[Key]string{
+	English: {
+		InternalError:              "Internal server error",
+		ValidationError:            "Validation error",
+		InvalidRequestBody:         "Invalid request body",
+		Unauthorized:               "Unauthorized",
+		Forbidden:                  "Forbidden",
+		NotFound:                   "Not found",
+		EmailRequired:              "Email and password are required",
+		InvalidCredentials:         "Invalid email or password",
+		InvalidReservationID:       "Invalid reservation ID format",
+		InvalidTableID:             "Invalid table ID format",
+		InvalidUserID:              "Invalid user ID format",
+		InvalidWebhookID:           "Invalid webhook ID format",
+		InvalidRecurrenceGroupID:   "Invalid recurrence group ID format",
+		InvalidBlockedDateID:       "Invalid blocked date ID format",
+		InvalidSpecialHoursID:      "Invalid special hours ID format",
+		InvalidMonthFormat:         "Invalid month format (expected YYYY-MM)",
+		ReservationNotFound:        "Reservation not found",
+		DeletedReservationNotFound: "Deleted reservation not found",
+		TableNotFound:              "Table not found",
+		UserNotFound:               "User not found",
+		MonthlyStatsNotFound:       "Statistics for this month not found",
+		RestaurantNotFound:         "Restaurant not found",
+		WebhookNotFound:            "Webhook not found",
+		RecurrenceGroupNotFound:    "Recurring booking not found",
+		BlockedDateNotFound:        "Blocked date not found",
+		SpecialHoursNotFound:       "Special hours not found",
+		FeedbackNotFound:           "Feedback not found",
+		ReservationModified:        "Reservation was modified by another request",
+		DuplicateReservation:       "Possible duplicate reservation",
+		DuplicateFeedback:          "This reservation has already been rated",
+		TooManyActiveReservations:  "Too many active reservations",
+		SlotFull:                   "No more parties can be seated at this time",
+		DateBlocked:                "The restaurant does not accept reservations on this date",
+		RequestInProgress:          "A request with this idempotency key is still in progress",
+		TooManyLookupAttempts:      "Too many lookup attempts, try again later",
+		TooManyConfirmationResends: "The confirmation was resent too many times, try again later",
+		SystemAccountUndeletable:   "System account cannot be deleted",
+
+		InvalidDateFormat:                "Invalid date format",
+		InvalidTimeFormat:                "Invalid time format",
+		DateMissing:                      "Date is required",
+		TimeMissing:                      "Time is required",
+		GuestsNotPositive:                "Number of guests must be greater than 0",
+		InvalidStatus:                    "Invalid status",
+		ToDateBeforeFrom:                 "To date must not be before from date",
+		StartDateMissing:                 "Start date is required",
+		EndDateMissing:                   "End date is required",
+		EndDateBeforeStart:               "End date must not be before start date",
+		DateRangeTooLong:                 "Date range must not exceed %d days",
+		DateRangeTooLongWeeks:            "Date range must not exceed %d weeks",
+		TableNumberMissing:               "Table number is required",
+		GuestNameMissing:                 "Guest name is required",
+		GuestPhoneMissing:                "Guest phone is required",
+		GuestEmailMissing:                "Guest email is required",
+		GuestNameEmpty:                   "Guest name cannot be empty",
+		GuestPhoneEmpty:                  "Guest phone cannot be empty",
+		GuestEmailEmpty:                  "Guest email cannot be empty",
+		TableNumberEmpty:                 "Table number cannot be empty",
+		NameEmpty:                        "Name cannot be empty",
+		EmailEmpty:                       "Email cannot be empty",
+		FromMonthAfterTo:                 "From month must not be after to month",
+		FromDateMissing:                  "From date is required",
+		ToDateMissing:                    "To date is required",
+		RecurringTooManyOccurrences:      "A recurring booking can have at most %d occurrences",
+		RecurringTooFewOccurrences:       "A recurring booking needs at least 2 occurrences",
+		RecurringUntilTooEarly:           "Until must allow at least 2 occurrences",
+		RecurringFrequencyWeekly:         "Frequency must be weekly",
+		RecurringCountAndUntil:           "Either count or until is required, not both",
+		RecurringEndMissing:              "Either count or until is required",
+		ActiveReservationsLimit:          "You already have %d upcoming reservations, the limit is %d. Cancel one before booking another",
+		ActiveReservationsLimitRecurring: "You already have %d upcoming reservations and the booking adds %d, the limit is %d",
+		DateBlockedDetail:                "The restaurant does not accept reservations on %s: %s. Choose another date",
+		TableBookedByRow:                 "Table is already booked by row %d",
+		TableBookedAtTime:                "Table is already booked at this time",
+		TableIDsWithLocation:             "Table IDs and location cannot be combined",
+		TableIDsOrLocationMissing:        "Either table IDs or a location is required",
+		TableBookedNextFree:              "Table %s is already booked at %s, the next free slot is %s",
+		TableBookedNoFreeSlots:           "Table %s is already booked at %s and has no free slots later that day",
+		StaffNotesTooLong:                "Staff notes must not be longer than %d characters",
+		SpecialHoursExist:                "Special hours are already set for %s",
+		ReasonTooLong:                    "Reason must be at most %d characters",
+		ReasonMissing:                    "Reason is required",
+		RatingOutOfRange:                 "Rating must be between %d and %d",
+		PhotoURLInvalid:                  "Photo must be an http or https URL",
+		PhoneMissing:                     "Phone is required",
+		OpenTimeMissing:                  "Opening time is required",
+		CloseTimeMissing:                 "Closing time is required",
+		CloseTimeBeforeOpen:              "Closing time must be after opening time",
+		NoteTooLong:                      "Note must be at most %d characters",
+		RowNotImported:                   "Not imported because other rows are invalid",
+		MaxGuestsBelowGuests:             "Maximum guests must be greater than or equal to guests",
+		ConfirmationCodeMissing:          "Confirmation code is required",
+		InvalidConfirmationCode:          "Invalid confirmation code format",
+		FeedbackNotCompleted:             "Feedback can only be left on completed reservations, not %s ones",
+		ConfirmationNotResendable:        "Confirmations are only sent for pending and confirmed reservations, not %s ones",
+		CommentTooLong:                   "Comment must be at most %d characters",
+		AvailabilityMissing:              "Availability is required",
+		BulkTablesTooMany:                "At most %d tables can be updated at once",
+		BulkReservationsTooMany:          "At most %d reservations can be updated at once",
+		ImportTooManyRows:                "At most %d reservations can be imported at once",
+		BlockedRangeTooLong:              "At most %d days can be blocked at once",
+		ImportRowsMissing:                "At least one reservation is required",
+		BulkIDsMissing:                   "At least one reservation ID is required",
+		WebhookURLInvalid:                "An absolute http or https URL is required",
+		IdempotencyKeyTooLong:            "Idempotency key must not be longer than %d characters",
+		DuplicatePhone:                   "A reservation under this phone already exists at %s on %s",
+		BulkListedTwice:                  "Listed more than once, the first occurrence applies",
+		BulkNotApplied:                   "Not applied because other reservations could not be updated",
+		TableNumberNotFound:              "Table %s not found",
+		CombinedCapacityTooSmall:         "Combined capacity %d is less than the number of guests",
+		TableUnderMaintenance:            "Table %s is under maintenance and cannot be booked",
+		TableRetired:                     "Table %s is retired and cannot be booked",
+		SlotBoundary:                     "Reservations start every %d minutes, e.g. %s",
+		BookingTooFarAhead:               "Reservations can be made at most %d days in advance",
+		BookingTooLate:                   "Reservations must be made at least %d hours in advance",
+		PartyTooSmall:                    "Online reservations require at least %d guests",
+		PartyTooLarge:                    "Parties over %d must call the restaurant",
+		RestaurantClosedOn:               "The restaurant is closed %s",
+		OutsideOpeningHours:              "Reservations %s are accepted between %s and %s",
+		PhotoTooLarge:                    "Photo must not be larger than %d bytes",
+		PhotoMissing:                     "Photo is required",
+		PhotoFormat:                      "Photo must be a JPEG, PNG or WebP image",
+		StatusUnchanged:                  "reservation is already %s",
+		StatusFinal:                      "cannot change status from %s to %s, %s reservations are final",
+		StatusTransitionNotAllowed:       "cannot change status from %s to %s",
+		PartiesSeatedOnDate:              "%d parties are already seated at %s on %s, the limit is %d. Choose another time",
+		PartiesSeatedLimit:               "%d parties are already seated at %s, the limit is %d",
+		PartiesSeated:                    "%d parties are already seated at %s",
+		OccurrenceBreaksPolicy:           "Breaks the reservation policy",
+		OccurrenceClosed:                 "The restaurant is closed: %s",
+		OccurrenceTableBooked:            "Table %s is already booked",
+		OccurrenceDuplicate:              "A reservation under this phone already exists close to this time",
+		OnDate:                           "on %s",
+		OnMondays:                        "on Mondays",
+		OnTuesdays:                       "on Tuesdays",
+		OnWednesdays:                     "on Wednesdays",
+		OnThursdays:                      "on Thursdays",
+		OnFridays:                        "on Fridays",
+		OnSaturdays:                      "on Saturdays",
+		OnSundays:                        "on Sundays",
+		BodyTooLarge:                     "Request body must not be larger than %d bytes",
+		BodyMalformed:                    "Request body contains malformed JSON",
+		BodyInvalidField:                 "Request body contains an invalid value for field %q",
+		BodyInvalidValue:                 "Request body contains an invalid value",
+		BodyUnknownField:                 "Request body contains unknown field %s",
+		BodyEmpty:                        "Request body must not be empty",
+		BodyMultipleObjects:              "Request body must contain a single JSON object",
+		EmailMissing:                     "Email is required",
+		InvalidEmailFormat:               "Invalid email format",
+		PasswordMissing:                  "Password is required",
+		NameMissing:                      "Name is required",
+		InvalidPhoneFormat:               "Invalid phone format",
+		EmailTaken:                       "Email already exists",
+		PasswordTooShort:                 "Password must be at least %d characters",
+		PasswordNeedsDigit:               "Password must contain at least one digit",
+		PasswordNeedsUpper:               "Password must contain at least one uppercase letter",
+		PasswordNeedsSpecial:             "Password must contain at least one special character",
+		CSVGuestsNotNumber:               "guests must be a whole number",
+		CSVHeaderMissing:                 "CSV file must start with a header row",
+		CSVUnknownColumn:                 "CSV file contains unknown column %q",
+		CSVRowInvalid:                    "CSV row %d: %s",
+		CSVMalformedAt:                   "CSV file is malformed at line %d: %s",
+		CSVMalformed:                     "CSV file is malformed",
+	},
+	Ukrainian: {
+		InternalError:              "Внутрішня помилка сервера",
+		ValidationError:            "Помилка валідації",
+		InvalidRequestBody:         "Некоректне тіло запиту",
+		Unauthorized:               "Потрібна авторизація",
+		Forbidden:                  "Доступ заборонено",
+		NotFound:                   "Не знайдено",
+		EmailRequired:              "Потрібно вказати email і пароль",
+		InvalidCredentials:         "Невірний email або пароль",
+		InvalidReservationID:       "Некоректний формат ID бронювання",
+		InvalidTableID:             "Некоректний формат ID столика",
+		InvalidUserID:              "Некоректний формат ID користувача",
+		InvalidWebhookID:           "Некоректний формат ID вебхука",
+		InvalidRecurrenceGroupID:   "Некоректний формат ID регулярного бронювання",
+		InvalidBlockedDateID:       "Некоректний формат ID заблокованої дати",
+		InvalidSpecialHoursID:      "Некоректний формат ID особливого графіка",
+		InvalidMonthFormat:         "Некоректний формат місяця (очікується YYYY-MM)",
+		ReservationNotFound:        "Бронювання не знайдено",
+		DeletedReservationNotFound: "Видалене бронювання не знайдено",
+		TableNotFound:              "Столик не знайдено",
+		UserNotFound:               "Користувача не знайдено",
+		MonthlyStatsNotFound:       "Статистику за цей місяць не знайдено",
+		RestaurantNotFound:         "Ресторан не знайдено",
+		WebhookNotFound:            "Вебхук не знайдено",
+		RecurrenceGroupNotFound:    "Регулярне бронювання не знайдено",
+		BlockedDateNotFound:        "Заблоковану дату не знайдено",
+		SpecialHoursNotFound:       "Особливий графік не знайдено",
+		FeedbackNotFound:           "Відгук не знайдено",
+		ReservationModified:        "Бронювання було змінено іншим запитом",
+		DuplicateReservation:       "Можливе повторне бронювання",
+		DuplicateFeedback:          "Це бронювання вже оцінено",
+		TooManyActiveReservations:  "Забагато активних бронювань",
+		SlotFull:                   "На цей час більше не можна розмістити гостей",
+		DateBlocked:                "Ресторан не приймає бронювання на цю дату",
+		RequestInProgress:          "Запит з цим ключем ідемпотентності ще виконується",
+		TooManyLookupAttempts:      "Забагато спроб пошуку, спробуйте пізніше",
+		TooManyConfirmationResends: "Підтвердження надсилалося занадто багато разів, спробуйте пізніше",
+		SystemAccountUndeletable:   "Системний обліковий запис не можна видалити",
+
+		InvalidDateFormat:                "Некоректний формат дати",
+		InvalidTimeFormat:                "Некоректний формат часу",
+		DateMissing:                      "Потрібно вказати дату",
+		TimeMissing:                      "Потрібно вказати час",
+		GuestsNotPositive:                "Кількість гостей має бути більшою за 0",
+		InvalidStatus:                    "Некоректний статус",
+		ToDateBeforeFrom:                 "Кінцева дата не може передувати початковій",
+		StartDateMissing:                 "Потрібно вказати початкову дату",
+		EndDateMissing:                   "Потрібно вказати кінцеву дату",
+		EndDateBeforeStart:               "Кінцева дата не може передувати початковій",
+		DateRangeTooLong:                 "Діапазон дат не може перевищувати %d днів",
+		DateRangeTooLongWeeks:            "Діапазон дат не може перевищувати %d тижнів",
+		TableNumberMissing:               "Потрібно вказати номер столика",
+		GuestNameMissing:                 "Потрібно вказати ім'я гостя",
+		GuestPhoneMissing:                "Потрібно вказати телефон гостя",
+		GuestEmailMissing:                "Потрібно вказати email гостя",
+		GuestNameEmpty:                   "Ім'я гостя не може бути порожнім",
+		GuestPhoneEmpty:                  "Телефон гостя не може бути порожнім",
+		GuestEmailEmpty:                  "Email гостя не може бути порожнім",
+		TableNumberEmpty:                 "Номер столика не може бути порожнім",
+		NameEmpty:                        "Ім'я не може бути порожнім",
+		EmailEmpty:                       "Email не може бути порожнім",
+		FromMonthAfterTo:                 "Початковий місяць не може бути пізнішим за кінцевий",
+		FromDateMissing:                  "Потрібно вказати початкову дату",
+		ToDateMissing:                    "Потрібно вказати кінцеву дату",
+		RecurringTooManyOccurrences:      "Регулярне бронювання може мати щонайбільше %d повторень",
+		RecurringTooFewOccurrences:       "Регулярне бронювання має містити щонайменше 2 повторення",
+		RecurringUntilTooEarly:           "Кінцева дата має допускати щонайменше 2 повторення",
+		RecurringFrequencyWeekly:         "Частота має бути щотижневою",
+		RecurringCountAndUntil:           "Потрібно вказати або кількість повторень, або кінцеву дату, але не обидва",
+		RecurringEndMissing:              "Потрібно вказати кількість повторень або кінцеву дату",
+		ActiveReservationsLimit:          "Майбутніх бронювань у вас уже: %d, ліміт — %d. Скасуйте одне, перш ніж бронювати ще",
+		ActiveReservationsLimitRecurring: "Майбутніх бронювань у вас уже: %d, це бронювання додає ще %d, ліміт — %d",
+		DateBlockedDetail:                "Ресторан не приймає бронювання на %s: %s. Оберіть іншу дату",
+		TableBookedByRow:                 "Столик уже заброньовано в рядку %d",
+		TableBookedAtTime:                "Столик уже заброньовано на цей час",
+		TableIDsWithLocation:             "Не можна одночасно вказувати ID столиків і розташування",
+		TableIDsOrLocationMissing:        "Потрібно вказати ID столиків або розташування",
+		TableBookedNextFree:              "Столик %s уже заброньовано на %s, найближчий вільний час — %s",
+		TableBookedNoFreeSlots:           "Столик %s уже заброньовано на %s, і пізніше цього дня вільного часу немає",
+		StaffNotesTooLong:                "Службові нотатки не можуть бути довшими за %d символів",
+		SpecialHoursExist:                "Особливий графік на %s уже встановлено",
+		ReasonTooLong:                    "Причина не може бути довшою за %d символів",
+		ReasonMissing:                    "Потрібно вказати причину",
+		RatingOutOfRange:                 "Оцінка має бути від %d до %d",
+		PhotoURLInvalid:                  "Фото має бути http- або https-посиланням",
+		PhoneMissing:                     "Потрібно вказати телефон",
+		OpenTimeMissing:                  "Потрібно вказати час відкриття",
+		CloseTimeMissing:                 "Потрібно вказати час закриття",
+		CloseTimeBeforeOpen:              "Час закриття має бути пізнішим за час відкриття",
+		NoteTooLong:                      "Примітка не може бути довшою за %d символів",
+		RowNotImported:                   "Не імпортовано, оскільки інші рядки некоректні",
+		MaxGuestsBelowGuests:             "Максимальна кількість гостей має бути не меншою за кількість гостей",
+		ConfirmationCodeMissing:          "Потрібно вказати код підтвердження",
+		InvalidConfirmationCode:          "Некоректний формат коду підтвердження",
+		FeedbackNotCompleted:             "Відгук можна залишити лише для завершених бронювань, а не для бронювань зі статусом %s",
+		ConfirmationNotResendable:        "Підтвердження надсилаються лише для бронювань в очікуванні та підтверджених, а не для бронювань зі статусом %s",
+		CommentTooLong:                   "Коментар не може бути довшим за %d символів",
+		AvailabilityMissing:              "Потрібно вказати доступність",
+		BulkTablesTooMany:                "За один раз можна змінити щонайбільше %d столиків",
+		BulkReservationsTooMany:          "За один раз можна змінити щонайбільше %d бронювань",
+		ImportTooManyRows:                "За один раз можна імпортувати щонайбільше %d бронювань",
+		BlockedRangeTooLong:              "За один раз можна заблокувати щонайбільше %d днів",
+		ImportRowsMissing:                "Потрібно вказати щонайменше одне бронювання",
+		BulkIDsMissing:                   "Потрібно вказати щонайменше один ID бронювання",
+		WebhookURLInvalid:                "Потрібне абсолютне http- або https-посилання",
+		IdempotencyKeyTooLong:            "Ключ ідемпотентності не може бути довшим за %d символів",
+		DuplicatePhone:                   "Бронювання на цей телефон уже існує на %[2]s о %[1]s",
+		BulkListedTwice:                  "Указано більше одного разу, застосовано перше входження",
+		BulkNotApplied:                   "Не застосовано, оскільки інші бронювання не вдалося змінити",
+		TableNumberNotFound:              "Столик %s не знайдено",
+		CombinedCapacityTooSmall:         "Загальна місткість %d менша за кількість гостей",
+		TableUnderMaintenance:            "Столик %s на обслуговуванні, його не можна забронювати",
+		TableRetired:                     "Столик %s виведено з експлуатації, його не можна забронювати",
+		SlotBoundary:                     "Бронювання починаються кожні %d хвилин, наприклад о %s",
+		BookingTooFarAhead:               "Бронювати можна щонайбільше за %d днів наперед",
+		BookingTooLate:                   "Бронювати потрібно щонайменше за %d годин наперед",
+		PartyTooSmall:                    "Онлайн-бронювання доступне для щонайменше %d гостей",
+		PartyTooLarge:                    "Компаніям понад %d осіб потрібно зателефонувати до ресторану",
+		RestaurantClosedOn:               "Ресторан не працює %s",
+		OutsideOpeningHours:              "Бронювання %s приймаються з %s до %s",
+		PhotoTooLarge:                    "Фото не може бути більшим за %d байтів",
+		PhotoMissing:                     "Потрібно завантажити фото",
+		PhotoFormat:                      "Фото має бути зображенням JPEG, PNG або WebP",
+		StatusUnchanged:                  "бронювання вже має статус %s",
+		StatusFinal:                      "неможливо змінити статус з %s на %s, статус %s остаточний",
+		StatusTransitionNotAllowed:       "неможливо змінити статус з %s на %s",
+		PartiesSeatedOnDate:              "На %[3]s о %[2]s уже розміщено компаній: %[1]d, ліміт — %[4]d. Оберіть інший час",
+		PartiesSeatedLimit:               "О %[2]s уже розміщено компаній: %[1]d, ліміт — %[3]d",
+		PartiesSeated:                    "О %[2]s уже розміщено компаній: %[1]d",
+		OccurrenceBreaksPolicy:           "Порушує правила бронювання",
+		OccurrenceClosed:                 "Ресторан не працює: %s",
+		OccurrenceTableBooked:            "Столик %s уже заброньовано",
+		OccurrenceDuplicate:              "Бронювання на цей телефон уже існує близько до цього часу",
+		OnDate:                           "на %s",
+		OnMondays:                        "по понеділках",
+		OnTuesdays:                       "по вівторках",
+		OnWednesdays:                     "по середах",
+		OnThursdays:                      "по четвергах",
+		OnFridays:                        "по п'ятницях",
+		OnSaturdays:                      "по суботах",
+		OnSundays:                        "по неділях",
+		BodyTooLarge:                     "Тіло запиту не має перевищувати %d байтів",
+		BodyMalformed:                    "Тіло запиту містить некоректний JSON",
+		BodyInvalidField:                 "Тіло запиту містить некоректне значення поля %q",
+		BodyInvalidValue:                 "Тіло запиту містить некоректне значення",
+		BodyUnknownField:                 "Тіло запиту містить невідоме поле %s",
+		BodyEmpty:                        "Тіло запиту не може бути порожнім",
+		BodyMultipleObjects:              "Тіло запиту має містити один JSON-об'єкт",
+		EmailMissing:                     "Потрібно вказати email",
+		InvalidEmailFormat:               "Некоректний формат email",
+		PasswordMissing:                  "Потрібно вказати пароль",
+		NameMissing:                      "Потрібно вказати ім'я",
+		InvalidPhoneFormat:               "Некоректний формат телефону",
+		EmailTaken:                       "Цей email уже зареєстровано",
+		PasswordTooShort:                 "Пароль має містити щонайменше %d символів",
+		PasswordNeedsDigit:               "Пароль має містити щонайменше одну цифру",
+		PasswordNeedsUpper:               "Пароль має містити щонайменше одну велику літеру",
+		PasswordNeedsSpecial:             "Пароль має містити щонайменше один спеціальний символ",
+		CSVGuestsNotNumber:               "кількість гостей має бути цілим числом",
+		CSVHeaderMissing:                 "CSV-файл має починатися з рядка заголовків",
+		CSVUnknownColumn:                 "CSV-файл містить невідомий стовпець %q",
+		CSVRowInvalid:                    "Рядок CSV %d: %s",
+		CSVMalformedAt:                   "CSV-файл пошкоджено в рядку %d: %s",
+		CSVMalformed:                     "CSV-файл пошкоджено",
+	},
+}
+
+// Translate returns the message for key in lang, falling back to English
+// and then to the key itself when no translation exists
+func Translate(lang string, key Key) string {
+	if message, ok := catalog[lang][key]; ok {
+		return message
+	}
+	if message, ok := catalog[DefaultLanguage][key]; ok {
+		return message
+	}
+	return string(key)
+}
+
+// Negotiate picks the supported language the client prefers most from an Accept-Language
+// header value, honouring quality values. Region subtags are ignored, so "uk-UA" selects Ukrainian
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := catalog[lang]; ok && quality > 0 {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+
+	// A stable sort keeps the client's order among languages of equal quality
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+	return candidates[0].lang
+}
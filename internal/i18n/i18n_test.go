@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "missing header", header: "", want: English},
+		{name: "supported language", header: "uk", want: Ukrainian},
+		{name: "region subtag", header: "uk-UA", want: Ukrainian},
+		{name: "first preferred", header: "uk-UA,uk;q=0.9,en;q=0.8", want: Ukrainian},
+		{name: "quality wins over order", header: "en;q=0.5,uk;q=0.9", want: Ukrainian},
+		{name: "unsupported languages skipped", header: "de-DE,de;q=0.9,uk;q=0.5", want: Ukrainian},
+		{name: "nothing supported", header: "de,fr;q=0.5", want: English},
+		{name: "refused language", header: "uk;q=0", want: English},
+		{name: "wildcard", header: "*", want: English},
+		{name: "invalid quality", header: "uk;q=high,en", want: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Table not found", Translate(English, TableNotFound))
+	assert.Equal(t, "Столик не знайдено", Translate(Ukrainian, TableNotFound))
+	assert.Equal(t, "Table not found", Translate("de", TableNotFound))
+	assert.Equal(t, "unknown_key", Translate(English, Key("unknown_key")))
+}
+
+// Every message must be translated so that no locale silently falls back to English
+func TestCatalogComplete(t *testing.T) {
+	for lang, messages := range catalog {
+		assert.Len(t, messages, len(catalog[DefaultLanguage]), "language %s", lang)
+		for key := range catalog[DefaultLanguage] {
+			assert.NotEmpty(t, messages[key], "language %s is missing %s", lang, key)
+			assert.Equal(t, strings.Count(catalog[DefaultLanguage][key], "%"), strings.Count(messages[key], "%"),
+				"language %s has different placeholders in %s", lang, key)
+		}
+	}
+}
+
+func TestMessage_Translate(t *testing.T) {
+	message := Msg(OutsideOpeningHours, Msg(OnDate, "2025-12-31"), "18:00", "23:30")
+	assert.Equal(t, "Reservations on 2025-12-31 are accepted between 18:00 and 23:30", message.Translate(English))
+	assert.Equal(t, "Бронювання на 2025-12-31 приймаються з 18:00 до 23:30", message.Translate(Ukrainian))
+
+	seated := Msg(PartiesSeatedLimit, 3, "19:00", 3)
+	assert.Equal(t, "3 parties are already seated at 19:00, the limit is 3", seated.Translate(English))
+	assert.Equal(t, "О 19:00 уже розміщено компаній: 3, ліміт — 3", seated.Translate(Ukrainian))
+
+	assert.Equal(t, "19:30", Value("19:30").Translate(Ukrainian))
+	assert.True(t, Message{}.IsZero())
+	assert.False(t, Value("19:30").IsZero())
+}
+
+func TestDetails_Translate(t *testing.T) {
+	details := Details{"guests": Msg(GuestsNotPositive), "nextAvailableTime": Value("19:30")}
+	assert.Equal(t, map[string]string{
+		"guests":            "Кількість гостей має бути більшою за 0",
+		"nextAvailableTime": "19:30",
+	}, details.Translate(Ukrainian))
+	assert.Nil(t, Details(nil).Translate(English))
+}
+
+func TestMessageOf(t *testing.T) {
+	err := Errorf(BodyUnknownField, `"nmae"`)
+	assert.EqualError(t, err, `Request body contains unknown field "nmae"`)
+	assert.Equal(t, `Тіло запиту містить невідоме поле "nmae"`, MessageOf(err).Translate(Ukrainian))
+	assert.Equal(t, "plain", MessageOf(errors.New("plain")).Translate(Ukrainian))
+}
@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Message is a catalog message together with the values of its placeholders,
+// translated only once the language of the response is known
+type Message struct {
+	Key  Key
+	Args []interface{}
+
+	// value is rendered verbatim in every language, see Value
+	value string
+}
+
+// Msg returns the message for key whose fmt verbs are filled in with args.
+// Args that are messages themselves are translated into the same language
+func Msg(key Key, args ...interface{}) Message {
+	return Message{Key: key, Args: args}
+}
+
+// Value returns a message rendered verbatim, for data such as IDs or times carried in details
+func Value(value string) Message {
+	return Message{value: value}
+}
+
+// IsZero reports whether m carries no message
+func (m Message) IsZero() bool {
+	return m.Key == "" && m.value == ""
+}
+
+// Translate renders m in lang
+func (m Message) Translate(lang string) string {
+	if m.Key == "" {
+		return m.value
+	}
+	message := Translate(lang, m.Key)
+	if len(m.Args) > 0 {
+		args := make([]interface{}, len(m.Args))
+		for i, arg := range m.Args {
+			if nested, ok := arg.(Message); ok {
+				arg = nested.Translate(lang)
+			}
+			args[i] = arg
+		}
+		message = fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Details are field-level messages keyed by field path
+type Details map[string]Message
+
+// Translate renders every message in lang, in the shape of an error response's details
+func (d Details) Translate(lang string) map[string]string {
+	if d == nil {
+		return nil
+	}
+	translated := make(map[string]string, len(d))
+	for field, message := range d {
+		translated[field] = message.Translate(lang)
+	}
+	return translated
+}
+
+// Error is an error whose message comes from the catalog. Error() renders it in the default language
+type Error struct {
+	Message Message
+}
+
+// Errorf returns an error with the message for key whose fmt verbs are filled in with args
+func Errorf(key Key, args ...interface{}) *Error {
+	return &Error{Message: Msg(key, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Message.Translate(DefaultLanguage)
+}
+
+// MessageOf returns the catalog message carried by err, or err's text verbatim when it carries none
+func MessageOf(err error) Message {
+	var catalogErr *Error
+	if errors.As(err, &catalogErr) {
+		return catalogErr.Message
+	}
+	return Value(err.Error())
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/notifier"
+	"github.com/EduardMikhrin/university-booking-project/internal/notifier/smtp"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+const notifierConfigKey = "notifier"
+
+type Notifierer interface {
+	Notifier() notifier.Notifier
+}
+
+func NewNotifierer(getter kv.Getter) Notifierer {
+	return &notifierer{
+		getter: getter,
+	}
+}
+
+type notifierer struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+type config struct {
+	Enabled   bool   `fig:"enabled"`
+	Host      string `fig:"host"`
+	Port      int    `fig:"port"`
+	Username  string `fig:"username"`
+	Password  string `fig:"password"`
+	From      string `fig:"from"`
+	CancelURL string `fig:"cancel_url"`
+}
+
+func (n *notifierer) Notifier() notifier.Notifier {
+	config := n.Config()
+	if !config.Enabled {
+		return notifier.NewNoop()
+	}
+
+	return smtp.NewNotifier(smtp.Options{
+		Host:      config.Host,
+		Port:      config.Port,
+		Username:  config.Username,
+		Password:  config.Password,
+		From:      config.From,
+		CancelURL: config.CancelURL,
+	})
+}
+
+func (n *notifierer) Config() *config {
+	return n.once.Do(func() interface{} {
+		var cfg config
+		if err := figure.Out(&cfg).From(kv.MustGetStringMap(n.getter, notifierConfigKey)).Please(); err != nil {
+			panic(errors.Wrap(err, "failed to figure out notifier config"))
+		}
+
+		if cfg.Enabled && (cfg.Host == "" || cfg.Port == 0 || cfg.From == "") {
+			panic(errors.New("notifier host, port and from are required when notifier is enabled"))
+		}
+
+		return &cfg
+	}).(*config)
+}
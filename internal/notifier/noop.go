@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+)
+
+// Noop implements Notifier interface without sending anything
+// It is used in tests and in environments where notifications are disabled
+type Noop struct{}
+
+// NewNoop creates a new Noop notifier instance
+func NewNoop() Notifier {
+	return &Noop{}
+}
+
+// SendReservationConfirmation does nothing
+func (n *Noop) SendReservationConfirmation(ctx context.Context, reservation *types.Reservation) error {
+	return nil
+}
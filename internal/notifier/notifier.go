@@ -0,0 +1,13 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+)
+
+// Notifier defines methods for sending notifications to guests
+type Notifier interface {
+	// SendReservationConfirmation sends a confirmation for a newly created reservation
+	SendReservationConfirmation(ctx context.Context, reservation *types.Reservation) error
+}
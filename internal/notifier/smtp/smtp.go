@@ -0,0 +1,133 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/notifier"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/pkg/errors"
+)
+
+// Options holds SMTP connection and message settings
+type Options struct {
+	Host      string
+	Port      int
+	Username  string
+	Password  string
+	From      string
+	CancelURL string
+}
+
+// Notifier implements notifier.Notifier interface using SMTP
+type Notifier struct {
+	opts Options
+}
+
+// NewNotifier creates a new SMTP Notifier instance
+func NewNotifier(opts Options) notifier.Notifier {
+	return &Notifier{opts: opts}
+}
+
+// SendReservationConfirmation sends a confirmation email to the reservation guest
+func (n *Notifier) SendReservationConfirmation(ctx context.Context, reservation *types.Reservation) error {
+	msg := buildConfirmationMessage(n.opts.From, reservation, n.cancelLink(reservation))
+	return n.send(ctx, reservation.GuestEmail, msg)
+}
+
+// cancelLink builds the link a guest can follow to cancel the reservation
+func (n *Notifier) cancelLink(reservation *types.Reservation) string {
+	return fmt.Sprintf("%s/%s/cancel", strings.TrimRight(n.opts.CancelURL, "/"), reservation.ID)
+}
+
+func (n *Notifier) send(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(n.opts.Host, strconv.Itoa(n.opts.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial smtp server %s", addr)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return errors.Wrap(err, "failed to set smtp connection deadline")
+		}
+	}
+
+	client, err := smtp.NewClient(conn, n.opts.Host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed to create smtp client")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.opts.Host}); err != nil {
+			return errors.Wrap(err, "failed to start tls")
+		}
+	}
+
+	if n.opts.Username != "" {
+		auth := smtp.PlainAuth("", n.opts.Username, n.opts.Password, n.opts.Host)
+		if err := client.Auth(auth); err != nil {
+			return errors.Wrap(err, "failed to authenticate")
+		}
+	}
+
+	if err := client.Mail(n.opts.From); err != nil {
+		return errors.Wrap(err, "failed to set sender")
+	}
+	if err := client.Rcpt(to); err != nil {
+		return errors.Wrap(err, "failed to set recipient")
+	}
+
+	wc, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to open message body")
+	}
+	if _, err := wc.Write(msg); err != nil {
+		wc.Close()
+		return errors.Wrap(err, "failed to write message body")
+	}
+	if err := wc.Close(); err != nil {
+		return errors.Wrap(err, "failed to send message body")
+	}
+
+	return client.Quit()
+}
+
+// buildConfirmationMessage renders the confirmation email including headers
+func buildConfirmationMessage(from string, reservation *types.Reservation, cancelLink string) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", reservation.GuestEmail)
+	fmt.Fprintf(&buf, "Subject: Reservation confirmation for %s\r\n", reservation.Date.Format("2006-01-02"))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "Dear %s,\r\n\r\n", reservation.GuestName)
+	if reservation.Status == types.ReservationStatusConfirmed {
+		buf.WriteString("Your reservation is confirmed.\r\n\r\n")
+	} else {
+		buf.WriteString("Your reservation has been received.\r\n\r\n")
+	}
+	fmt.Fprintf(&buf, "Date: %s\r\n", reservation.Date.Format("2006-01-02"))
+	fmt.Fprintf(&buf, "Time: %s\r\n", reservation.Time)
+	fmt.Fprintf(&buf, "Table: %s\r\n", reservation.TableNumber)
+	fmt.Fprintf(&buf, "Party size: %d\r\n", reservation.Guests)
+	if reservation.ConfirmationCode != nil {
+		fmt.Fprintf(&buf, "Confirmation code: %s\r\n", *reservation.ConfirmationCode)
+	}
+	fmt.Fprintf(&buf, "\r\nTo cancel your reservation, follow this link:\r\n%s\r\n", cancelLink)
+
+	return buf.Bytes()
+}
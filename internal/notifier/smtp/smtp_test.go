@@ -0,0 +1,53 @@
+package smtp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildConfirmationMessage(t *testing.T) {
+	reservationID := uuid.New()
+	code := "K7M2QX"
+	reservation := &types.Reservation{
+		ID:               reservationID,
+		GuestName:        "John Doe",
+		GuestEmail:       "john@example.com",
+		Date:             time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		Time:             "19:00",
+		Guests:           4,
+		TableNumber:      "T1",
+		ConfirmationCode: &code,
+	}
+
+	n := &Notifier{opts: Options{From: "booking@example.com", CancelURL: "https://booking.example.com/reservations/"}}
+	msg := string(buildConfirmationMessage(n.opts.From, reservation, n.cancelLink(reservation)))
+
+	assert.Contains(t, msg, "From: booking@example.com\r\n")
+	assert.Contains(t, msg, "To: john@example.com\r\n")
+	assert.Contains(t, msg, "Date: 2025-12-25\r\n")
+	assert.Contains(t, msg, "Time: 19:00\r\n")
+	assert.Contains(t, msg, "Table: T1\r\n")
+	assert.Contains(t, msg, "Party size: 4\r\n")
+	assert.Contains(t, msg, "Confirmation code: K7M2QX\r\n")
+	assert.Contains(t, msg, "Your reservation has been received.\r\n")
+	assert.Contains(t, msg, "https://booking.example.com/reservations/"+reservationID.String()+"/cancel")
+}
+
+func TestBuildConfirmationMessageAutoConfirmed(t *testing.T) {
+	reservation := &types.Reservation{
+		ID:         uuid.New(),
+		GuestName:  "John Doe",
+		GuestEmail: "john@example.com",
+		Date:       time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		Status:     types.ReservationStatusConfirmed,
+	}
+
+	msg := string(buildConfirmationMessage("booking@example.com", reservation, "https://booking.example.com/cancel"))
+
+	assert.Contains(t, msg, "Your reservation is confirmed.\r\n")
+	assert.NotContains(t, msg, "has been received")
+}
@@ -0,0 +1,99 @@
+// Package pdf renders simple single-page PDF documents using the standard Helvetica fonts,
+// so no font files have to be embedded
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Page size of an A4 sheet in points
+const (
+	PageWidth  = 595
+	PageHeight = 842
+)
+
+// Font is one of the standard fonts every PDF viewer provides
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// fontResources are the resource names of the fonts in the order of the Font constants
+var fontResources = []string{"F1", "F2"}
+
+// Page collects the content of a single A4 page. Coordinates are in points from the bottom left corner
+type Page struct {
+	content bytes.Buffer
+}
+
+// Text draws text starting at the given baseline position. Characters outside
+// Latin-1 cannot be shown by the standard fonts and are replaced with '?'
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		fontResources[font], formatNumber(size), formatNumber(x), formatNumber(y), encodeText(text))
+}
+
+// Line draws a straight line of the given width
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		formatNumber(width), formatNumber(x1), formatNumber(y1), formatNumber(x2), formatNumber(y2))
+}
+
+// Bytes returns the PDF document holding the page. The output only depends on the page content
+func (p *Page) Bytes() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", PageWidth, PageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+// encodeText converts text to a WinAnsi string literal body, escaping delimiters and non-ASCII bytes
+func encodeText(text string) string {
+	var buf bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			buf.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// Latin-1 supplement characters share their codes with WinAnsi
+			fmt.Fprintf(&buf, "\\%03o", r)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
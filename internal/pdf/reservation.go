@@ -0,0 +1,68 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+)
+
+// Layout of the reservation confirmation in points
+const (
+	margin      = 56
+	valueColumn = 200
+	rowHeight   = 22
+)
+
+// RenderReservation renders the printable confirmation of a reservation
+func RenderReservation(restaurantName string, reservation *types.Reservation) []byte {
+	var page Page
+
+	y := float64(PageHeight - 62)
+	page.Text(margin, y, Bold, 22, restaurantName)
+	y -= 28
+	page.Text(margin, y, Regular, 14, "Reservation confirmation")
+	y -= 14
+	page.Line(margin, y, PageWidth-margin, y, 1)
+	y -= 28
+
+	for _, row := range reservationRows(reservation) {
+		page.Text(margin, y, Bold, 11, row[0])
+		page.Text(valueColumn, y, Regular, 11, row[1])
+		y -= rowHeight
+	}
+
+	y -= rowHeight
+	page.Text(margin, y, Regular, 10, "Please show this confirmation when you arrive.")
+
+	return page.Bytes()
+}
+
+// reservationRows lists the label and value of every detail printed on the confirmation
+func reservationRows(reservation *types.Reservation) [][2]string {
+	tableLabel, tables := "Table", reservation.TableNumber
+	if len(reservation.TableNumbers) > 1 {
+		tableLabel, tables = "Tables", strings.Join(reservation.TableNumbers, ", ")
+	}
+
+	partySize := strconv.Itoa(reservation.Guests) + " guests"
+	if reservation.Guests == 1 {
+		partySize = "1 guest"
+	}
+
+	code := "-"
+	if reservation.ConfirmationCode != nil {
+		code = *reservation.ConfirmationCode
+	}
+
+	return [][2]string{
+		{"Guest", reservation.GuestName},
+		{"Phone", reservation.GuestPhone},
+		{"Email", reservation.GuestEmail},
+		{"Date", reservation.Date.Format("Monday, 2 January 2006")},
+		{"Time", reservation.Time},
+		{tableLabel, tables},
+		{"Party size", partySize},
+		{"Confirmation code", code},
+	}
+}
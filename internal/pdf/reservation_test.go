@@ -0,0 +1,75 @@
+package pdf
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRenderReservation(t *testing.T) {
+	code := "K7M2QX9P"
+
+	tests := []struct {
+		name        string
+		reservation *types.Reservation
+		golden      string
+	}{
+		{
+			name: "single table",
+			reservation: &types.Reservation{
+				GuestName:        "John Doe",
+				GuestPhone:       "+1234567890",
+				GuestEmail:       "john@example.com",
+				Date:             time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				Time:             "19:00",
+				Guests:           4,
+				TableNumber:      "T1",
+				ConfirmationCode: &code,
+			},
+			golden: "reservation.pdf",
+		},
+		{
+			name: "merged tables without code",
+			reservation: &types.Reservation{
+				GuestName:    "Zoë (O'Brien) Ковальчук",
+				GuestPhone:   "+1234567890",
+				GuestEmail:   "zoe@example.com",
+				Date:         time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+				Time:         "20:30",
+				Guests:       10,
+				TableNumber:  "T1",
+				TableNumbers: []string{"T1", "T2"},
+			},
+			golden: "reservation_merged.pdf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderReservation("Test Restaurant", tt.reservation)
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(path, got, 0o644))
+			}
+
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "golden file missing, run with -update")
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	assert.Equal(t, `a \(b\) \\ c`, encodeText(`a (b) \ c`))
+	assert.Equal(t, `Zo\353`, encodeText("Zoë"))
+	assert.Equal(t, "???", encodeText("Ков"))
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// Business actions logged once they succeed. Every entry carries the action under "action",
+// the acting user under "user_id" and the restaurant under "restaurant_id", so that the history
+// of an entity can be reconstructed from the logs. Passwords, tokens and secrets are never logged
+const (
+	actionReservationCreated       = "reservation_created"
+	actionReservationUpdated       = "reservation_updated"
+	actionReservationStatusChanged = "reservation_status_changed"
+	actionReservationNotesUpdated  = "reservation_notes_updated"
+	actionReservationDeleted       = "reservation_deleted"
+	actionReservationRestored      = "reservation_restored"
+	actionConfirmationResent       = "confirmation_resent"
+	actionFeedbackCreated          = "feedback_created"
+	actionReservationsImported     = "reservations_imported"
+	actionTableAvailabilityChanged = "table_availability_changed"
+	actionTableStatusChanged       = "table_status_changed"
+	actionUserRegistered           = "user_registered"
+	actionUserUpdated              = "user_updated"
+	actionUserPhotoUpdated         = "user_photo_updated"
+	actionUserDeleted              = "user_deleted"
+	actionWebhookCreated           = "webhook_created"
+	actionWebhookDeleted           = "webhook_deleted"
+	actionBlockedDateCreated       = "blocked_date_created"
+	actionBlockedDateDeleted       = "blocked_date_deleted"
+	actionSpecialHoursCreated      = "special_hours_created"
+	actionSpecialHoursUpdated      = "special_hours_updated"
+	actionSpecialHoursDeleted      = "special_hours_deleted"
+)
+
+// logAction logs a successful business action at info level. Fields describe the affected entity,
+// e.g. reservation_id, and its before and after values, e.g. from_status and to_status
+func (s *Server) logAction(r *http.Request, action string, fields logan.F) {
+	entry := s.log.WithFields(fields).WithField("action", action)
+	if user, err := GetUserFromContext(r); err == nil {
+		entry = entry.WithField("user_id", user.ID)
+	}
+	if restaurantID, ok := tenant.RestaurantFromContext(r.Context()); ok {
+		entry = entry.WithField("restaurant_id", restaurantID)
+	}
+	entry.Info(strings.ReplaceAll(action, "_", " "))
+}
+
+// reservationFields describes a reservation in business action logs. Guest contact details are left out
+func reservationFields(reservation *types.Reservation) logan.F {
+	fields := logan.F{
+		"reservation_id": reservation.ID,
+		"date":           reservation.Date.Format("2006-01-02"),
+		"time":           reservation.Time,
+		"guests":         reservation.Guests,
+		"table_numbers":  strings.Join(reservationTableNumbers(reservation), ","),
+		"status":         reservation.Status,
+	}
+	if reservation.UserID != nil {
+		fields["owner_id"] = *reservation.UserID
+	}
+	if reservation.RecurrenceGroupID != nil {
+		fields["recurrence_group_id"] = *reservation.RecurrenceGroupID
+	}
+	return fields
+}
+
+// changedFields pairs up the values that differ between two descriptions of an entity
+// as from_ and to_ fields, e.g. from_time and to_time
+func changedFields(before, after logan.F) logan.F {
+	fields := logan.F{}
+	for key, value := range after {
+		if before[key] != value {
+			fields["from_"+key] = before[key]
+			fields["to_"+key] = value
+		}
+	}
+	return fields
+}
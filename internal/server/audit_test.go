@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+func TestReservationFields(t *testing.T) {
+	ownerID := uuid.New()
+	reservation := &types.Reservation{
+		ID:           uuid.New(),
+		UserID:       &ownerID,
+		GuestName:    "Alice",
+		GuestPhone:   "+380501234567",
+		GuestEmail:   "alice@example.com",
+		Date:         time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		Time:         "19:00",
+		Guests:       6,
+		TableNumber:  "T1",
+		TableNumbers: []string{"T1", "T2"},
+		Status:       "pending",
+	}
+
+	fields := reservationFields(reservation)
+	assert.Equal(t, reservation.ID, fields["reservation_id"])
+	assert.Equal(t, ownerID, fields["owner_id"])
+	assert.Equal(t, "2025-12-25", fields["date"])
+	assert.Equal(t, "T1,T2", fields["table_numbers"])
+	assert.NotContains(t, fields, "recurrence_group_id")
+
+	for _, value := range fields {
+		assert.NotContains(t, []interface{}{"Alice", "+380501234567", "alice@example.com"}, value)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	before := logan.F{"time": "19:00", "guests": 4, "status": "pending"}
+	after := logan.F{"time": "20:00", "guests": 4, "status": "pending"}
+
+	assert.Equal(t, logan.F{"from_time": "19:00", "to_time": "20:00"}, changedFields(before, after))
+	assert.Empty(t, changedFields(before, before))
+}
@@ -1,14 +1,19 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -53,45 +58,42 @@ type LogoutResponse struct {
 // @Router /auth/login [post]
 func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode login request")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
 	req.Email = strings.TrimSpace(req.Email)
 	if req.Email == "" || req.Password == "" {
-		writeErrorResponse(w, http.StatusBadRequest, "Email and password are required", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.EmailRequired, nil)
 		return
 	}
 
 	user, err := s.db.UserQ().GetByEmail(r.Context(), req.Email)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusUnauthorized, codeInvalidCredentials, i18n.InvalidCredentials, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get user by email")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if user == nil {
-		writeErrorResponse(w, http.StatusUnauthorized, "Invalid email or password", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		writeErrorResponse(w, http.StatusUnauthorized, "Invalid email or password", nil)
+		writeErrorResponse(w, r, http.StatusUnauthorized, codeInvalidCredentials, i18n.InvalidCredentials, nil)
 		return
 	}
 
 	token, err := s.generateToken(user.ID)
 	if err != nil {
 		s.log.WithError(err).Error("failed to generate token")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	if err := s.cache.TokenCache().SetToken(r.Context(), token, user.ID, s.jwtConfig.AccessTokenLifetime); err != nil {
-		s.log.WithError(err).Warn("failed to cache token")
-	}
+	s.cacheToken(r.Context(), token, user.ID)
 
 	response := AuthResponse{
 		User:  user,
@@ -113,47 +115,53 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 // @Router /auth/register [post]
 func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode register request")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
-	validationErrors := make(map[string]string)
+	validationErrors := make(i18n.Details)
 	req.Email = strings.TrimSpace(req.Email)
 	req.Name = strings.TrimSpace(req.Name)
-	req.Phone = strings.TrimSpace(req.Phone)
+	req.Phone = normalizePhone(req.Phone)
 
 	if req.Email == "" {
-		validationErrors["email"] = "Email is required"
+		validationErrors["email"] = i18n.Msg(i18n.EmailMissing)
 	} else if !isValidEmail(req.Email) {
-		validationErrors["email"] = "Invalid email format"
+		validationErrors["email"] = i18n.Msg(i18n.InvalidEmailFormat)
 	}
 
 	if req.Password == "" {
-		validationErrors["password"] = "Password is required"
-	} else if len(req.Password) < 6 {
-		validationErrors["password"] = "Password must be at least 6 characters"
+		validationErrors["password"] = i18n.Msg(i18n.PasswordMissing)
+	} else {
+		for rule, message := range s.passwordPolicy.Validate(req.Password) {
+			validationErrors[rule] = message
+		}
 	}
 
 	if req.Name == "" {
-		validationErrors["name"] = "Name is required"
+		validationErrors["name"] = i18n.Msg(i18n.NameMissing)
+	}
+
+	if req.Phone != "" && !isValidPhone(req.Phone) {
+		validationErrors["phone"] = i18n.Msg(i18n.InvalidPhoneFormat)
 	}
 
 	if len(validationErrors) > 0 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", validationErrors)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
 		return
 	}
 
 	existingUser, err := s.db.UserQ().GetByEmail(r.Context(), req.Email)
-	if err != nil {
+	if err != nil && !errors.Is(err, data.ErrNotFound) {
 		s.log.WithError(err).Error("failed to check email existence")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 	if existingUser != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", map[string]string{
-			"email": "Email already exists",
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"email": i18n.Msg(i18n.EmailTaken),
 		})
 		return
 	}
@@ -161,7 +169,7 @@ func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		s.log.WithError(err).Error("failed to hash password")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -177,20 +185,19 @@ func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
 
 	if err := s.db.UserQ().Create(r.Context(), user); err != nil {
 		s.log.WithError(err).Error("failed to create user")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
+	s.logAction(r, actionUserRegistered, logan.F{"target_user_id": user.ID, "role": user.Role})
 
 	token, err := s.generateToken(user.ID)
 	if err != nil {
 		s.log.WithError(err).Error("failed to generate token")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	if err := s.cache.TokenCache().SetToken(r.Context(), token, user.ID, s.jwtConfig.AccessTokenLifetime); err != nil {
-		s.log.WithError(err).Warn("failed to cache token")
-	}
+	s.cacheToken(r.Context(), token, user.ID)
 
 	response := AuthResponse{
 		User:  user,
@@ -203,6 +210,7 @@ func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
 // @Summary Get current user
 // @Description Get authenticated user from JWT token
 // @Tags Auth
+// @Security BearerAuth
 // @Produce json
 // @Success 200 {object} types.User
 // @Failure 500 {object} ErrorResponse "Server error"
@@ -211,7 +219,7 @@ func (s *Server) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -223,7 +231,7 @@ func (s *Server) handleGetMe(w http.ResponseWriter, r *http.Request) {
 // @Description Invalidate JWT token and remove from cache
 // @Tags Auth
 // @Produce json
-// @Param Authorization header string true "Bearer {token}"
+// @Security BearerAuth
 // @Success 200 {object} LogoutResponse
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 500 {object} ErrorResponse "Server error"
@@ -232,23 +240,27 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 	token, err := extractToken(r)
 	if err != nil {
 		s.log.WithError(err).Debug("failed to extract token")
-		writeErrorResponse(w, http.StatusUnauthorized, "Unauthorized", nil)
+		writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
 		return
 	}
 
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	if err := s.cache.TokenCache().DeleteToken(r.Context(), token); err != nil {
-		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to delete token from cache")
+		s.log.WithError(err).WithFields(logan.F{"user_id": user.ID, "token": maskToken(token)}).Warn("failed to delete token from cache")
+	}
+
+	if err := s.cache.TokenCache().RemoveUserToken(r.Context(), user.ID, token); err != nil {
+		s.log.WithError(err).WithFields(logan.F{"user_id": user.ID, "token": maskToken(token)}).Warn("failed to untrack user token")
 	}
 
 	if err := s.cache.TokenCache().SetTokenBlacklist(r.Context(), token, s.jwtConfig.AccessTokenLifetime); err != nil {
-		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to blacklist token")
+		s.log.WithError(err).WithFields(logan.F{"user_id": user.ID, "token": maskToken(token)}).Warn("failed to blacklist token")
 	}
 
 	response := LogoutResponse{
@@ -257,6 +269,66 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+// handleLogoutAll handles POST /auth/logout-all
+// @Summary Logout from all sessions
+// @Description Invalidate every active JWT token of the authenticated user, including the presented one
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} LogoutResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Router /auth/logout-all [post]
+func (s *Server) handleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	token, err := extractToken(r)
+	if err != nil {
+		s.log.WithError(err).Debug("failed to extract token")
+		writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
+		return
+	}
+
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// The presented token is blacklisted first so it stops working even if it was never tracked
+	if err := s.cache.TokenCache().SetTokenBlacklist(r.Context(), token, s.jwtConfig.AccessTokenLifetime); err != nil {
+		s.log.WithError(err).WithFields(logan.F{"user_id": user.ID, "token": maskToken(token)}).Error("failed to blacklist token")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.TokenCache().DeleteToken(r.Context(), token); err != nil {
+		s.log.WithError(err).WithFields(logan.F{"user_id": user.ID, "token": maskToken(token)}).Warn("failed to delete token from cache")
+	}
+
+	if err := s.revokeUserTokens(r.Context(), user.ID); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to revoke user tokens")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	response := LogoutResponse{
+		Message: "Logged out from all sessions",
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// cacheToken stores an issued token and tracks it among the user's active tokens
+func (s *Server) cacheToken(ctx context.Context, token string, userID uuid.UUID) {
+	if err := s.cache.TokenCache().SetToken(ctx, token, userID, s.jwtConfig.AccessTokenLifetime); err != nil {
+		s.log.WithError(err).WithFields(logan.F{"user_id": userID, "token": maskToken(token)}).Warn("failed to cache token")
+		return
+	}
+
+	if err := s.cache.TokenCache().AddUserToken(ctx, userID, token, s.jwtConfig.AccessTokenLifetime); err != nil {
+		s.log.WithError(err).WithFields(logan.F{"user_id": userID, "token": maskToken(token)}).Warn("failed to track user token")
+	}
+}
+
 func (s *Server) generateToken(userID uuid.UUID) (string, error) {
 	now := time.Now()
 	claims := jwt.RegisteredClaims{
@@ -267,6 +339,68 @@ func (s *Server) generateToken(userID uuid.UUID) (string, error) {
 		ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtConfig.AccessTokenLifetime)),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtConfig.SecretKey))
+	token := jwt.NewWithClaims(s.jwtConfig.signingMethod(), claims)
+	return token.SignedString(s.jwtConfig.signingKey())
+}
+
+// parseToken verifies the signature, issuer, audience and expiry of a token and returns the user ID it was issued to
+func (s *Server) parseToken(tokenString string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return s.jwtConfig.verificationKey(), nil
+	}, jwt.WithValidMethods([]string{s.jwtConfig.signingMethod().Alg()}))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// RegisteredClaims only validates the claims that are present, so the required ones are checked explicitly
+	if !claims.VerifyExpiresAt(time.Now(), true) {
+		return uuid.Nil, jwt.ErrTokenExpired
+	}
+	if !claims.VerifyIssuer(s.jwtConfig.Issuer, true) {
+		return uuid.Nil, jwt.ErrTokenInvalidIssuer
+	}
+	if !claims.VerifyAudience(s.jwtConfig.Audience, true) {
+		return uuid.Nil, jwt.ErrTokenInvalidAudience
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid subject: %v", jwt.ErrTokenInvalidClaims, err)
+	}
+
+	return userID, nil
+}
+
+// tokenMaskVisible is how many characters of a token are kept at either end when it is masked
+const tokenMaskVisible = 4
+
+// maskToken shortens a token to its first and last few characters, so that log entries about
+// the same token can be correlated without the token ever being logged in a usable form.
+// Every token is logged masked, whatever the level. Tokens too short to mask safely are hidden entirely
+func maskToken(token string) string {
+	if len(token) < 4*tokenMaskVisible {
+		return "****"
+	}
+	return token[:tokenMaskVisible] + "..." + token[len(token)-tokenMaskVisible:]
+}
+
+// tokenFailureReason describes why a token failed verification, for debug logging
+func tokenFailureReason(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return "token is malformed"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return "token signature is invalid"
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "token is expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return "token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "token has invalid issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "token has invalid audience"
+	default:
+		return "token is invalid"
+	}
 }
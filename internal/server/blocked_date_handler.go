@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// maxBlockedDateDays caps the length of a single blocked range and of a blocked dates listing
+	maxBlockedDateDays = 366
+
+	// maxBlockedDateReasonLength matches the reason column of the blocked_dates table
+	maxBlockedDateReasonLength = 255
+)
+
+// CreateBlockedDateRequest blocks a single date, or a range of dates when EndDate is set
+type CreateBlockedDateRequest struct {
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// @Summary Get blocked dates
+// @Description List the holidays and private events, overlapping a date range, on which no reservations are accepted.
+// @Description The range defaults to the year starting today
+// @Tags Blocked dates
+// @Security BearerAuth
+// @Produce json
+// @Param from query string false "First included date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last included date (YYYY-MM-DD), at most 366 days after from"
+// @Success 200 {array} types.BlockedDate
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /blocked-dates [get]
+func (s *Server) handleGetBlockedDates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	v := validation.New()
+	from, _ := time.Parse("2006-01-02", s.reservationPolicy.Now().Format("2006-01-02"))
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			v.Add("from", i18n.InvalidDateFormat)
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, maxBlockedDateDays-1)
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			v.Add("to", i18n.InvalidDateFormat)
+		}
+		to = parsed
+	}
+	if !v.HasErrors() {
+		if to.Before(from) {
+			v.Add("to", i18n.ToDateBeforeFrom)
+		} else if to.Sub(from) > maxBlockedDateDays*24*time.Hour {
+			v.Add("to", i18n.DateRangeTooLong, maxBlockedDateDays)
+		}
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	blocked, err := s.db.BlockedDateQ().GetInRange(r.Context(), from, to)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get blocked dates")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, blocked)
+}
+
+// @Summary Block dates
+// @Description Block a date, or a range of dates, for a holiday or a private event (admin only).
+// @Description New reservations and reservations moved onto a blocked date are rejected with date_blocked.
+// @Description Existing reservations on these dates are kept
+// @Tags Blocked dates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateBlockedDateRequest true "Blocked dates"
+// @Success 201 {object} types.BlockedDate
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /blocked-dates [post]
+func (s *Server) handleCreateBlockedDate(w http.ResponseWriter, r *http.Request) {
+	var req CreateBlockedDateRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	blocked, v := validateCreateBlockedDate(req)
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if err := s.db.BlockedDateQ().Create(r.Context(), blocked); err != nil {
+		s.log.WithError(err).Error("failed to create blocked date")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionBlockedDateCreated, blockedDateFields(blocked))
+
+	writeJSONResponse(w, http.StatusCreated, blocked)
+}
+
+// @Summary Unblock dates
+// @Description Remove a blocked date range so that reservations are accepted again (admin only)
+// @Tags Blocked dates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Blocked date ID"
+// @Success 200 {object} DeleteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /blocked-dates/{id} [delete]
+func (s *Server) handleDeleteBlockedDate(w http.ResponseWriter, r *http.Request) {
+	blockedID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid blocked date ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidBlockedDateID, nil)
+		return
+	}
+
+	if err := s.db.BlockedDateQ().Delete(r.Context(), blockedID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.BlockedDateNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to delete blocked date")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionBlockedDateDeleted, logan.F{"blocked_date_id": blockedID})
+
+	writeJSONResponse(w, http.StatusOK, DeleteResponse{
+		Message: "Blocked date deleted successfully",
+	})
+}
+
+// validateCreateBlockedDate builds the blocked range described by req. A missing end date blocks the start date only
+func validateCreateBlockedDate(req CreateBlockedDateRequest) (*types.BlockedDate, *validation.Errors) {
+	v := validation.New()
+	reason := strings.TrimSpace(req.Reason)
+
+	startDate, err := time.Parse("2006-01-02", strings.TrimSpace(req.StartDate))
+	if strings.TrimSpace(req.StartDate) == "" {
+		v.Add("startDate", i18n.StartDateMissing)
+	} else if err != nil {
+		v.Add("startDate", i18n.InvalidDateFormat)
+	}
+	endDate := startDate
+	if raw := strings.TrimSpace(req.EndDate); raw != "" {
+		if endDate, err = time.Parse("2006-01-02", raw); err != nil {
+			v.Add("endDate", i18n.InvalidDateFormat)
+		}
+	}
+	if !v.HasErrors() {
+		if endDate.Before(startDate) {
+			v.Add("endDate", i18n.EndDateBeforeStart)
+		} else if endDate.Sub(startDate) >= maxBlockedDateDays*24*time.Hour {
+			v.Add("endDate", i18n.BlockedRangeTooLong, maxBlockedDateDays)
+		}
+	}
+	if reason == "" {
+		v.Add("reason", i18n.ReasonMissing)
+	} else if len([]rune(reason)) > maxBlockedDateReasonLength {
+		v.Add("reason", i18n.ReasonTooLong, maxBlockedDateReasonLength)
+	}
+	if v.HasErrors() {
+		return nil, v
+	}
+
+	return &types.BlockedDate{StartDate: startDate, EndDate: endDate, Reason: reason}, v
+}
+
+// blockedReason returns why the "YYYY-MM-DD" date is blocked, or an empty string when reservations are accepted on it
+func blockedReason(ctx context.Context, q data.BlockedDateQ, date string) (string, error) {
+	blocked, err := q.GetByDate(ctx, date)
+	if errors.Is(err, data.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return blocked.Reason, nil
+}
+
+// checkBlockedDate responds with a conflict and returns false when the "YYYY-MM-DD" date is blocked
+func (s *Server) checkBlockedDate(w http.ResponseWriter, r *http.Request, date string) bool {
+	reason, err := blockedReason(r.Context(), s.db.BlockedDateQ(), date)
+	if err != nil {
+		s.log.WithError(err).Error("failed to check blocked dates")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return false
+	}
+	if reason != "" {
+		writeErrorResponse(w, r, http.StatusConflict, codeDateBlocked, i18n.DateBlocked, i18n.Details{
+			"date": i18n.Msg(i18n.DateBlockedDetail, date, reason),
+		})
+		return false
+	}
+	return true
+}
+
+// blockedDateFields describes a blocked range in business action logs
+func blockedDateFields(blocked *types.BlockedDate) logan.F {
+	return logan.F{
+		"blocked_date_id": blocked.ID,
+		"start_date":      blocked.StartDate.Format("2006-01-02"),
+		"end_date":        blocked.EndDate.Format("2006-01-02"),
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCreateBlockedDate(t *testing.T) {
+	t.Run("single date", func(t *testing.T) {
+		blocked, v := validateCreateBlockedDate(CreateBlockedDateRequest{StartDate: "2025-12-25", Reason: " Christmas "})
+		require.False(t, v.HasErrors())
+		assert.Equal(t, "2025-12-25", blocked.StartDate.Format("2006-01-02"))
+		assert.Equal(t, "2025-12-25", blocked.EndDate.Format("2006-01-02"))
+		assert.Equal(t, "Christmas", blocked.Reason)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		blocked, v := validateCreateBlockedDate(CreateBlockedDateRequest{StartDate: "2025-12-31", EndDate: "2026-01-02", Reason: "New Year"})
+		require.False(t, v.HasErrors())
+		assert.Equal(t, "2026-01-02", blocked.EndDate.Format("2006-01-02"))
+	})
+
+	tests := []struct {
+		name  string
+		req   CreateBlockedDateRequest
+		field string
+	}{
+		{"missing start date", CreateBlockedDateRequest{Reason: "Holiday"}, "startDate"},
+		{"invalid start date", CreateBlockedDateRequest{StartDate: "25.12.2025", Reason: "Holiday"}, "startDate"},
+		{"invalid end date", CreateBlockedDateRequest{StartDate: "2025-12-25", EndDate: "2025-13-01", Reason: "Holiday"}, "endDate"},
+		{"end before start", CreateBlockedDateRequest{StartDate: "2025-12-25", EndDate: "2025-12-24", Reason: "Holiday"}, "endDate"},
+		{"range too long", CreateBlockedDateRequest{StartDate: "2025-01-01", EndDate: "2026-01-02", Reason: "Renovation"}, "endDate"},
+		{"missing reason", CreateBlockedDateRequest{StartDate: "2025-12-25", Reason: "  "}, "reason"},
+		{"reason too long", CreateBlockedDateRequest{StartDate: "2025-12-25", Reason: strings.Repeat("a", maxBlockedDateReasonLength+1)}, "reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked, v := validateCreateBlockedDate(tt.req)
+			assert.Nil(t, blocked)
+			assert.Contains(t, v.Map(), tt.field)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+const (
+	// confirmationCodeAlphabet leaves out characters that are easily confused when read out loud
+	// or written down (0/O, 1/I/L)
+	confirmationCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	confirmationCodeLength   = 6
+)
+
+// generateConfirmationCode returns a random confirmation code made of confirmationCodeAlphabet characters
+func generateConfirmationCode() (string, error) {
+	max := big.NewInt(int64(len(confirmationCodeAlphabet)))
+	code := make([]byte, confirmationCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = confirmationCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// normalizeConfirmationCode removes surrounding whitespace and upper-cases a code entered by a guest
+func normalizeConfirmationCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// isValidConfirmationCode checks that a normalized code could have been generated by generateConfirmationCode
+func isValidConfirmationCode(code string) bool {
+	if len(code) != confirmationCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(confirmationCodeAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConfirmationCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := generateConfirmationCode()
+		require.NoError(t, err)
+		assert.True(t, isValidConfirmationCode(code), code)
+		seen[code] = true
+	}
+	assert.Greater(t, len(seen), 90)
+}
+
+func TestIsValidConfirmationCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "valid", code: "K7M2QX", want: true},
+		{name: "normalized input", code: normalizeConfirmationCode(" k7m2qx "), want: true},
+		{name: "too short", code: "K7M2Q", want: false},
+		{name: "too long", code: "K7M2QXA", want: false},
+		{name: "ambiguous character", code: "K7M2Q0", want: false},
+		{name: "lower case", code: "k7m2qx", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isValidConfirmationCode(tt.code))
+		})
+	}
+}
@@ -1,17 +1,90 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"net/mail"
 	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
 )
 
-// ErrorResponse represents an error response
+// maxRequestBodySize caps the size of JSON request bodies
+const maxRequestBodySize = 1 << 20
+
+// ErrorResponse represents an error response. Error is meant for humans, Code lets clients
+// branch on the kind of failure and is one of:
+//
+//   - validation_failed: the request is well-formed but some fields are invalid, see Details
+//   - invalid_request: the body or a path or query parameter cannot be parsed
+//   - unauthorized: the bearer token is missing, invalid or revoked
+//   - invalid_credentials: the email or password is wrong
+//   - forbidden: the caller may not perform the action
+//   - not_found: the resource does not exist
+//   - table_unavailable: the requested table is already booked at that time
+//   - duplicate_reservation: the guest already has a reservation close to the requested time
+//   - duplicate_feedback: the reservation has already been rated
+//   - too_many_reservations: the user holds the maximum number of active reservations
+//   - slot_full: the restaurant already seats the maximum number of parties at the requested time
+//   - date_blocked: the restaurant does not accept reservations on the requested date, e.g. a holiday
+//   - edit_conflict: the resource was modified by another request
+//   - request_in_progress: a request with the same idempotency key has not finished yet
+//   - rate_limited: too many attempts, retry after the Retry-After delay
+//   - internal_error: the server failed to handle the request
 type ErrorResponse struct {
 	Error   string            `json:"error"`
+	Code    string            `json:"code"`
 	Details map[string]string `json:"details,omitempty"`
 }
 
+// Error codes reported in ErrorResponse.Code
+const (
+	codeValidationFailed     = "validation_failed"
+	codeInvalidRequest       = "invalid_request"
+	codeUnauthorized         = "unauthorized"
+	codeInvalidCredentials   = "invalid_credentials"
+	codeForbidden            = "forbidden"
+	codeNotFound             = "not_found"
+	codeTableUnavailable     = "table_unavailable"
+	codeDuplicateReservation = "duplicate_reservation"
+	codeDuplicateFeedback    = "duplicate_feedback"
+	codeTooManyReservations  = "too_many_reservations"
+	codeSlotFull             = "slot_full"
+	codeDateBlocked          = "date_blocked"
+	codeEditConflict         = "edit_conflict"
+	codeRequestInProgress    = "request_in_progress"
+	codeRateLimited          = "rate_limited"
+	codeInternal             = "internal_error"
+)
+
+// NullableString is an optional JSON string field that tells an omitted field apart from an explicit null
+type NullableString struct {
+	// Set reports whether the field was present in the JSON object
+	Set bool
+	// Value is nil when the field was null
+	Value *string
+}
+
+// UnmarshalJSON is only called for fields present in the input, including null ones
+func (n *NullableString) UnmarshalJSON(b []byte) error {
+	n.Set = true
+	if string(b) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
 // writeJSONResponse writes a JSON response
 func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -22,32 +95,186 @@ func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{})
 	}
 }
 
-// writeErrorResponse writes an error JSON response
-func writeErrorResponse(w http.ResponseWriter, statusCode int, message string, details map[string]string) {
-	response := ErrorResponse{
-		Error: message,
+// writeJSONResponseWithETag writes a JSON response tagged with a strong ETag derived from the payload.
+// When the request's If-None-Match matches the ETag, 304 Not Modified is written without a body
+func writeJSONResponseWithETag(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	etag := computeETag(payload)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(append(payload, '\n'))
+	return nil
+}
+
+// computeETag returns a quoted strong ETag for the given payload
+func computeETag(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+// The header may list several tags or be "*"; weak tags are compared by their opaque value
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
 	}
-	if details != nil {
-		response.Details = details
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeErrorResponse writes an error JSON response with the message for key
+// in the language the client prefers according to its Accept-Language header
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, code string, key i18n.Key, details i18n.Details) {
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+
+	response := ErrorResponse{
+		Error:   i18n.Translate(lang, key),
+		Code:    code,
+		Details: details.Translate(lang),
 	}
 	writeJSONResponse(w, statusCode, response)
 }
 
-// isValidEmail performs basic email validation
+// requestLanguage returns the supported language the client prefers according to its Accept-Language header
+func requestLanguage(r *http.Request) string {
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// bodyErrorDetails reports a request body that cannot be decoded
+func bodyErrorDetails(err error) i18n.Details {
+	return i18n.Details{"body": i18n.MessageOf(err)}
+}
+
+// decodeJSON strictly decodes a single JSON object from the request body into dst.
+// The body is capped at maxRequestBodySize and unknown fields are rejected.
+// The returned error carries a catalog message meant to be shown to the client
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	return decodeJSONWithLimit(w, r, dst, maxRequestBodySize)
+}
+
+// decodeJSONWithLimit is decodeJSON for endpoints accepting bodies of up to limit bytes
+func decodeJSONWithLimit(w http.ResponseWriter, r *http.Request, dst interface{}, limit int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		var maxBytesErr *http.MaxBytesError
+
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return i18n.Errorf(i18n.BodyTooLarge, maxBytesErr.Limit)
+		case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+			return i18n.Errorf(i18n.BodyMalformed)
+		case errors.As(err, &typeErr):
+			if typeErr.Field != "" {
+				return i18n.Errorf(i18n.BodyInvalidField, typeErr.Field)
+			}
+			return i18n.Errorf(i18n.BodyInvalidValue)
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+			return i18n.Errorf(i18n.BodyUnknownField, field)
+		case errors.Is(err, io.EOF):
+			return i18n.Errorf(i18n.BodyEmpty)
+		default:
+			return i18n.Errorf(i18n.InvalidRequestBody)
+		}
+	}
+
+	if decoder.More() {
+		return i18n.Errorf(i18n.BodyMultipleObjects)
+	}
+
+	return nil
+}
+
+// isValidEmail validates a bare email address (no display name or surrounding whitespace)
+// and checks that its domain is made of well-formed DNS labels
 func isValidEmail(email string) bool {
-	if email == "" {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
 		return false
 	}
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
 		return false
 	}
-	if parts[0] == "" || parts[1] == "" {
+	return isValidEmailDomain(email[at+1:])
+}
+
+// isValidEmailDomain checks that a domain has at least two labels, each 1-63 characters
+// long, consisting of letters, digits and inner hyphens
+func isValidEmailDomain(domain string) bool {
+	if len(domain) > 253 {
 		return false
 	}
-	if !strings.Contains(parts[1], ".") {
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
 		return false
 	}
+
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+			isDigit := c >= '0' && c <= '9'
+			if !isLetter && !isDigit && c != '-' {
+				return false
+			}
+		}
+	}
 	return true
 }
 
+// phoneFormattingReplacer strips the formatting characters commonly used in phone numbers
+var phoneFormattingReplacer = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// normalizePhone removes surrounding whitespace and formatting characters
+// (spaces, dashes, dots and parentheses) from a phone number
+func normalizePhone(phone string) string {
+	return phoneFormattingReplacer.Replace(strings.TrimSpace(phone))
+}
+
+// isValidPhone checks that a normalized phone number follows E.164 rules:
+// a leading + followed by 8 to 15 digits
+func isValidPhone(phone string) bool {
+	if !strings.HasPrefix(phone, "+") {
+		return false
+	}
+	digits := phone[1:]
+	if len(digits) < 8 || len(digits) > 15 {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  bool
+	}{
+		{name: "simple address", email: "john@example.com", want: true},
+		{name: "short address", email: "a@b.c", want: true},
+		{name: "plus addressing", email: "john+booking@example.com", want: true},
+		{name: "dots in local part", email: "john.doe@example.com", want: true},
+		{name: "subdomain", email: "john@mail.example.co.uk", want: true},
+		{name: "hyphenated domain", email: "john@my-restaurant.com", want: true},
+		{name: "empty string", email: "", want: false},
+		{name: "missing at sign", email: "john.example.com", want: false},
+		{name: "multiple at signs", email: "john@doe@example.com", want: false},
+		{name: "missing local part", email: "@example.com", want: false},
+		{name: "missing domain", email: "john@", want: false},
+		{name: "domain without dot", email: "john@localhost", want: false},
+		{name: "space in domain", email: "a@ .com", want: false},
+		{name: "empty domain label", email: "john@example..com", want: false},
+		{name: "leading dot in domain", email: "john@.example.com", want: false},
+		{name: "trailing dot in domain", email: "john@example.com.", want: false},
+		{name: "trailing dot in local part", email: "john.@example.com", want: false},
+		{name: "label starting with hyphen", email: "john@-example.com", want: false},
+		{name: "label ending with hyphen", email: "john@example-.com", want: false},
+		{name: "underscore in domain", email: "john@exa_mple.com", want: false},
+		{name: "leading whitespace", email: " john@example.com", want: false},
+		{name: "trailing whitespace", email: "john@example.com ", want: false},
+		{name: "space in local part", email: "john doe@example.com", want: false},
+		{name: "display name", email: "John <john@example.com>", want: false},
+		{name: "angle brackets only", email: "<john@example.com>", want: false},
+		{name: "plain text", email: "not an email", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isValidEmail(tt.email))
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{name: "already normalized", phone: "+380501234567", want: "+380501234567"},
+		{name: "surrounding whitespace", phone: "  +380501234567\t", want: "+380501234567"},
+		{name: "spaces", phone: "+380 50 123 45 67", want: "+380501234567"},
+		{name: "dashes", phone: "+1-202-555-0143", want: "+12025550143"},
+		{name: "dots", phone: "+44.20.7946.0958", want: "+442079460958"},
+		{name: "parentheses", phone: "+1 (202) 555-0143", want: "+12025550143"},
+		{name: "other characters are kept", phone: "+1/202/555", want: "+1/202/555"},
+		{name: "empty string", phone: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizePhone(tt.phone))
+		})
+	}
+}
+
+func TestIsValidPhone(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  bool
+	}{
+		{name: "shortest number", phone: "+12345678", want: true},
+		{name: "longest number", phone: "+123456789012345", want: true},
+		{name: "ukrainian number", phone: "+380501234567", want: true},
+		{name: "empty string", phone: "", want: false},
+		{name: "plus only", phone: "+", want: false},
+		{name: "missing plus", phone: "380501234567", want: false},
+		{name: "too short", phone: "+1234567", want: false},
+		{name: "too long", phone: "+1234567890123456", want: false},
+		{name: "letters", phone: "+38050123456A", want: false},
+		{name: "second plus", phone: "++380501234567", want: false},
+		{name: "not normalized", phone: "+380 50 123 45 67", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isValidPhone(tt.phone))
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	type payload struct {
+		Name   string `json:"name"`
+		Guests int    `json:"guests"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "valid object", body: `{"name":"John","guests":2}`},
+		{name: "empty body", body: ``, wantErr: "Request body must not be empty"},
+		{name: "malformed JSON", body: `{"name":`, wantErr: "Request body contains malformed JSON"},
+		{name: "syntax error", body: `{"name" "John"}`, wantErr: "Request body contains malformed JSON"},
+		{name: "unknown field", body: `{"nmae":"John"}`, wantErr: `Request body contains unknown field "nmae"`},
+		{name: "wrong type", body: `{"guests":"two"}`, wantErr: `Request body contains an invalid value for field "guests"`},
+		{name: "multiple objects", body: `{"name":"John"}{"name":"Jane"}`, wantErr: "Request body must contain a single JSON object"},
+		{
+			name:    "body too large",
+			body:    `{"name":"` + strings.Repeat("a", maxRequestBodySize) + `"}`,
+			wantErr: fmt.Sprintf("Request body must not be larger than %d bytes", maxRequestBodySize),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var dst payload
+			err := decodeJSON(w, r, &dst)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, payload{Name: "John", Guests: 2}, dst)
+			}
+		})
+	}
+}
+
+func TestWriteJSONResponseWithETag(t *testing.T) {
+	payload := []string{"T1", "T2"}
+
+	first := httptest.NewRecorder()
+	err := writeJSONResponseWithETag(first, httptest.NewRequest(http.MethodGet, "/tables", nil), http.StatusOK, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.JSONEq(t, `["T1","T2"]`, first.Body.String())
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		payload     []string
+		wantStatus  int
+	}{
+		{name: "no validator", ifNoneMatch: "", payload: payload, wantStatus: http.StatusOK},
+		{name: "matching etag", ifNoneMatch: etag, payload: payload, wantStatus: http.StatusNotModified},
+		{name: "weak matching etag", ifNoneMatch: "W/" + etag, payload: payload, wantStatus: http.StatusNotModified},
+		{name: "etag in list", ifNoneMatch: `"stale", ` + etag, payload: payload, wantStatus: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: "*", payload: payload, wantStatus: http.StatusNotModified},
+		{name: "payload changed", ifNoneMatch: etag, payload: []string{"T1"}, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tables", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, writeJSONResponseWithETag(rec, req, http.StatusOK, tt.payload))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get("ETag"))
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteErrorResponse(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	writeErrorResponse(rec, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{"guests": i18n.Msg(i18n.GuestsNotPositive)})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, i18n.English, rec.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{"error":"Validation error","code":"validation_failed","details":{"guests":"Number of guests must be greater than 0"}}`, rec.Body.String())
+
+	r.Header.Set("Accept-Language", "uk-UA,uk;q=0.9,en;q=0.8")
+	rec = httptest.NewRecorder()
+	writeErrorResponse(rec, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+	assert.Equal(t, i18n.Ukrainian, rec.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{"error":"Столик не знайдено","code":"not_found"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	writeErrorResponse(rec, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+		"guests":            i18n.Msg(i18n.PartyTooLarge, 8),
+		"nextAvailableTime": i18n.Value("19:30"),
+	})
+	assert.JSONEq(t, `{"error":"Помилка валідації","code":"validation_failed","details":{"guests":"Компаніям понад 8 осіб потрібно зателефонувати до ресторану","nextAvailableTime":"19:30"}}`, rec.Body.String())
+}
+
+func TestNullableString(t *testing.T) {
+	type payload struct {
+		Notes NullableString `json:"notes"`
+	}
+	value := "Window seat"
+
+	tests := []struct {
+		name string
+		body string
+		want NullableString
+	}{
+		{name: "omitted", body: `{}`, want: NullableString{}},
+		{name: "null", body: `{"notes":null}`, want: NullableString{Set: true}},
+		{name: "value", body: `{"notes":"Window seat"}`, want: NullableString{Set: true, Value: &value}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.body))
+
+			var dst payload
+			assert.NoError(t, decodeJSON(httptest.NewRecorder(), r, &dst))
+			assert.Equal(t, tt.want, dst.Notes)
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"notes":42}`))
+	var dst payload
+	assert.Error(t, decodeJSON(httptest.NewRecorder(), r, &dst))
+}
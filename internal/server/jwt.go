@@ -1,11 +1,52 @@
 package server
 
-import "time"
+import (
+	"crypto/rsa"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// JWTAlgorithmHS256 signs and verifies tokens with a shared secret
+	JWTAlgorithmHS256 = "HS256"
+	// JWTAlgorithmRS256 signs tokens with an RSA private key so that other services
+	// can verify them holding only the public key
+	JWTAlgorithmRS256 = "RS256"
+)
 
 type JWT struct {
-	SecretKey            string        `fig:"secret_key,required"`
-	Issuer               string        `fig:"issuer,required"`
-	Audience             string        `fig:"audience,required"`
-	AccessTokenLifetime  time.Duration `fig:"access_token_lifetime,required"`
-	RefreshTokenLifetime time.Duration `fig:"refresh_token_lifetime,required"`
+	// Algorithm is either JWTAlgorithmHS256 or JWTAlgorithmRS256. Empty means HS256
+	Algorithm            string
+	SecretKey            string
+	PrivateKey           *rsa.PrivateKey
+	PublicKey            *rsa.PublicKey
+	Issuer               string
+	Audience             string
+	AccessTokenLifetime  time.Duration
+	RefreshTokenLifetime time.Duration
+}
+
+// signingMethod returns the method tokens are signed with
+func (j JWT) signingMethod() jwt.SigningMethod {
+	if j.Algorithm == JWTAlgorithmRS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodHS256
+}
+
+// signingKey returns the key tokens are signed with
+func (j JWT) signingKey() interface{} {
+	if j.Algorithm == JWTAlgorithmRS256 {
+		return j.PrivateKey
+	}
+	return []byte(j.SecretKey)
+}
+
+// verificationKey returns the key token signatures are verified with
+func (j JWT) verificationKey() interface{} {
+	if j.Algorithm == JWTAlgorithmRS256 {
+		return j.PublicKey
+	}
+	return []byte(j.SecretKey)
 }
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"gitlab.com/distributed_lab/logan/v3"
 )
@@ -49,42 +52,55 @@ func (s *Server) userMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		token, err := extractToken(r)
 		if err != nil {
 			s.log.WithError(err).Debug("failed to extract token")
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
 			return
 		}
 
-		// Check if token is blacklisted
-		isBlacklisted, err := s.cache.TokenCache().IsTokenBlacklisted(r.Context(), token)
+		// Verify the token itself before trusting anything stored for it
+		userID, err := s.parseToken(token)
 		if err != nil {
-			s.log.WithError(err).Error("failed to check token blacklist")
-			http.Error(w, `{"error":"Internal server error"}`, http.StatusInternalServerError)
+			s.log.WithError(err).Debug(tokenFailureReason(err))
+			writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
 			return
 		}
+
+		// Check if token is blacklisted. The cache already retries transient failures, so an
+		// error here means Redis is down; fail open rather than taking the whole API offline
+		isBlacklisted, err := s.cache.TokenCache().IsTokenBlacklisted(r.Context(), token)
+		if err != nil {
+			s.log.WithError(err).WithFields(logan.F{"user_id": userID, "token": maskToken(token)}).Error("token blacklist unavailable, accepting verified token without revocation check")
+		}
 		if isBlacklisted {
-			s.log.Debug("token is blacklisted")
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			s.log.WithFields(logan.F{"user_id": userID, "token": maskToken(token)}).Debug("token is blacklisted")
+			writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
 			return
 		}
 
-		// Get user ID from token cache
-		userID, err := s.cache.TokenCache().GetUserIDByToken(r.Context(), token)
+		// A cached token must belong to the user it was issued to. A missing entry
+		// (e.g. after a cache flush) is not fatal since the token has been verified
+		cachedUserID, err := s.cache.TokenCache().GetUserIDByToken(r.Context(), token)
 		if err != nil {
-			s.log.WithError(err).Debug("failed to get user ID from token")
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			s.log.WithError(err).WithField("token", maskToken(token)).Debug("token not found in cache, relying on verified claims")
+		} else if cachedUserID != userID {
+			s.log.WithFields(logan.F{
+				"user_id":        userID,
+				"cached_user_id": cachedUserID,
+				"token":          maskToken(token),
+			}).Debug("token subject does not match cached user")
+			writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
 			return
 		}
 
 		// Get user from database
 		user, err := s.db.UserQ().GetByID(r.Context(), userID)
 		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				s.log.WithField("user_id", userID).Warn("user not found")
+				writeErrorResponse(w, r, http.StatusUnauthorized, codeUnauthorized, i18n.Unauthorized, nil)
+				return
+			}
 			s.log.WithError(err).Error("failed to get user from database")
-			http.Error(w, `{"error":"Internal server error"}`, http.StatusInternalServerError)
-			return
-		}
-
-		if user == nil {
-			s.log.WithField("user_id", userID).Warn("user not found")
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 			return
 		}
 
@@ -100,7 +116,7 @@ func (s *Server) adminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		user, err := GetUserFromContext(r)
 		if err != nil {
 			s.log.WithError(err).Error("failed to get user from context in admin middleware")
-			http.Error(w, `{"error":"Internal server error"}`, http.StatusInternalServerError)
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 			return
 		}
 
@@ -109,7 +125,7 @@ func (s *Server) adminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 				"user_id": user.ID,
 				"role":    user.Role,
 			}).Debug("non-admin user attempted to access admin endpoint")
-			http.Error(w, `{"error":"Forbidden"}`, http.StatusForbidden)
+			writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 			return
 		}
 
@@ -121,7 +137,8 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key, If-None-Match, X-Restaurant")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		// Preflight request
@@ -133,3 +150,36 @@ func corsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// metricsMiddleware records the count and latency of requests served by the wrapped mux,
+// labeled by the matched route pattern and the response status
+func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		// The mux sets the matched pattern on the request, e.g. "GET /tables/{id}"
+		route := "unmatched"
+		if r.Pattern != "" {
+			route = r.Pattern
+			if _, path, ok := strings.Cut(r.Pattern, " "); ok {
+				route = path
+			}
+		}
+
+		s.metrics.ObserveHTTPRequest(r.Method, route, recorder.status, time.Since(start))
+	})
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestaurantMiddlewareDefaultsToDefaultRestaurant(t *testing.T) {
+	s := &Server{}
+
+	var got uuid.UUID
+	handler := s.restaurantMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = tenant.RestaurantFromContext(r.Context())
+	}))
+
+	// Without the header the restaurant is not looked up, so no database is needed
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tables", nil))
+
+	assert.Equal(t, types.DefaultRestaurantID, got)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	s := &Server{metrics: metrics.New("test")}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tables/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := s.metricsMiddleware(mux)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "matched route is labeled by pattern",
+			path: "/tables/42",
+			want: `test_http_requests_total{method="GET",route="/tables/{id}",status="404"} 1`,
+		},
+		{
+			name: "unknown path is labeled as unmatched",
+			path: "/unknown",
+			want: `test_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			rec := httptest.NewRecorder()
+			s.metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			body, err := io.ReadAll(rec.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tt.want)
+		})
+	}
+}
+
+func TestParseToken(t *testing.T) {
+	cfg := JWT{
+		SecretKey:           "secret",
+		Issuer:              "booking",
+		Audience:            "booking-clients",
+		AccessTokenLifetime: time.Hour,
+	}
+	s := &Server{jwtConfig: cfg}
+	userID := uuid.New()
+
+	sign := func(claims jwt.RegisteredClaims, method jwt.SigningMethod, key interface{}) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+	claims := func(modify func(*jwt.RegisteredClaims)) jwt.RegisteredClaims {
+		c := jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			Issuer:    cfg.Issuer,
+			Audience:  []string{cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+		if modify != nil {
+			modify(&c)
+		}
+		return c
+	}
+	secret := []byte(cfg.SecretKey)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid token",
+			token: sign(claims(nil), jwt.SigningMethodHS256, secret),
+		},
+		{
+			name:    "malformed token",
+			token:   "not-a-jwt",
+			wantErr: jwt.ErrTokenMalformed,
+		},
+		{
+			name:    "wrong secret",
+			token:   sign(claims(nil), jwt.SigningMethodHS256, []byte("other")),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "unexpected signing method",
+			token:   sign(claims(nil), jwt.SigningMethodHS512, secret),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "expired token",
+			token:   sign(claims(func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) }), jwt.SigningMethodHS256, secret),
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name:    "missing expiry",
+			token:   sign(claims(func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil }), jwt.SigningMethodHS256, secret),
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(claims(func(c *jwt.RegisteredClaims) { c.Issuer = "someone-else" }), jwt.SigningMethodHS256, secret),
+			wantErr: jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name:    "wrong audience",
+			token:   sign(claims(func(c *jwt.RegisteredClaims) { c.Audience = []string{"other"} }), jwt.SigningMethodHS256, secret),
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:    "invalid subject",
+			token:   sign(claims(func(c *jwt.RegisteredClaims) { c.Subject = "nobody" }), jwt.SigningMethodHS256, secret),
+			wantErr: jwt.ErrTokenInvalidClaims,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.parseToken(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, userID, got)
+		})
+	}
+}
+
+func TestParseToken_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cfg := JWT{
+		Algorithm:           JWTAlgorithmRS256,
+		PrivateKey:          key,
+		PublicKey:           &key.PublicKey,
+		Issuer:              "booking",
+		Audience:            "booking-clients",
+		AccessTokenLifetime: time.Hour,
+	}
+	s := &Server{jwtConfig: cfg}
+	userID := uuid.New()
+
+	claims := jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		Issuer:    cfg.Issuer,
+		Audience:  []string{cfg.Audience},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	sign := func(method jwt.SigningMethod, key interface{}) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)})
+
+	generated, err := s.generateToken(userID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "generated token",
+			token: generated,
+		},
+		{
+			name:    "signed with another key",
+			token:   sign(jwt.SigningMethodRS256, otherKey),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "HS256 token keyed with the public key",
+			token:   sign(jwt.SigningMethodHS256, publicPEM),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.parseToken(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, userID, got)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"unicode"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+)
+
+// DefaultPasswordMinLength is the minimum password length used when the policy does not set one
+const DefaultPasswordMinLength = 6
+
+// PasswordPolicy describes the rules a new password has to satisfy
+type PasswordPolicy struct {
+	MinLength      int  `fig:"min_length"`
+	RequireDigit   bool `fig:"require_digit"`
+	RequireUpper   bool `fig:"require_upper"`
+	RequireSpecial bool `fig:"require_special"`
+}
+
+// Validate checks the password against the policy and returns a validation
+// detail for every rule it fails, keyed by the rule name
+func (p PasswordPolicy) Validate(password string) i18n.Details {
+	failed := make(i18n.Details)
+
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = DefaultPasswordMinLength
+	}
+
+	if len([]rune(password)) < minLength {
+		failed["password.minLength"] = i18n.Msg(i18n.PasswordTooShort, minLength)
+	}
+
+	var hasDigit, hasUpper, hasSpecial bool
+	for _, c := range password {
+		switch {
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsUpper(c):
+			hasUpper = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireDigit && !hasDigit {
+		failed["password.requireDigit"] = i18n.Msg(i18n.PasswordNeedsDigit)
+	}
+	if p.RequireUpper && !hasUpper {
+		failed["password.requireUpper"] = i18n.Msg(i18n.PasswordNeedsUpper)
+	}
+	if p.RequireSpecial && !hasSpecial {
+		failed["password.requireSpecial"] = i18n.Msg(i18n.PasswordNeedsSpecial)
+	}
+
+	return failed
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 10, RequireDigit: true, RequireUpper: true, RequireSpecial: true}
+
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		want     []string
+	}{
+		{name: "default policy accepts six characters", policy: PasswordPolicy{}, password: "secret", want: nil},
+		{name: "default policy rejects short password", policy: PasswordPolicy{}, password: "short", want: []string{"password.minLength"}},
+		{name: "strict policy accepts compliant password", policy: strict, password: "Str0ng!Pass", want: nil},
+		{name: "strict policy reports every failed rule", policy: strict, password: "weak", want: []string{"password.minLength", "password.requireDigit", "password.requireUpper", "password.requireSpecial"}},
+		{name: "strict policy reports missing special character", policy: strict, password: "Str0ngPassword", want: []string{"password.requireSpecial"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Validate(tt.password)
+
+			rules := make([]string, 0, len(got))
+			for rule := range got {
+				rules = append(rules, rule)
+			}
+			assert.ElementsMatch(t, tt.want, rules)
+		})
+	}
+}
@@ -1,58 +1,365 @@
 package server
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	userStatsCacheExpiration   = 5 * time.Minute
+	yearlyStatsCacheExpiration = 5 * time.Minute
+	// todaySnapshotCacheExpiration keeps the front desk view close to real time
+	todaySnapshotCacheExpiration = 30 * time.Second
+
+	// defaultMonthlyReportsLimit is the number of most recent months returned when the limit query parameter is missing
+	defaultMonthlyReportsLimit = 24
+	// maxMonthlyReportsLimit caps the number of months returned by the monthly reports list
+	maxMonthlyReportsLimit = 120
+	// maxWeeklyReportsWeeks caps the span of the weekly reports range
+	maxWeeklyReportsWeeks = 53
 )
 
 // handleGetMonthlyReports handles GET /reports/monthly
 // @Summary Get monthly statistics list
-// @Description Returns aggregated statistics for all months
+// @Description Returns aggregated statistics for the most recent months, optionally bounded by a month range
 // @Tags Reports
+// @Security BearerAuth
 // @Produce json
+// @Param from query string false "First included month (YYYY-MM)"
+// @Param to query string false "Last included month (YYYY-MM)"
+// @Param limit query int false "Number of most recent months (default 24, max 120)"
 // @Success 200 {array} types.MonthlyStats
+// @Failure 400 {object} ErrorResponse "Invalid month range"
 // @Failure 500 {object} ErrorResponse "Server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin role required"
 // @Router /reports/monthly [get]
 func (s *Server) handleGetMonthlyReports(w http.ResponseWriter, r *http.Request) {
-	stats, err := s.db.ReportsQ().GetMonthlyStatsList(r.Context())
+	filters := &types.MonthlyStatsFilters{Limit: defaultMonthlyReportsLimit}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = min(l, maxMonthlyReportsLimit)
+		}
+	}
+
+	validationErrors := make(i18n.Details)
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		if from, err := time.Parse("2006-01", fromStr); err == nil {
+			filters.From = &from
+		} else {
+			validationErrors["from"] = i18n.Msg(i18n.InvalidMonthFormat)
+		}
+	}
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		if to, err := time.Parse("2006-01", toStr); err == nil {
+			filters.To = &to
+		} else {
+			validationErrors["to"] = i18n.Msg(i18n.InvalidMonthFormat)
+		}
+	}
+	if filters.From != nil && filters.To != nil && filters.From.After(*filters.To) {
+		validationErrors["from"] = i18n.Msg(i18n.FromMonthAfterTo)
+	}
+	if len(validationErrors) > 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
+		return
+	}
+
+	stats, err := s.db.ReportsQ().GetMonthlyStatsList(r.Context(), filters)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get monthly reports")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// handleGetYearlyReports handles GET /reports/yearly
+// @Summary Get yearly statistics
+// @Description Returns aggregated statistics for every year
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} types.YearlyStats
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin role required"
+// @Router /reports/yearly [get]
+func (s *Server) handleGetYearlyReports(w http.ResponseWriter, r *http.Request) {
+	if stats, err := s.cache.ReportCache().GetYearlyStats(r.Context()); err == nil {
+		writeJSONResponse(w, http.StatusOK, stats)
+		return
+	}
+
+	stats, err := s.db.ReportsQ().GetYearlyStats(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get yearly reports")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.ReportCache().SetYearlyStats(r.Context(), stats, yearlyStatsCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache yearly stats")
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// handleGetWeeklyReports handles GET /reports/weekly
+// @Summary Get weekly statistics
+// @Description Returns statistics aggregated per ISO week for the given date range (YYYY-MM-DD, inclusive, at most 53 weeks)
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} types.WeeklyStats
+// @Failure 400 {object} ErrorResponse "Validation error"
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin role required"
+// @Router /reports/weekly [get]
+func (s *Server) handleGetWeeklyReports(w http.ResponseWriter, r *http.Request) {
+	validationErrors := make(i18n.Details)
+
+	fromStr := r.URL.Query().Get("from")
+	dateFrom, err := time.Parse("2006-01-02", fromStr)
+	if fromStr == "" {
+		validationErrors["from"] = i18n.Msg(i18n.StartDateMissing)
+	} else if err != nil {
+		validationErrors["from"] = i18n.Msg(i18n.InvalidDateFormat)
+	}
+
+	toStr := r.URL.Query().Get("to")
+	dateTo, err := time.Parse("2006-01-02", toStr)
+	if toStr == "" {
+		validationErrors["to"] = i18n.Msg(i18n.EndDateMissing)
+	} else if err != nil {
+		validationErrors["to"] = i18n.Msg(i18n.InvalidDateFormat)
+	}
+
+	if len(validationErrors) == 0 {
+		if dateTo.Before(dateFrom) {
+			validationErrors["to"] = i18n.Msg(i18n.EndDateBeforeStart)
+		} else if dateTo.After(dateFrom.AddDate(0, 0, 7*maxWeeklyReportsWeeks-1)) {
+			validationErrors["to"] = i18n.Msg(i18n.DateRangeTooLongWeeks, maxWeeklyReportsWeeks)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
+		return
+	}
+
+	stats, err := s.db.ReportsQ().GetWeeklyStats(r.Context(), dateFrom, dateTo)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get weekly reports")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	writeJSONResponse(w, http.StatusOK, stats)
 }
 
+// handleGetTodayReport handles GET /reports/today
+// @Summary Get today's reservation snapshot
+// @Description Returns live reservation counts by status and expected covers for the current date in the server timezone
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} types.TodaySnapshot
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin role required"
+// @Router /reports/today [get]
+func (s *Server) handleGetTodayReport(w http.ResponseWriter, r *http.Request) {
+	if snapshot, err := s.cache.ReportCache().GetTodaySnapshot(r.Context()); err == nil {
+		writeJSONResponse(w, http.StatusOK, snapshot)
+		return
+	}
+
+	snapshot, err := s.db.ReportsQ().GetTodaySnapshot(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get today snapshot")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.ReportCache().SetTodaySnapshot(r.Context(), snapshot, todaySnapshotCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache today snapshot")
+	}
+
+	writeJSONResponse(w, http.StatusOK, snapshot)
+}
+
 // handleGetMonthlyReport handles GET /reports/monthly/{month}
 // @Summary Get detailed monthly report
 // @Description Returns detailed statistics for a specific month (YYYY-MM)
 // @Tags Reports
+// @Security BearerAuth
 // @Produce json
 // @Param month path string true "Month in format YYYY-MM"
 // @Success 200 {object} types.DetailedMonthlyStats
 // @Failure 400 {object} ErrorResponse "Invalid month format"
 // @Failure 404 {object} ErrorResponse "Statistics not found"
 // @Failure 500 {object} ErrorResponse "Server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin role required"
 // @Router /reports/monthly/{month} [get]
 func (s *Server) handleGetMonthlyReport(w http.ResponseWriter, r *http.Request) {
 	month := r.PathValue("month")
 
 	if len(month) != 7 || month[4] != '-' {
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid month format (expected YYYY-MM)", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidMonthFormat, nil)
 		return
 	}
 
 	stats, err := s.db.ReportsQ().GetDetailedMonthlyStats(r.Context(), month)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.MonthlyStatsNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get monthly report")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// handleGetUserReport handles GET /reports/user/{userId}
+// @Summary Get user statistics
+// @Description Returns reservation statistics for a specific user (only self or admin)
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} types.UserStats
+// @Failure 400 {object} ErrorResponse "Invalid user ID format"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Router /reports/user/{userId} [get]
+func (s *Server) handleGetUserReport(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.PathValue("userId")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userIDStr).Debug("invalid user ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
+		return
+	}
+
+	authenticatedUser, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get authenticated user")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if authenticatedUser.ID != userID && authenticatedUser.Role != adminRole {
+		s.log.WithFields(logan.F{
+			"authenticated_user_id": authenticatedUser.ID,
+			"requested_user_id":     userID,
+		}).Debug("unauthorized user report access attempt")
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
-	if stats == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Statistics for this month not found", nil)
+	if stats, err := s.cache.ReportCache().GetUserStats(r.Context(), userID); err == nil {
+		writeJSONResponse(w, http.StatusOK, stats)
 		return
 	}
 
+	_, err = s.db.UserQ().GetByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user from database")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	stats, err := s.db.ReportsQ().GetUserStats(r.Context(), userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user report")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.ReportCache().SetUserStats(r.Context(), userID, stats, userStatsCacheExpiration); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to cache user report")
+	}
+
 	writeJSONResponse(w, http.StatusOK, stats)
 }
+
+// handleGetOccupancyReport handles GET /reports/occupancy
+// @Summary Get table occupancy report
+// @Description Returns per-table occupancy for the given date range (YYYY-MM-DD, inclusive).
+// @Description Rates are relative to the slots a table can be booked in over the range, following business hours,
+// @Description special hours and the slot granularity
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} types.TableOccupancy
+// @Failure 400 {object} ErrorResponse "Validation error"
+// @Failure 500 {object} ErrorResponse "Server error"
+// @Router /reports/occupancy [get]
+func (s *Server) handleGetOccupancyReport(w http.ResponseWriter, r *http.Request) {
+	validationErrors := make(i18n.Details)
+
+	fromStr := r.URL.Query().Get("from")
+	dateFrom, err := time.Parse("2006-01-02", fromStr)
+	if fromStr == "" {
+		validationErrors["from"] = i18n.Msg(i18n.StartDateMissing)
+	} else if err != nil {
+		validationErrors["from"] = i18n.Msg(i18n.InvalidDateFormat)
+	}
+
+	toStr := r.URL.Query().Get("to")
+	dateTo, err := time.Parse("2006-01-02", toStr)
+	if toStr == "" {
+		validationErrors["to"] = i18n.Msg(i18n.EndDateMissing)
+	} else if err != nil {
+		validationErrors["to"] = i18n.Msg(i18n.InvalidDateFormat)
+	}
+
+	if len(validationErrors) == 0 && dateTo.Before(dateFrom) {
+		validationErrors["to"] = i18n.Msg(i18n.EndDateBeforeStart)
+	}
+
+	if len(validationErrors) > 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
+		return
+	}
+
+	slots, err := s.bookableSlots(r.Context(), dateFrom, dateTo)
+	if err != nil {
+		s.log.WithError(err).Error("failed to count bookable slots")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	occupancy, err := s.db.ReportsQ().GetTableOccupancy(r.Context(), dateFrom, dateTo, slots)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get occupancy report")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, occupancy)
+}
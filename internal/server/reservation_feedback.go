@@ -0,0 +1,199 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// minFeedbackRating and maxFeedbackRating bound the rating of a reservation
+	minFeedbackRating = 1
+	maxFeedbackRating = 5
+
+	// maxFeedbackCommentLength caps the optional feedback comment
+	maxFeedbackCommentLength = 1000
+)
+
+// CreateFeedbackRequest rates a completed reservation
+type CreateFeedbackRequest struct {
+	Rating  int     `json:"rating"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// @Summary Leave reservation feedback
+// @Description Rate a completed reservation from 1 to 5 with an optional comment, once per reservation (only owner)
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param body body CreateFeedbackRequest true "Rating and comment"
+// @Success 201 {object} types.Feedback
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/feedback [post]
+func (s *Server) handleCreateFeedback(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	var req CreateFeedbackRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	feedback, v := validateCreateFeedback(req)
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// Feedback speaks for the guest, so staff cannot leave it on their behalf
+	if !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	if reservation.Status != "completed" {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"status": i18n.Msg(i18n.FeedbackNotCompleted, reservation.Status),
+		})
+		return
+	}
+
+	feedback.ReservationID = reservationID
+	if err := s.db.FeedbackQ().Create(r.Context(), feedback); err != nil {
+		if errors.Is(err, data.ErrConflict) {
+			writeErrorResponse(w, r, http.StatusConflict, codeDuplicateFeedback, i18n.DuplicateFeedback, nil)
+			return
+		}
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to create feedback")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.ReportCache().InvalidateMonthlyStats(r.Context(), reservation.Date.Format("2006-01")); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate monthly stats cache")
+	}
+	s.logAction(r, actionFeedbackCreated, logan.F{"reservation_id": reservationID, "rating": feedback.Rating})
+
+	writeJSONResponse(w, http.StatusCreated, feedback)
+}
+
+// @Summary Get reservation feedback
+// @Description Get the rating left on a reservation (only owner or admin)
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} types.Feedback
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/feedback [get]
+func (s *Server) handleGetFeedback(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	feedback, err := s.db.FeedbackQ().GetByReservationID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.FeedbackNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get feedback")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, feedback)
+}
+
+// validateCreateFeedback builds the feedback described by req. A blank comment is dropped
+func validateCreateFeedback(req CreateFeedbackRequest) (*types.Feedback, *validation.Errors) {
+	v := validation.New()
+	if req.Rating < minFeedbackRating || req.Rating > maxFeedbackRating {
+		v.Add("rating", i18n.RatingOutOfRange, minFeedbackRating, maxFeedbackRating)
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if trimmed := strings.TrimSpace(*req.Comment); trimmed != "" {
+			comment = &trimmed
+		}
+	}
+	if comment != nil && len([]rune(*comment)) > maxFeedbackCommentLength {
+		v.Add("comment", i18n.CommentTooLong, maxFeedbackCommentLength)
+	}
+	if v.HasErrors() {
+		return nil, v
+	}
+
+	return &types.Feedback{Rating: req.Rating, Comment: comment}, v
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCreateFeedback(t *testing.T) {
+	comment := func(s string) *string { return &s }
+
+	t.Run("rating with comment", func(t *testing.T) {
+		feedback, v := validateCreateFeedback(CreateFeedbackRequest{Rating: 5, Comment: comment(" Lovely evening ")})
+		require.False(t, v.HasErrors())
+		assert.Equal(t, 5, feedback.Rating)
+		require.NotNil(t, feedback.Comment)
+		assert.Equal(t, "Lovely evening", *feedback.Comment)
+	})
+
+	t.Run("blank comment is dropped", func(t *testing.T) {
+		feedback, v := validateCreateFeedback(CreateFeedbackRequest{Rating: 1, Comment: comment("  ")})
+		require.False(t, v.HasErrors())
+		assert.Nil(t, feedback.Comment)
+	})
+
+	tests := []struct {
+		name  string
+		req   CreateFeedbackRequest
+		field string
+	}{
+		{"missing rating", CreateFeedbackRequest{}, "rating"},
+		{"rating above 5", CreateFeedbackRequest{Rating: 6}, "rating"},
+		{"negative rating", CreateFeedbackRequest{Rating: -1}, "rating"},
+		{"comment too long", CreateFeedbackRequest{Rating: 4, Comment: comment(strings.Repeat("a", maxFeedbackCommentLength+1))}, "comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedback, v := validateCreateFeedback(tt.req)
+			assert.Nil(t, feedback)
+			assert.Contains(t, v.Map(), tt.field)
+		})
+	}
+}
@@ -1,45 +1,149 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/pdf"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/EduardMikhrin/university-booking-project/internal/webhook"
 	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
 )
 
+const (
+	notificationTimeout = 30 * time.Second
+
+	// idempotencyKeyHeader lets clients safely retry reservation creation
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotencyKeyExpiration = 24 * time.Hour
+	maxIdempotencyKeyLength  = 255
+
+	// maxConfirmationCodeAttempts bounds how often a new code is drawn when a generated one is already taken
+	maxConfirmationCodeAttempts = 3
+
+	// lookupRateLimit is how many guest lookups a client may make per lookupRateWindow,
+	// which keeps confirmation codes from being enumerated
+	lookupRateLimit  = 10
+	lookupRateWindow = 15 * time.Minute
+
+	// resendConfirmationLimit is how many times the confirmation of a reservation may be resent
+	// per resendConfirmationWindow, which keeps guests' inboxes from being flooded
+	resendConfirmationLimit  = 3
+	resendConfirmationWindow = time.Hour
+
+	// maxBulkStatusUpdateSize limits how many reservations can be updated in a single bulk request
+	maxBulkStatusUpdateSize = 100
+
+	bulkResultSucceeded = "succeeded"
+	bulkResultFailed    = "failed"
+	// bulkResultDuplicate marks an ID listed more than once. The reservation is updated once,
+	// under the result of its first occurrence
+	bulkResultDuplicate = "duplicate"
+
+	// maxStaffNotesLength limits the staff notes of a reservation, in characters
+	maxStaffNotesLength = 1000
+
+	// reservationListCacheExpiration is short because "upcoming" and "past" listings shift with the clock
+	reservationListCacheExpiration = time.Minute
+
+	// upcomingCountCacheExpiration is short for the same reason, as reservations stop being upcoming once they start
+	upcomingCountCacheExpiration = time.Minute
+)
+
+// UpcomingCountResponse is the number of upcoming reservations of the authenticated user
+type UpcomingCountResponse struct {
+	Upcoming int `json:"upcoming"`
+}
+
 type CreateReservationRequest struct {
-	GuestName       string  `json:"guestName"`
-	GuestPhone      string  `json:"guestPhone"`
-	GuestEmail      string  `json:"guestEmail"`
-	Date            string  `json:"date"`
-	Time            string  `json:"time"`
-	Guests          int     `json:"guests"`
-	TableNumber     string  `json:"tableNumber"`
-	SpecialRequests *string `json:"specialRequests,omitempty"`
+	GuestName       string   `json:"guestName"`
+	GuestPhone      string   `json:"guestPhone"`
+	GuestEmail      string   `json:"guestEmail"`
+	Date            string   `json:"date"`
+	Time            string   `json:"time"`
+	Guests          int      `json:"guests"`
+	TableNumber     string   `json:"tableNumber"`
+	TableNumbers    []string `json:"tableNumbers,omitempty"`
+	SpecialRequests *string  `json:"specialRequests,omitempty"`
+}
+
+// RebookReservationRequest is the date an existing reservation is copied onto
+type RebookReservationRequest struct {
+	Date string `json:"date"`
 }
 
 type UpdateReservationRequest struct {
-	GuestName       *string `json:"guestName,omitempty"`
-	GuestPhone      *string `json:"guestPhone,omitempty"`
-	GuestEmail      *string `json:"guestEmail,omitempty"`
-	Date            *string `json:"date,omitempty"`
-	Time            *string `json:"time,omitempty"`
-	Guests          *int    `json:"guests,omitempty"`
-	TableNumber     *string `json:"tableNumber,omitempty"`
-	SpecialRequests *string `json:"specialRequests,omitempty"`
+	GuestName    *string  `json:"guestName,omitempty"`
+	GuestPhone   *string  `json:"guestPhone,omitempty"`
+	GuestEmail   *string  `json:"guestEmail,omitempty"`
+	Date         *string  `json:"date,omitempty"`
+	Time         *string  `json:"time,omitempty"`
+	Guests       *int     `json:"guests,omitempty"`
+	TableNumber  *string  `json:"tableNumber,omitempty"`
+	TableNumbers []string `json:"tableNumbers,omitempty"`
+	// SpecialRequests replaces the special requests; null or a blank string clears them
+	SpecialRequests NullableString `json:"specialRequests" swaggertype:"string"`
+	// UpdatedAt is the last modification time known to the client. When it no longer
+	// matches the stored reservation, the update is rejected with 409 Conflict
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
 type UpdateReservationStatusRequest struct {
 	Status string `json:"status"`
 }
 
+// UpdateReservationNotesRequest replaces the staff notes of a reservation; null or a blank string clears them
+type UpdateReservationNotesRequest struct {
+	Notes *string `json:"notes"`
+}
+
+type BulkUpdateReservationStatusRequest struct {
+	IDs    []string `json:"ids"`
+	Status string   `json:"status"`
+}
+
+type BulkStatusResult struct {
+	ID     string `json:"id"`
+	Result string `json:"result"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type BulkUpdateReservationStatusResponse struct {
+	Results []BulkStatusResult `json:"results"`
+}
+
 type DeleteResponse struct {
 	Message string `json:"message"`
 }
 
+type ResendConfirmationResponse struct {
+	Message string `json:"message"`
+}
+
+// ReservationLookupResponse holds the reservation details shown to a guest looking it up by confirmation code
+type ReservationLookupResponse struct {
+	ConfirmationCode string `json:"confirmationCode"`
+	GuestName        string `json:"guestName"`
+	Date             string `json:"date"`
+	Time             string `json:"time"`
+	Guests           int    `json:"guests"`
+	Status           string `json:"status"`
+}
+
 // @Summary Get reservations
 // @Description Get reservations for current user (admin – all reservations)
 // @Tags Reservations
@@ -47,7 +151,12 @@ type DeleteResponse struct {
 // @Produce json
 // @Param status query string false "Filter by status"
 // @Param date query string false "Filter by date (YYYY-MM-DD)"
-// @Param search query string false "Search"
+// @Param search query string false "Search guest name, phone and email, table number, confirmation code and date (YYYY-MM-DD, partial matches)"
+// @Param tableNumber query string false "Filter by table number"
+// @Param minGuests query int false "Filter by minimum number of guests"
+// @Param guests query int false "Filter by exact number of guests"
+// @Param sort query string false "Sort order" Enums(date_asc, date_desc, created_asc, created_desc, guests_asc, guests_desc)
+// @Param when query string false "Time frame relative to now (default all)" Enums(upcoming, past, all)
 // @Success 200 {array} types.Reservation
 // @Failure 500 {object} ErrorResponse
 // @Router /reservations [get]
@@ -55,36 +164,105 @@ func (s *Server) handleGetReservations(w http.ResponseWriter, r *http.Request) {
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	filters := &types.ReservationFilters{}
-	if status := r.URL.Query().Get("status"); status != "" {
-		filters.Status = &status
-	}
-	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
-		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-			filters.Date = &date
-		}
-	}
-	if search := r.URL.Query().Get("search"); search != "" {
-		filters.Search = &search
-	}
+	filters := reservationFiltersFromQuery(r)
 
 	var userID *uuid.UUID
 	if user.Role != adminRole {
 		userID = &user.ID
 	}
 
+	cacheKey := buildReservationListKey(userID, filters)
+	if reservations, err := s.cache.ReservationCache().GetReservationList(r.Context(), cacheKey); err == nil {
+		writeJSONResponse(w, http.StatusOK, sanitizeReservationsForUser(reservations, user))
+		return
+	}
+
 	reservations, err := s.db.ReservationQ().GetAll(r.Context(), userID, filters)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get reservations")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, reservations)
+	if err := s.cache.ReservationCache().SetReservationList(r.Context(), cacheKey, reservations, reservationListCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache reservation list")
+	}
+
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationsForUser(reservations, user))
+}
+
+// @Summary Get my reservations
+// @Description Get reservations of the authenticated user
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Filter by status"
+// @Param date query string false "Filter by date (YYYY-MM-DD)"
+// @Param search query string false "Search guest name, phone and email, table number, confirmation code and date (YYYY-MM-DD, partial matches)"
+// @Param tableNumber query string false "Filter by table number"
+// @Param minGuests query int false "Filter by minimum number of guests"
+// @Param guests query int false "Filter by exact number of guests"
+// @Param sort query string false "Sort order" Enums(date_asc, date_desc, created_asc, created_desc, guests_asc, guests_desc)
+// @Param when query string false "Time frame relative to now (default all)" Enums(upcoming, past, all)
+// @Success 200 {array} types.Reservation
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/me [get]
+func (s *Server) handleGetMyReservations(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// Always scoped to the caller, regardless of role
+	reservations, err := s.db.ReservationQ().GetAll(r.Context(), &user.ID, reservationFiltersFromQuery(r))
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to get user reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationsForUser(reservations, user))
+}
+
+// @Summary Count my upcoming reservations
+// @Description Get the number of reservations of the authenticated user that have not started yet and were not cancelled
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} UpcomingCountResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/me/count [get]
+func (s *Server) handleGetMyUpcomingCount(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if count, err := s.cache.ReservationCache().GetUserUpcomingCount(r.Context(), user.ID); err == nil {
+		writeJSONResponse(w, http.StatusOK, UpcomingCountResponse{Upcoming: count})
+		return
+	}
+
+	count, err := s.db.ReservationQ().CountUpcomingByUser(r.Context(), user.ID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to count upcoming reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.ReservationCache().SetUserUpcomingCount(r.Context(), user.ID, count, upcomingCountCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache upcoming reservation count")
+	}
+
+	writeJSONResponse(w, http.StatusOK, UpcomingCountResponse{Upcoming: count})
 }
 
 // @Summary Get reservation by ID
@@ -103,7 +281,7 @@ func (s *Server) handleGetReservation(w http.ResponseWriter, r *http.Request) {
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -111,28 +289,94 @@ func (s *Server) handleGetReservation(w http.ResponseWriter, r *http.Request) {
 	reservationID, err := uuid.Parse(reservationIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid reservation ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid reservation ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
 		return
 	}
 
 	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationForUser(reservation, user))
+}
+
+// @Summary Look up reservation by confirmation code
+// @Description Public guest self-service lookup. Both the confirmation code and the guest phone must match.
+// @Description Lookups are rate limited per client to prevent code enumeration
+// @Tags Reservations
+// @Produce json
+// @Param code query string true "Confirmation code"
+// @Param phone query string true "Guest phone"
+// @Success 200 {object} ReservationLookupResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/lookup [get]
+func (s *Server) handleLookupReservation(w http.ResponseWriter, r *http.Request) {
+	client := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client = host
+	}
+
+	attempts, err := s.cache.ReservationCache().IncrementLookupAttempts(r.Context(), client, lookupRateWindow)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to count reservation lookup attempts")
+	} else if attempts > lookupRateLimit {
+		w.Header().Set("Retry-After", strconv.Itoa(int(lookupRateWindow.Seconds())))
+		writeErrorResponse(w, r, http.StatusTooManyRequests, codeRateLimited, i18n.TooManyLookupAttempts, nil)
 		return
 	}
 
-	if reservation == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Reservation not found", nil)
+	code := normalizeConfirmationCode(r.URL.Query().Get("code"))
+	phone := normalizePhone(r.URL.Query().Get("phone"))
+
+	v := validation.New()
+	if code == "" {
+		v.Add("code", i18n.ConfirmationCodeMissing)
+	} else if !isValidConfirmationCode(code) {
+		v.Add("code", i18n.InvalidConfirmationCode)
+	}
+	if phone == "" {
+		v.Add("phone", i18n.PhoneMissing)
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
 		return
 	}
 
-	if user.Role != adminRole && reservation.UserID != user.ID {
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
+	reservation, err := s.db.ReservationQ().GetByConfirmationCode(r.Context(), code)
+	if err != nil && !errors.Is(err, data.ErrNotFound) {
+		s.log.WithError(err).Error("failed to get reservation by confirmation code")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	// An unknown code and a phone mismatch look the same so that neither can be probed on its own
+	if reservation == nil || normalizePhone(reservation.GuestPhone) != phone {
+		writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, reservation)
+	writeJSONResponse(w, http.StatusOK, ReservationLookupResponse{
+		ConfirmationCode: code,
+		GuestName:        reservation.GuestName,
+		Date:             reservation.Date.Format("2006-01-02"),
+		Time:             reservation.Time,
+		Guests:           reservation.Guests,
+		Status:           reservation.Status,
+	})
 }
 
 // @Summary Get reservations by user
@@ -150,7 +394,7 @@ func (s *Server) handleGetUserReservations(w http.ResponseWriter, r *http.Reques
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -158,23 +402,23 @@ func (s *Server) handleGetUserReservations(w http.ResponseWriter, r *http.Reques
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid user ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
 		return
 	}
 
 	if user.Role != adminRole && userID != user.ID {
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
 	reservations, err := s.db.ReservationQ().GetByUserID(r.Context(), userID)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user reservations")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, reservations)
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationsForUser(reservations, user))
 }
 
 // @Summary Create reservation
@@ -183,185 +427,521 @@ func (s *Server) handleGetUserReservations(w http.ResponseWriter, r *http.Reques
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key making retries return the originally created reservation"
 // @Param reservation body CreateReservationRequest true "Reservation payload"
 // @Success 201 {object} types.Reservation
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} TableConflictResponse "Validation error, or the requested table is already booked"
+// @Failure 409 {object} ErrorResponse "A request with the same idempotency key is still in progress, the guest phone already has a reservation close to the requested time, the user holds too many active reservations, or the time slot is full"
 // @Failure 500 {object} ErrorResponse
 // @Router /reservations [post]
 func (s *Server) handleCreateReservation(w http.ResponseWriter, r *http.Request) {
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	s.serveCreateReservation(w, r, user, &user.ID)
+}
+
+// @Summary Create guest reservation
+// @Description Create a reservation for a walk-in guest without a linked account (admin only).
+// @Description The reservation has no owner, so only admins can view or change it afterwards
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Key making retries return the originally created reservation"
+// @Param reservation body CreateReservationRequest true "Reservation payload"
+// @Success 201 {object} types.Reservation
+// @Failure 400 {object} TableConflictResponse "Validation error, or the requested table is already booked"
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "A request with the same idempotency key is still in progress, the guest phone already has a reservation close to the requested time, or the time slot is full"
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/guest [post]
+func (s *Server) handleCreateGuestReservation(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
+	s.serveCreateReservation(w, r, user, nil)
+}
+
+// serveCreateReservation validates and stores a reservation requested by user on behalf of ownerID,
+// which is nil for guest reservations. Idempotency keys are scoped to the requesting user
+func (s *Server) serveCreateReservation(w http.ResponseWriter, r *http.Request, user *types.User, ownerID *uuid.UUID) {
+	idempotencyKey := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			idempotencyKeyHeader: i18n.Msg(i18n.IdempotencyKeyTooLong, maxIdempotencyKeyLength),
+		})
+		return
+	}
+	if idempotencyKey != "" {
+		if reservationID, err := s.cache.ReservationCache().GetIdempotencyKey(r.Context(), user.ID, idempotencyKey); err == nil {
+			s.replayReservation(w, r, user, reservationID)
+			return
+		}
+	}
+
 	var req CreateReservationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode request body")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	s.serveNewReservation(w, r, user, ownerID, req, idempotencyKey)
+}
+
+// serveNewReservation validates req, checks that its tables are free and stores the reservation
+// requested by user on behalf of ownerID. A non-empty idempotency key is claimed for the new reservation
+func (s *Server) serveNewReservation(w http.ResponseWriter, r *http.Request, user *types.User, ownerID *uuid.UUID, req CreateReservationRequest, idempotencyKey string) {
+	tableNumbers, v, err := s.validateCreateReservation(r.Context(), &req)
+	if err != nil {
+		s.log.WithError(err).Error("failed to validate reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if s.reservationPolicy.MaxActivePerUser > 0 && ownerID != nil && user.Role != adminRole {
+		active, err := s.db.ReservationQ().CountActiveByUser(r.Context(), *ownerID)
+		if err != nil {
+			s.log.WithError(err).Error("failed to count active reservations")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if active >= s.reservationPolicy.MaxActivePerUser {
+			writeErrorResponse(w, r, http.StatusConflict, codeTooManyReservations, i18n.TooManyActiveReservations, i18n.Details{
+				"reservations": i18n.Msg(i18n.ActiveReservationsLimit, active, s.reservationPolicy.MaxActivePerUser),
+			})
+			return
+		}
+	}
+
+	if !s.checkBlockedDate(w, r, req.Date) {
+		return
+	}
+
+	date, _ := time.Parse("2006-01-02", req.Date)
+
+	for _, tableNumber := range tableNumbers {
+		available, err := s.db.ReservationQ().CheckTableAvailability(r.Context(), tableNumber, req.Date, req.Time)
+		if err != nil {
+			s.log.WithError(err).Error("failed to check table availability")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if !available {
+			s.writeTableConflict(w, r, tableNumber, date, req.Time, req.Guests, tableNumbers)
+			return
+		}
+	}
+
+	if !s.checkConcurrentParties(w, r, req.Date, req.Time, uuid.Nil) {
+		return
+	}
+
+	if s.reservationPolicy.DuplicateWindow > 0 {
+		existing, err := s.db.ReservationQ().GetByPhoneAndDate(r.Context(), req.GuestPhone, req.Date)
+		if err != nil {
+			s.log.WithError(err).Error("failed to get reservations by phone")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if duplicate := s.reservationPolicy.FindDuplicate(existing, req.Time); duplicate != nil {
+			writeErrorResponse(w, r, http.StatusConflict, codeDuplicateReservation, i18n.DuplicateReservation, duplicateDetails(duplicate, user, req.Date))
+			return
+		}
+	}
+
+	reservation := s.newReservation(req, ownerID, tableNumbers)
+
+	// The key is claimed before the insert so that concurrent retries cannot both create a reservation.
+	// If the cache is unavailable the request proceeds without idempotency protection
+	if idempotencyKey != "" {
+		claimed, err := s.cache.ReservationCache().SetIdempotencyKey(r.Context(), user.ID, idempotencyKey, reservation.ID, idempotencyKeyExpiration)
+		if err != nil {
+			s.log.WithError(err).Warn("failed to store idempotency key")
+			idempotencyKey = ""
+		} else if !claimed {
+			reservationID, err := s.cache.ReservationCache().GetIdempotencyKey(r.Context(), user.ID, idempotencyKey)
+			if err != nil {
+				s.log.WithError(err).Error("failed to get idempotency key")
+				writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+				return
+			}
+			s.replayReservation(w, r, user, reservationID)
+			return
+		}
+	}
+
+	if err := s.createReservation(r.Context(), reservation); err != nil {
+		s.log.WithError(err).Error("failed to create reservation")
+		if idempotencyKey != "" {
+			if err := s.cache.ReservationCache().DeleteIdempotencyKey(r.Context(), user.ID, idempotencyKey); err != nil {
+				s.log.WithError(err).Warn("failed to release idempotency key")
+			}
+		}
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	validationErrors := make(map[string]string)
+	s.invalidateReservationCache(r.Context(), reservation)
+	s.webhooks.Dispatch(r.Context(), webhook.EventReservationCreated, reservation)
+	s.logAction(r, actionReservationCreated, reservationFields(reservation))
+
+	go s.sendReservationConfirmation(context.WithoutCancel(r.Context()), reservation)
+
+	writeJSONResponse(w, http.StatusCreated, reservation)
+}
+
+// duplicateDetails describes the existing reservation a new one duplicates. Only its owner learns
+// which reservation it is, anyone else gets the bare conflict code
+func duplicateDetails(duplicate *types.Reservation, user *types.User, date string) i18n.Details {
+	if user == nil || !duplicate.OwnedBy(user.ID) {
+		return nil
+	}
+	return i18n.Details{
+		"guestPhone":    i18n.Msg(i18n.DuplicatePhone, duplicate.Time, date),
+		"reservationId": i18n.Value(duplicate.ID.String()),
+	}
+}
+
+// checkConcurrentParties responds with a conflict and returns false when the restaurant already
+// seats the maximum number of parties at the given date and time. A party is seated for one slot
+// interval, so parties starting within an interval of the time are counted. excludeID is the
+// reservation being moved, if any, so that it does not count against itself
+func (s *Server) checkConcurrentParties(w http.ResponseWriter, r *http.Request, date, clock string, excludeID uuid.UUID) bool {
+	if s.reservationPolicy.MaxConcurrentParties <= 0 {
+		return true
+	}
+
+	concurrent, err := s.db.ReservationQ().CountConcurrentAt(r.Context(), date, clock, s.reservationPolicy.SlotInterval(), excludeID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to count concurrent reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return false
+	}
+	if concurrent >= s.reservationPolicy.MaxConcurrentParties {
+		writeErrorResponse(w, r, http.StatusConflict, codeSlotFull, i18n.SlotFull, i18n.Details{
+			"time": i18n.Msg(i18n.PartiesSeatedOnDate, concurrent, clock, date, s.reservationPolicy.MaxConcurrentParties),
+		})
+		return false
+	}
+	return true
+}
+
+// validateCreateReservation normalizes req in place and checks it against the reservation policy.
+// It returns the requested table set together with the validation errors, or an error when the
+// opening hours of the date cannot be looked up
+func (s *Server) validateCreateReservation(ctx context.Context, req *CreateReservationRequest) ([]string, *validation.Errors, error) {
+	v := validation.New()
 	req.GuestName = strings.TrimSpace(req.GuestName)
-	req.GuestPhone = strings.TrimSpace(req.GuestPhone)
+	req.GuestPhone = normalizePhone(req.GuestPhone)
 	req.GuestEmail = strings.TrimSpace(req.GuestEmail)
 	req.TableNumber = strings.TrimSpace(req.TableNumber)
 
 	if req.GuestName == "" {
-		validationErrors["guestName"] = "Guest name is required"
+		v.Add("guestName", i18n.GuestNameMissing)
 	}
 	if req.GuestPhone == "" {
-		validationErrors["guestPhone"] = "Guest phone is required"
+		v.Add("guestPhone", i18n.GuestPhoneMissing)
+	} else if !isValidPhone(req.GuestPhone) {
+		v.Add("guestPhone", i18n.InvalidPhoneFormat)
 	}
 	if req.GuestEmail == "" {
-		validationErrors["guestEmail"] = "Guest email is required"
+		v.Add("guestEmail", i18n.GuestEmailMissing)
 	} else if !isValidEmail(req.GuestEmail) {
-		validationErrors["guestEmail"] = "Invalid email format"
+		v.Add("guestEmail", i18n.InvalidEmailFormat)
 	}
 	if req.Date == "" {
-		validationErrors["date"] = "Date is required"
+		v.Add("date", i18n.DateMissing)
 	} else if _, err := time.Parse("2006-01-02", req.Date); err != nil {
-		validationErrors["date"] = "Invalid date format"
+		v.Add("date", i18n.InvalidDateFormat)
 	}
 	if req.Time == "" {
-		validationErrors["time"] = "Time is required"
+		v.Add("time", i18n.TimeMissing)
 	} else if _, err := time.Parse("15:04", req.Time); err != nil {
-		validationErrors["time"] = "Invalid time format"
+		v.Add("time", i18n.InvalidTimeFormat)
+	} else if message := s.reservationPolicy.ValidateSlotBoundary(req.Time); !message.IsZero() {
+		v.AddMessage("time", message)
 	}
-	if req.Guests <= 0 {
-		validationErrors["guests"] = "Number of guests must be greater than 0"
+	if date, err := time.Parse("2006-01-02", req.Date); err == nil {
+		message, err := s.validateOpeningHours(ctx, date, req.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !message.IsZero() {
+			v.AddMessage("time", message)
+		}
+		if at, err := s.reservationPolicy.ReservationStart(date, req.Time); err == nil {
+			if field, message := s.reservationPolicy.ValidateBookingWindow(at, s.reservationPolicy.Now()); !message.IsZero() {
+				v.AddMessage(field, message)
+			}
+		}
 	}
-	if req.TableNumber == "" {
-		validationErrors["tableNumber"] = "Table number is required"
+	if req.Guests <= 0 {
+		v.Add("guests", i18n.GuestsNotPositive)
+	} else if message := s.reservationPolicy.ValidatePartySize(req.Guests); !message.IsZero() {
+		v.AddMessage("guests", message)
+	}
+	tableNumbers := normalizeTableNumbers(req.TableNumber, req.TableNumbers)
+	if len(tableNumbers) == 0 {
+		v.Add("tableNumber", i18n.TableNumberMissing)
+	} else {
+		details, err := s.validateTables(ctx, tableNumbers, req.Guests)
+		if err != nil {
+			return nil, nil, err
+		}
+		v.Merge(details)
 	}
 
-	if len(validationErrors) > 0 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", validationErrors)
-		return
-	}
+	return tableNumbers, v, nil
+}
 
+// newReservation builds the reservation described by a validated create request on behalf of ownerID
+func (s *Server) newReservation(req CreateReservationRequest, ownerID *uuid.UUID, tableNumbers []string) *types.Reservation {
 	date, _ := time.Parse("2006-01-02", req.Date)
-
-	available, err := s.db.ReservationQ().CheckTableAvailability(r.Context(), req.TableNumber, req.Date, req.Time)
-	if err != nil {
-		s.log.WithError(err).Error("failed to check table availability")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-	if !available {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", map[string]string{
-			"tableNumber": "Table not available at this time",
-		})
-		return
-	}
-
 	reservation := &types.Reservation{
 		ID:              uuid.New(),
-		UserID:          user.ID,
+		UserID:          ownerID,
 		GuestName:       req.GuestName,
 		GuestPhone:      req.GuestPhone,
 		GuestEmail:      req.GuestEmail,
 		Date:            date,
 		Time:            req.Time,
 		Guests:          req.Guests,
-		TableNumber:     req.TableNumber,
-		Status:          "pending",
+		TableNumber:     tableNumbers[0],
+		Status:          s.reservationPolicy.InitialStatus(),
 		SpecialRequests: req.SpecialRequests,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
+	if len(tableNumbers) > 1 {
+		reservation.TableNumbers = tableNumbers
+	}
+	return reservation
+}
 
-	if err := s.db.ReservationQ().Create(r.Context(), reservation); err != nil {
-		s.log.WithError(err).Error("failed to create reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
+// createReservation stores a new reservation under a fresh confirmation code,
+// drawing another code when the generated one is already taken
+func (s *Server) createReservation(ctx context.Context, reservation *types.Reservation) error {
+	for attempt := 1; ; attempt++ {
+		code, err := generateConfirmationCode()
+		if err != nil {
+			return fmt.Errorf("failed to generate confirmation code: %w", err)
+		}
+		reservation.ConfirmationCode = &code
+
+		err = s.db.ReservationQ().Create(ctx, reservation, s.reservationPolicy.InitialStatus())
+		if !errors.Is(err, data.ErrConflict) || attempt == maxConfirmationCodeAttempts {
+			return err
+		}
 	}
+}
 
-	if err := s.cache.ReservationCache().InvalidateUserReservations(r.Context(), user.ID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate reservation cache")
+// replayReservation responds to a retried create request with the reservation created by the original one
+func (s *Server) replayReservation(w http.ResponseWriter, r *http.Request, user *types.User, reservationID uuid.UUID) {
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			// The original request claimed the key but has not inserted the reservation yet
+			writeErrorResponse(w, r, http.StatusConflict, codeRequestInProgress, i18n.RequestInProgress, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation for idempotent replay")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
 	}
 
-	writeJSONResponse(w, http.StatusCreated, reservation)
+	writeJSONResponse(w, http.StatusCreated, sanitizeReservationForUser(reservation, user))
 }
 
-// @Summary Update reservation
-// @Description Update reservation fields (owner or admin)
+// @Summary Rebook reservation
+// @Description Create a new reservation copying the guest details, tables, party size, time and special requests
+// @Description of an existing one onto another date (owner or admin). The copy gets a fresh ID and confirmation code,
+// @Description belongs to the owner of the original and goes through the same validation and availability checks as a new reservation
 // @Tags Reservations
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Reservation ID"
-// @Param body body UpdateReservationRequest true "Payload"
-// @Success 200 {object} types.Reservation
-// @Failure 400 {object} ErrorResponse
+// @Param body body RebookReservationRequest true "Payload"
+// @Success 201 {object} types.Reservation
+// @Failure 400 {object} TableConflictResponse "Validation error, or the table is already booked on the new date"
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The guest phone already has a reservation close to the time on the new date, the user holds too many active reservations, or the time slot is full"
 // @Failure 500 {object} ErrorResponse
-// @Router /reservations/{id} [patch]
-func (s *Server) handleUpdateReservation(w http.ResponseWriter, r *http.Request) {
+// @Router /reservations/{id}/rebook [post]
+func (s *Server) handleRebookReservation(w http.ResponseWriter, r *http.Request) {
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	reservationIDStr := r.PathValue("id")
-	reservationID, err := uuid.Parse(reservationIDStr)
+	reservationID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		s.log.WithError(err).Debug("invalid reservation ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid reservation ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
 		return
 	}
 
-	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	original, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	if reservation == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Reservation not found", nil)
+	if user.Role != adminRole && !original.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
-	if user.Role != adminRole && reservation.UserID != user.ID {
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
-		return
-	}
-
-	var req UpdateReservationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var req RebookReservationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode request body")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
-	hasUpdates := false
-	validationErrors := make(map[string]string)
+	s.serveNewReservation(w, r, user, original.UserID, rebookRequest(original, strings.TrimSpace(req.Date)), "")
+}
 
-	if req.GuestName != nil {
-		name := strings.TrimSpace(*req.GuestName)
-		if name == "" {
-			validationErrors["guestName"] = "Guest name cannot be empty"
-		} else {
-			reservation.GuestName = name
-			hasUpdates = true
-		}
-	}
-	if req.GuestPhone != nil {
-		reservation.GuestPhone = strings.TrimSpace(*req.GuestPhone)
-		hasUpdates = true
+// rebookRequest turns a reservation into a create request for the same guest, tables,
+// party size and time of day on the given date
+func rebookRequest(reservation *types.Reservation, date string) CreateReservationRequest {
+	clock := reservation.Time
+	// Times read back from the database carry seconds, which create requests do not accept
+	if offset, err := parseClock(clock); err == nil {
+		clock = formatClock(offset)
+	}
+
+	return CreateReservationRequest{
+		GuestName:       reservation.GuestName,
+		GuestPhone:      reservation.GuestPhone,
+		GuestEmail:      reservation.GuestEmail,
+		Date:            date,
+		Time:            clock,
+		Guests:          reservation.Guests,
+		TableNumber:     reservation.TableNumber,
+		TableNumbers:    append([]string(nil), reservation.TableNumbers...),
+		SpecialRequests: reservation.SpecialRequests,
+	}
+}
+
+// @Summary Update reservation
+// @Description Update reservation fields (owner or admin)
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param body body UpdateReservationRequest true "Payload"
+// @Success 200 {object} types.Reservation
+// @Failure 400 {object} TableConflictResponse "Validation error, or a newly added table is already booked"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Reservation was modified concurrently"
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id} [patch]
+func (s *Server) handleUpdateReservation(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationIDStr := r.PathValue("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	var req UpdateReservationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+	before := reservationFields(reservation)
+	previousDate := reservation.Date
+	previousTime := reservation.Time
+
+	// The write is guarded by the version the client saw or, if it didn't send one,
+	// the version read above, so concurrent edits are never silently overwritten.
+	// Both are compared at the microsecond precision PostgreSQL stores timestamps with,
+	// so a version echoed back with extra or missing nanoseconds still matches
+	expectedUpdatedAt := reservation.UpdatedAt.Truncate(time.Microsecond)
+	if req.UpdatedAt != nil && !req.UpdatedAt.Truncate(time.Microsecond).Equal(expectedUpdatedAt) {
+		writeErrorResponse(w, r, http.StatusConflict, codeEditConflict, i18n.ReservationModified, nil)
+		return
+	}
+
+	hasUpdates := false
+	validationErrors := make(i18n.Details)
+
+	if req.GuestName != nil {
+		name := strings.TrimSpace(*req.GuestName)
+		if name == "" {
+			validationErrors["guestName"] = i18n.Msg(i18n.GuestNameEmpty)
+		} else {
+			reservation.GuestName = name
+			hasUpdates = true
+		}
+	}
+	if req.GuestPhone != nil {
+		phone := normalizePhone(*req.GuestPhone)
+		if phone == "" {
+			validationErrors["guestPhone"] = i18n.Msg(i18n.GuestPhoneEmpty)
+		} else if !isValidPhone(phone) {
+			validationErrors["guestPhone"] = i18n.Msg(i18n.InvalidPhoneFormat)
+		} else {
+			reservation.GuestPhone = phone
+			hasUpdates = true
+		}
 	}
 	if req.GuestEmail != nil {
 		email := strings.TrimSpace(*req.GuestEmail)
 		if email == "" {
-			validationErrors["guestEmail"] = "Guest email cannot be empty"
+			validationErrors["guestEmail"] = i18n.Msg(i18n.GuestEmailEmpty)
 		} else if !isValidEmail(email) {
-			validationErrors["guestEmail"] = "Invalid email format"
+			validationErrors["guestEmail"] = i18n.Msg(i18n.InvalidEmailFormat)
 		} else {
 			reservation.GuestEmail = email
 			hasUpdates = true
@@ -370,7 +950,7 @@ func (s *Server) handleUpdateReservation(w http.ResponseWriter, r *http.Request)
 	if req.Date != nil {
 		date, err := time.Parse("2006-01-02", *req.Date)
 		if err != nil {
-			validationErrors["date"] = "Invalid date format"
+			validationErrors["date"] = i18n.Msg(i18n.InvalidDateFormat)
 		} else {
 			reservation.Date = date
 			hasUpdates = true
@@ -378,59 +958,187 @@ func (s *Server) handleUpdateReservation(w http.ResponseWriter, r *http.Request)
 	}
 	if req.Time != nil {
 		if _, err := time.Parse("15:04", *req.Time); err != nil {
-			validationErrors["time"] = "Invalid time format"
+			validationErrors["time"] = i18n.Msg(i18n.InvalidTimeFormat)
+		} else if message := s.reservationPolicy.ValidateSlotBoundary(*req.Time); !message.IsZero() {
+			validationErrors["time"] = message
 		} else {
 			reservation.Time = *req.Time
 			hasUpdates = true
 		}
 	}
+	if (req.Date != nil || req.Time != nil) && validationErrors["date"].IsZero() && validationErrors["time"].IsZero() {
+		message, err := s.validateOpeningHours(r.Context(), reservation.Date, reservation.Time)
+		if err != nil {
+			s.log.WithError(err).Error("failed to get special hours")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if !message.IsZero() {
+			validationErrors["time"] = message
+		}
+		if at, err := s.reservationPolicy.ReservationStart(reservation.Date, reservation.Time); err == nil {
+			if field, message := s.reservationPolicy.ValidateBookingWindow(at, s.reservationPolicy.Now()); !message.IsZero() && validationErrors[field].IsZero() {
+				validationErrors[field] = message
+			}
+		}
+	}
 	if req.Guests != nil {
 		if *req.Guests <= 0 {
-			validationErrors["guests"] = "Number of guests must be greater than 0"
+			validationErrors["guests"] = i18n.Msg(i18n.GuestsNotPositive)
+		} else if message := s.reservationPolicy.ValidatePartySize(*req.Guests); !message.IsZero() {
+			validationErrors["guests"] = message
 		} else {
 			reservation.Guests = *req.Guests
 			hasUpdates = true
 		}
 	}
-	if req.TableNumber != nil {
-		reservation.TableNumber = strings.TrimSpace(*req.TableNumber)
-		hasUpdates = true
+
+	currentTables := reservationTableNumbers(reservation)
+	tableNumbers := currentTables
+	if req.TableNumber != nil || req.TableNumbers != nil {
+		// Setting only tableNumber turns the reservation back into a single-table one
+		primary := ""
+		if req.TableNumber != nil {
+			primary = *req.TableNumber
+		}
+		tableNumbers = normalizeTableNumbers(primary, req.TableNumbers)
+
+		if len(tableNumbers) == 0 {
+			validationErrors["tableNumber"] = i18n.Msg(i18n.TableNumberEmpty)
+		} else {
+			reservation.TableNumber = tableNumbers[0]
+			hasUpdates = true
+		}
 	}
-	if req.SpecialRequests != nil {
-		reservation.SpecialRequests = req.SpecialRequests
+	tablesChanged := !slices.Equal(currentTables, tableNumbers)
+	slotChanged := !previousDate.Equal(reservation.Date) || !sameClock(previousTime, reservation.Time)
+	// Tables that went out of service keep their existing bookings, but cannot be booked anew or in another slot
+	if len(tableNumbers) > 0 && (tablesChanged || slotChanged || (len(tableNumbers) > 1 && req.Guests != nil)) {
+		details, err := s.validateTables(r.Context(), tableNumbers, reservation.Guests)
+		if err != nil {
+			s.log.WithError(err).Error("failed to validate tables")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		for field, message := range details {
+			validationErrors[field] = message
+		}
+	}
+	if req.SpecialRequests.Set {
+		// An empty value tells Update to clear the column
+		cleared := ""
+		reservation.SpecialRequests = &cleared
+		if req.SpecialRequests.Value != nil && strings.TrimSpace(*req.SpecialRequests.Value) != "" {
+			reservation.SpecialRequests = req.SpecialRequests.Value
+		}
 		hasUpdates = true
 	}
 
 	if len(validationErrors) > 0 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", validationErrors)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
 		return
 	}
 
 	if !hasUpdates {
-		writeJSONResponse(w, http.StatusOK, reservation)
+		writeJSONResponse(w, http.StatusOK, sanitizeReservationForUser(reservation, user))
 		return
 	}
 
-	reservation.UpdatedAt = time.Now()
+	// Reservations already on a blocked date can still be edited, but none can be moved onto one
+	if !previousDate.Equal(reservation.Date) && !s.checkBlockedDate(w, r, reservation.Date.Format("2006-01-02")) {
+		return
+	}
+
+	// Newly added tables must be free at the reservation's date and time. A moved reservation
+	// has to find every table free in its new slot, including the ones it keeps
+	if tablesChanged || slotChanged {
+		for _, tableNumber := range tableNumbers {
+			if !slotChanged && slices.Contains(currentTables, tableNumber) {
+				continue
+			}
+			available, err := s.db.ReservationQ().CheckTableAvailability(r.Context(), tableNumber, reservation.Date.Format("2006-01-02"), reservation.Time)
+			if err != nil {
+				s.log.WithError(err).Error("failed to check table availability")
+				writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+				return
+			}
+			if !available {
+				s.writeTableConflict(w, r, tableNumber, reservation.Date, reservation.Time, reservation.Guests, tableNumbers)
+				return
+			}
+		}
+	}
+
+	// A moved active reservation is seated in its new slot, so it counts towards the cap there
+	activeStatus := reservation.Status == "pending" || reservation.Status == "confirmed"
+	if slotChanged && activeStatus && !s.checkConcurrentParties(w, r, reservation.Date.Format("2006-01-02"), reservation.Time, reservationID) {
+		return
+	}
 
-	if err := s.db.ReservationQ().Update(r.Context(), reservationID, reservation); err != nil {
+	// The row and its table set are written together, so a failure leaves neither changed
+	var updatedAt time.Time
+	err = s.db.Transaction(r.Context(), func(tx data.MasterQ) error {
+		var err error
+		updatedAt, err = tx.ReservationQ().Update(r.Context(), reservationID, reservation, &expectedUpdatedAt)
+		if err != nil {
+			return err
+		}
+		if tablesChanged {
+			if err := tx.ReservationQ().SetTables(r.Context(), reservationID, tableNumbers); err != nil {
+				return fmt.Errorf("failed to update reservation tables: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, data.ErrConflict) {
+			writeErrorResponse(w, r, http.StatusConflict, codeEditConflict, i18n.ReservationModified, nil)
+			return
+		}
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to update reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
+	reservation.UpdatedAt = updatedAt
 
-	if err := s.cache.ReservationCache().DeleteReservation(r.Context(), reservationID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate reservation cache")
+	if reservation.SpecialRequests != nil && *reservation.SpecialRequests == "" {
+		reservation.SpecialRequests = nil
 	}
-	if err := s.cache.ReservationCache().InvalidateUserReservations(r.Context(), reservation.UserID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate user reservations cache")
+
+	if tablesChanged {
+		reservation.TableNumbers = nil
+		if len(tableNumbers) > 1 {
+			reservation.TableNumbers = tableNumbers
+		}
 	}
 
-	writeJSONResponse(w, http.StatusOK, reservation)
+	s.invalidateReservationCache(r.Context(), reservation)
+	// A moved reservation frees its tables on the previous date too, and a move
+	// to another month also changes the statistics of the month it left
+	if !previousDate.Equal(reservation.Date) {
+		s.invalidateAvailableTables(r.Context(), previousDate)
+		if previousMonth := previousDate.Format("2006-01"); previousMonth != reservation.Date.Format("2006-01") {
+			if err := s.cache.ReportCache().InvalidateMonthlyStats(r.Context(), previousMonth); err != nil {
+				s.log.WithError(err).Warn("failed to invalidate monthly stats cache")
+			}
+		}
+	}
+	changes := changedFields(before, reservationFields(reservation))
+	changes["reservation_id"] = reservationID
+	s.logAction(r, actionReservationUpdated, changes)
+
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationForUser(reservation, user))
 }
 
 // @Summary Update reservation status
-// @Description Update reservation status (pending, confirmed, cancelled, completed)
+// @Description Update reservation status (pending, confirmed, cancelled, completed, no_show).
+// @Description Pending reservations can be confirmed or cancelled and confirmed ones completed, cancelled or marked as a no-show.
+// @Description Cancelled, completed and no-show reservations are final, and setting the current status again is rejected.
+// @Description Only admins can mark a reservation as a no-show
 // @Tags Reservations
 // @Security BearerAuth
 // @Accept json
@@ -439,79 +1147,249 @@ func (s *Server) handleUpdateReservation(w http.ResponseWriter, r *http.Request)
 // @Param body body UpdateReservationStatusRequest true "Status payload"
 // @Success 200 {object} types.Reservation
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /reservations/{id}/status [patch]
 func (s *Server) handleUpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
 	reservationIDStr := r.PathValue("id")
 	reservationID, err := uuid.Parse(reservationIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid reservation ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid reservation ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
 		return
 	}
 
 	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if reservation == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Reservation not found", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	var req UpdateReservationStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode request body")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
-	validStatuses := map[string]bool{
-		"pending":   true,
-		"confirmed": true,
-		"cancelled": true,
-		"completed": true,
+	if !isValidReservationStatus(req.Status) {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"status": i18n.Msg(i18n.InvalidStatus),
+		})
+		return
 	}
-	if !validStatuses[req.Status] {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", map[string]string{
-			"status": "Invalid status",
+	if err := validateStatusTransition(reservation.Status, req.Status); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"status": i18n.MessageOf(err),
 		})
 		return
 	}
+	if req.Status == "no_show" && user.Role != adminRole {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
 
-	if err := s.db.ReservationQ().UpdateStatus(r.Context(), reservationID, req.Status); err != nil {
+	// The status history is the audit trail of the reservation, so the change and its record commit together.
+	// The update only applies while the reservation is still in the status the transition was checked against
+	var updated *types.Reservation
+	err = s.db.Transaction(r.Context(), func(tx data.MasterQ) error {
+		var err error
+		updated, err = tx.ReservationQ().UpdateStatus(r.Context(), reservationID, reservation.Status, req.Status)
+		if err != nil {
+			return err
+		}
+		if err := tx.StatusHistoryQ().Create(r.Context(), &types.StatusChange{
+			ReservationID: reservationID,
+			FromStatus:    reservation.Status,
+			ToStatus:      req.Status,
+			ChangedBy:     &user.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to record reservation status change: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// The reservation was deleted after it was read
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		// The status was changed by someone else after it was read
+		if errors.Is(err, data.ErrConflict) {
+			writeErrorResponse(w, r, http.StatusConflict, codeEditConflict, i18n.ReservationModified, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to update reservation status")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	reservation, err = s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	s.logAction(r, actionReservationStatusChanged, logan.F{
+		"reservation_id": reservationID,
+		"from_status":    reservation.Status,
+		"to_status":      req.Status,
+	})
+	reservation = updated
+
+	s.invalidateReservationCache(r.Context(), reservation)
+	s.webhooks.Dispatch(r.Context(), webhook.EventReservationStatusChanged, reservation)
+
+	writeJSONResponse(w, http.StatusOK, sanitizeReservationForUser(reservation, user))
+}
+
+// @Summary Bulk update reservation status
+// @Description Change the status of several reservations at once (admin only).
+// @Description Changes are all-or-nothing: if any reservation cannot transition, none are updated.
+// @Description An ID listed more than once is applied once and its repeats are reported as duplicate
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body BulkUpdateReservationStatusRequest true "Reservation IDs and target status"
+// @Success 200 {object} BulkUpdateReservationStatusResponse
+// @Failure 400 {object} BulkUpdateReservationStatusResponse "Some reservations could not transition, nothing was updated"
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/status/bulk [patch]
+func (s *Server) handleBulkUpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
 	if err != nil {
-		s.log.WithError(err).Error("failed to get updated reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	var req BulkUpdateReservationStatusRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
-	if err := s.cache.ReservationCache().DeleteReservation(r.Context(), reservationID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate reservation cache")
+	validationErrors := make(i18n.Details)
+	if len(req.IDs) == 0 {
+		validationErrors["ids"] = i18n.Msg(i18n.BulkIDsMissing)
+	} else if len(req.IDs) > maxBulkStatusUpdateSize {
+		validationErrors["ids"] = i18n.Msg(i18n.BulkReservationsTooMany, maxBulkStatusUpdateSize)
 	}
-	if err := s.cache.ReservationCache().InvalidateUserReservations(r.Context(), reservation.UserID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate user reservations cache")
+	if !isValidReservationStatus(req.Status) {
+		validationErrors["status"] = i18n.Msg(i18n.InvalidStatus)
+	}
+	if len(validationErrors) > 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
+		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, reservation)
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	results := make([]BulkStatusResult, 0, len(req.IDs))
+	changes := make([]*types.StatusChange, 0, len(req.IDs))
+	reservations := make([]*types.Reservation, 0, len(req.IDs))
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	hasFailures := false
+
+	for _, idStr := range req.IDs {
+		result := BulkStatusResult{ID: idStr}
+
+		reservationID, err := uuid.Parse(idStr)
+		if err != nil {
+			result.Result, result.Reason = bulkResultFailed, i18n.Translate(lang, i18n.InvalidReservationID)
+			results = append(results, result)
+			hasFailures = true
+			continue
+		}
+		if seen[reservationID] {
+			result.Result, result.Reason = bulkResultDuplicate, i18n.Translate(lang, i18n.BulkListedTwice)
+			results = append(results, result)
+			continue
+		}
+		seen[reservationID] = true
+
+		reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+		if errors.Is(err, data.ErrNotFound) {
+			result.Result, result.Reason = bulkResultFailed, i18n.Translate(lang, i18n.ReservationNotFound)
+			results = append(results, result)
+			hasFailures = true
+			continue
+		}
+		if err != nil {
+			s.log.WithError(err).WithField("reservation_id", reservationID).Error("failed to get reservation for bulk status update")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+
+		if err := validateStatusTransition(reservation.Status, req.Status); err != nil {
+			result.Result, result.Reason = bulkResultFailed, i18n.MessageOf(err).Translate(lang)
+			results = append(results, result)
+			hasFailures = true
+			continue
+		}
+
+		result.Result = bulkResultSucceeded
+		results = append(results, result)
+		reservations = append(reservations, reservation)
+		changes = append(changes, &types.StatusChange{
+			ReservationID: reservationID,
+			FromStatus:    reservation.Status,
+			ToStatus:      req.Status,
+			ChangedBy:     &user.ID,
+		})
+	}
+
+	// Nothing is applied when at least one reservation cannot transition
+	if hasFailures {
+		for i := range results {
+			if results[i].Result == bulkResultSucceeded {
+				results[i].Result = bulkResultFailed
+				results[i].Reason = i18n.Translate(lang, i18n.BulkNotApplied)
+			}
+		}
+		writeJSONResponse(w, http.StatusBadRequest, BulkUpdateReservationStatusResponse{Results: results})
+		return
+	}
+
+	if err := s.db.ReservationQ().BulkUpdateStatus(r.Context(), changes); err != nil {
+		s.log.WithError(err).Error("failed to bulk update reservation status")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	s.invalidateReservationCache(r.Context(), reservations...)
+	for _, reservation := range reservations {
+		s.logAction(r, actionReservationStatusChanged, logan.F{
+			"reservation_id": reservation.ID,
+			"from_status":    reservation.Status,
+			"to_status":      req.Status,
+			"bulk":           true,
+		})
+		reservation.Status = req.Status
+		s.webhooks.Dispatch(r.Context(), webhook.EventReservationStatusChanged, reservation)
+	}
+
+	writeJSONResponse(w, http.StatusOK, BulkUpdateReservationStatusResponse{Results: results})
 }
 
 // @Summary Delete reservation
-// @Description Delete reservation (owner or admin)
+// @Description Soft-delete reservation (owner or admin). Admins may erase it permanently with hard=true
 // @Tags Reservations
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Reservation ID"
+// @Param hard query bool false "Permanently delete the reservation (admin only)"
 // @Success 200 {object} DeleteResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
@@ -522,7 +1400,7 @@ func (s *Server) handleDeleteReservation(w http.ResponseWriter, r *http.Request)
 	user, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get user from context")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -530,41 +1408,635 @@ func (s *Server) handleDeleteReservation(w http.ResponseWriter, r *http.Request)
 	reservationID, err := uuid.Parse(reservationIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid reservation ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid reservation ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	// Hard delete erases the reservation permanently (e.g. GDPR requests),
+	// including reservations that were already soft-deleted
+	if r.URL.Query().Get("hard") == "true" {
+		if user.Role != adminRole {
+			writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+			return
+		}
+
+		reservation, err := s.db.ReservationQ().HardDelete(r.Context(), reservationID)
+		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+				return
+			}
+			s.log.WithError(err).Error("failed to hard delete reservation")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+
+		s.invalidateReservationCache(r.Context(), reservation)
+		s.webhooks.Dispatch(r.Context(), webhook.EventReservationDeleted, reservation)
+		s.logAction(r, actionReservationDeleted, logan.F{"reservation_id": reservationID, "hard": true})
+
+		writeJSONResponse(w, http.StatusOK, DeleteResponse{
+			Message: "Reservation permanently deleted",
+		})
 		return
 	}
 
 	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	if reservation == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Reservation not found", nil)
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
-	if user.Role != adminRole && reservation.UserID != user.ID {
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
+	if err := s.db.ReservationQ().Delete(r.Context(), reservationID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to delete reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
-	if err := s.db.ReservationQ().Delete(r.Context(), reservationID); err != nil {
-		s.log.WithError(err).Error("failed to delete reservation")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+	s.invalidateReservationCache(r.Context(), reservation)
+	s.webhooks.Dispatch(r.Context(), webhook.EventReservationDeleted, reservation)
+	s.logAction(r, actionReservationDeleted, logan.F{"reservation_id": reservationID, "hard": false})
+
+	writeJSONResponse(w, http.StatusOK, DeleteResponse{
+		Message: "Reservation deleted successfully",
+	})
+}
+
+// @Summary Update reservation staff notes
+// @Description Replace the internal staff notes of a reservation (admin only).
+// @Description Staff notes are never shown to guests
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param body body UpdateReservationNotesRequest true "Staff notes, null or blank to clear"
+// @Success 200 {object} types.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/notes [patch]
+func (s *Server) handleUpdateReservationNotes(w http.ResponseWriter, r *http.Request) {
+	reservationIDStr := r.PathValue("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
 		return
 	}
 
-	if err := s.cache.ReservationCache().DeleteReservation(r.Context(), reservationID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate reservation cache")
+	var req UpdateReservationNotesRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
 	}
-	if err := s.cache.ReservationCache().InvalidateUserReservations(r.Context(), reservation.UserID); err != nil {
-		s.log.WithError(err).Warn("failed to invalidate user reservations cache")
+
+	var notes *string
+	if req.Notes != nil && strings.TrimSpace(*req.Notes) != "" {
+		trimmed := strings.TrimSpace(*req.Notes)
+		notes = &trimmed
 	}
 
-	writeJSONResponse(w, http.StatusOK, DeleteResponse{
-		Message: "Reservation deleted successfully",
+	v := validation.New()
+	if notes != nil && utf8.RuneCountInString(*notes) > maxStaffNotesLength {
+		v.Add("notes", i18n.StaffNotesTooLong, maxStaffNotesLength)
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if err := s.db.ReservationQ().UpdateStaffNotes(r.Context(), reservationID, notes); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).WithField("reservation_id", reservationID).Error("failed to update reservation staff notes")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get updated reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	s.invalidateReservationCache(r.Context(), reservation)
+	// The notes themselves may hold personal details and are not logged
+	s.logAction(r, actionReservationNotesUpdated, logan.F{"reservation_id": reservationID, "cleared": notes == nil})
+
+	writeJSONResponse(w, http.StatusOK, reservation)
+}
+
+// handleGetReservationResource dispatches GET /reservations/{id}/{resource} requests.
+// A literal "GET /reservations/{id}/history" pattern would conflict with
+// "GET /reservations/user/{userId}" in http.ServeMux, so sub-resources share one pattern
+func (s *Server) handleGetReservationResource(w http.ResponseWriter, r *http.Request) {
+	switch r.PathValue("resource") {
+	case "history":
+		s.handleGetReservationHistory(w, r)
+	case "pdf":
+		s.handleGetReservationPDF(w, r)
+	case "feedback":
+		s.handleGetFeedback(w, r)
+	default:
+		writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.NotFound, nil)
+	}
+}
+
+// @Summary Get reservation status history
+// @Description Get the ordered status change history of a reservation (only owner or admin)
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {array} types.StatusChange
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/history [get]
+func (s *Server) handleGetReservationHistory(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationIDStr := r.PathValue("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	history, err := s.db.StatusHistoryQ().GetByReservationID(r.Context(), reservationID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get reservation status history")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, history)
+}
+
+// @Summary Get reservation PDF
+// @Description Download a printable one-page confirmation of a reservation (only owner or admin)
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Reservation ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/pdf [get]
+func (s *Server) handleGetReservationPDF(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationIDStr := r.PathValue("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	document := pdf.RenderReservation(s.reservationPolicy.RestaurantName, reservation)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reservation-%s.pdf"`, reservation.ID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(document); err != nil {
+		s.log.WithError(err).Debug("failed to write reservation pdf")
+	}
+}
+
+// @Summary Get deleted reservations
+// @Description Get soft-deleted reservations (admin only)
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} types.Reservation
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/deleted [get]
+func (s *Server) handleGetDeletedReservations(w http.ResponseWriter, r *http.Request) {
+	reservations, err := s.db.ReservationQ().GetDeleted(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get deleted reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, reservations)
+}
+
+// @Summary Restore reservation
+// @Description Restore a soft-deleted reservation (admin only)
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} types.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/restore [post]
+func (s *Server) handleRestoreReservation(w http.ResponseWriter, r *http.Request) {
+	reservationIDStr := r.PathValue("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	if err := s.db.ReservationQ().Restore(r.Context(), reservationID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.DeletedReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to restore reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get restored reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	s.invalidateReservationCache(r.Context(), reservation)
+	s.logAction(r, actionReservationRestored, reservationFields(reservation))
+
+	writeJSONResponse(w, http.StatusOK, reservation)
+}
+
+// @Summary Resend reservation confirmation
+// @Description Send the confirmation of a pending or confirmed reservation to the guest again (owner or admin).
+// @Description Resends are rate limited per reservation
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} ResendConfirmationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/resend-confirmation [post]
+func (s *Server) handleResendConfirmation(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid reservation ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidReservationID, nil)
+		return
+	}
+
+	reservation, err := s.db.ReservationQ().GetByID(r.Context(), reservationID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.ReservationNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if user.Role != adminRole && !reservation.OwnedBy(user.ID) {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	if reservation.Status != types.ReservationStatusPending && reservation.Status != types.ReservationStatusConfirmed {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"status": i18n.Msg(i18n.ConfirmationNotResendable, reservation.Status),
+		})
+		return
+	}
+
+	resends, err := s.cache.ReservationCache().IncrementConfirmationResends(r.Context(), reservationID, resendConfirmationWindow)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to count confirmation resends")
+	} else if resends > resendConfirmationLimit {
+		w.Header().Set("Retry-After", strconv.Itoa(int(resendConfirmationWindow.Seconds())))
+		writeErrorResponse(w, r, http.StatusTooManyRequests, codeRateLimited, i18n.TooManyConfirmationResends, nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), notificationTimeout)
+	defer cancel()
+
+	if err := s.notifier.SendReservationConfirmation(ctx, reservation); err != nil {
+		s.log.WithError(err).WithField("reservation_id", reservationID).Error("failed to resend reservation confirmation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionConfirmationResent, logan.F{"reservation_id": reservationID})
+
+	writeJSONResponse(w, http.StatusOK, ResendConfirmationResponse{
+		Message: "Confirmation resent successfully",
 	})
 }
+
+// invalidateReservationCache drops cached entries affected by a change to the reservations.
+// Filtered lists are invalidated once, however many reservations changed, and available tables
+// only for the dates the reservations are on
+func (s *Server) invalidateReservationCache(ctx context.Context, reservations ...*types.Reservation) {
+	dates := make([]time.Time, 0, len(reservations))
+	for _, reservation := range reservations {
+		dates = append(dates, reservation.Date)
+		if err := s.cache.ReservationCache().DeleteReservation(ctx, reservation.ID); err != nil {
+			s.log.WithError(err).Warn("failed to invalidate reservation cache")
+		}
+		if reservation.UserID != nil {
+			if err := s.cache.ReservationCache().InvalidateUserReservations(ctx, *reservation.UserID); err != nil {
+				s.log.WithError(err).Warn("failed to invalidate user reservations cache")
+			}
+			if err := s.cache.ReportCache().InvalidateUserStats(ctx, *reservation.UserID); err != nil {
+				s.log.WithError(err).Warn("failed to invalidate user stats cache")
+			}
+		}
+		if err := s.cache.ReportCache().InvalidateMonthlyStats(ctx, reservation.Date.Format("2006-01")); err != nil {
+			s.log.WithError(err).Warn("failed to invalidate monthly stats cache")
+		}
+		s.publishInvalidation(cache.EntityReservation, reservation.ID.String())
+	}
+	if err := s.cache.ReservationCache().InvalidateReservationLists(ctx); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate reservation lists cache")
+	}
+	s.invalidateAvailableTables(ctx, dates...)
+}
+
+// sanitizeReservationForUser returns the reservation as the viewer may see it. Admins see
+// every field and owners everything but the internal staff notes. Anyone else also loses
+// the guest contact details, the confirmation code and the owner ID. Redacted fields are
+// cleared on a copy, so cached or shared reservations are never modified
+func sanitizeReservationForUser(reservation *types.Reservation, viewer *types.User) *types.Reservation {
+	if reservation == nil || (viewer != nil && viewer.Role == adminRole) {
+		return reservation
+	}
+
+	sanitized := *reservation
+	sanitized.StaffNotes = nil
+	if viewer == nil || !reservation.OwnedBy(viewer.ID) {
+		sanitized.UserID = nil
+		sanitized.GuestPhone = ""
+		sanitized.GuestEmail = ""
+		sanitized.ConfirmationCode = nil
+	}
+	return &sanitized
+}
+
+// sanitizeReservationsForUser applies sanitizeReservationForUser to every reservation of a listing
+func sanitizeReservationsForUser(reservations []*types.Reservation, viewer *types.User) []*types.Reservation {
+	if viewer != nil && viewer.Role == adminRole {
+		return reservations
+	}
+	sanitized := make([]*types.Reservation, len(reservations))
+	for i, reservation := range reservations {
+		sanitized[i] = sanitizeReservationForUser(reservation, viewer)
+	}
+	return sanitized
+}
+
+// reservationFiltersFromQuery builds reservation listing filters from the request query.
+// Malformed values are ignored
+func reservationFiltersFromQuery(r *http.Request) *types.ReservationFilters {
+	filters := &types.ReservationFilters{}
+	if status := r.URL.Query().Get("status"); status != "" {
+		filters.Status = &status
+	}
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
+			filters.Date = &date
+		}
+	}
+	if search := r.URL.Query().Get("search"); search != "" {
+		filters.Search = &search
+	}
+	if tableNumber := r.URL.Query().Get("tableNumber"); tableNumber != "" {
+		filters.TableNumber = &tableNumber
+	}
+	if minGuestsStr := r.URL.Query().Get("minGuests"); minGuestsStr != "" {
+		if minGuests, err := strconv.Atoi(minGuestsStr); err == nil && minGuests > 0 {
+			filters.MinGuests = &minGuests
+		}
+	}
+	if guestsStr := r.URL.Query().Get("guests"); guestsStr != "" {
+		if guests, err := strconv.Atoi(guestsStr); err == nil && guests > 0 {
+			filters.Guests = &guests
+		}
+	}
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		filters.Sort = &sort
+	}
+	if when := r.URL.Query().Get("when"); when != "" && when != "all" {
+		filters.When = &when
+	}
+
+	return filters
+}
+
+// buildReservationListKey builds the cache key of a reservation listing scoped to userID,
+// or to all reservations when userID is nil. The key lists every set filter in a fixed
+// order, so equal filters always produce the same key regardless of the query parameter order
+func buildReservationListKey(userID *uuid.UUID, filters *types.ReservationFilters) string {
+	values := url.Values{}
+	if userID != nil {
+		values.Set("user", userID.String())
+	} else {
+		values.Set("user", "all")
+	}
+
+	if filters != nil {
+		if filters.Status != nil {
+			values.Set("status", *filters.Status)
+		}
+		if filters.Date != nil {
+			values.Set("date", filters.Date.Format("2006-01-02"))
+		}
+		if filters.Search != nil {
+			values.Set("search", *filters.Search)
+		}
+		if filters.Sort != nil {
+			values.Set("sort", *filters.Sort)
+		}
+		if filters.TableNumber != nil {
+			values.Set("tableNumber", *filters.TableNumber)
+		}
+		if filters.MinGuests != nil {
+			values.Set("minGuests", strconv.Itoa(*filters.MinGuests))
+		}
+		if filters.Guests != nil {
+			values.Set("guests", strconv.Itoa(*filters.Guests))
+		}
+		if filters.When != nil {
+			values.Set("when", *filters.When)
+		}
+	}
+
+	// Encode sorts by parameter name and escapes the values, so filters cannot collide
+	return values.Encode()
+}
+
+// sendReservationConfirmation notifies the guest about the reservation.
+// It is meant to be run in a separate goroutine, so failures are only logged
+func (s *Server) sendReservationConfirmation(ctx context.Context, reservation *types.Reservation) {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	if err := s.notifier.SendReservationConfirmation(ctx, reservation); err != nil {
+		s.log.WithError(err).WithField("reservation_id", reservation.ID).Warn("failed to send reservation confirmation")
+	}
+}
+
+// normalizeTableNumbers builds the table set of a reservation: the primary table first,
+// followed by the remaining requested tables, trimmed and without duplicates
+func normalizeTableNumbers(primary string, tableNumbers []string) []string {
+	result := make([]string, 0, len(tableNumbers)+1)
+	for _, tableNumber := range append([]string{primary}, tableNumbers...) {
+		tableNumber = strings.TrimSpace(tableNumber)
+		if tableNumber == "" || slices.Contains(result, tableNumber) {
+			continue
+		}
+		result = append(result, tableNumber)
+	}
+	return result
+}
+
+// reservationTableNumbers returns every table the reservation occupies
+func reservationTableNumbers(reservation *types.Reservation) []string {
+	if len(reservation.TableNumbers) > 0 {
+		return reservation.TableNumbers
+	}
+	return []string{reservation.TableNumber}
+}
+
+// validateTables checks that every requested table exists and is in service and, for merged tables,
+// that their combined capacity fits the party. It returns validation details on failure,
+// or an error when the tables cannot be looked up
+func (s *Server) validateTables(ctx context.Context, tableNumbers []string, guests int) (i18n.Details, error) {
+	field := "tableNumber"
+	if len(tableNumbers) > 1 {
+		field = "tableNumbers"
+	}
+
+	totalCapacity := 0
+	for _, tableNumber := range tableNumbers {
+		table, err := s.db.TableQ().GetByNumber(ctx, tableNumber)
+		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				return i18n.Details{
+					field: i18n.Msg(i18n.TableNumberNotFound, tableNumber),
+				}, nil
+			}
+			return nil, fmt.Errorf("failed to get table %s: %w", tableNumber, err)
+		}
+		if detail := tableOutOfService(table); !detail.IsZero() {
+			return i18n.Details{field: detail}, nil
+		}
+		totalCapacity += table.Capacity
+	}
+
+	if len(tableNumbers) > 1 && guests > 0 && totalCapacity < guests {
+		return i18n.Details{
+			field: i18n.Msg(i18n.CombinedCapacityTooSmall, totalCapacity),
+		}, nil
+	}
+	return nil, nil
+}
+
+// tableOutOfService returns a validation detail when a table cannot take reservations
+// because of its service status, or a zero message when it is active
+func tableOutOfService(table *types.Table) i18n.Message {
+	switch table.Status {
+	case types.TableStatusMaintenance:
+		return i18n.Msg(i18n.TableUnderMaintenance, table.Number)
+	case types.TableStatusRetired:
+		return i18n.Msg(i18n.TableRetired, table.Number)
+	}
+	return i18n.Message{}
+}
+
+// sameClock reports whether two times of day are equal, whatever their precision, e.g. "19:00" and "19:00:00"
+func sameClock(a, b string) bool {
+	offsetA, errA := parseClock(a)
+	offsetB, errB := parseClock(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return offsetA == offsetB
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReservationListKey(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	status := "confirmed"
+	otherStatus := "pending"
+	date := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+	minGuests := 4
+	search := "a&status=pending"
+
+	base := buildReservationListKey(&userID, &types.ReservationFilters{Status: &status, Date: &date})
+
+	t.Run("stable for equal filters", func(t *testing.T) {
+		otherDate := date
+		assert.Equal(t, base, buildReservationListKey(&userID, &types.ReservationFilters{Status: &status, Date: &otherDate}))
+	})
+
+	t.Run("ignores query parameter order", func(t *testing.T) {
+		first := reservationFiltersFromQuery(httptest.NewRequest(http.MethodGet, "/reservations?status=confirmed&minGuests=4&date=2025-12-25", nil))
+		second := reservationFiltersFromQuery(httptest.NewRequest(http.MethodGet, "/reservations?date=2025-12-25&status=confirmed&minGuests=4", nil))
+		assert.Equal(t, buildReservationListKey(nil, first), buildReservationListKey(nil, second))
+	})
+
+	t.Run("changes with any filter", func(t *testing.T) {
+		keys := []string{
+			base,
+			buildReservationListKey(nil, &types.ReservationFilters{Status: &status, Date: &date}),
+			buildReservationListKey(&userID, &types.ReservationFilters{Status: &otherStatus, Date: &date}),
+			buildReservationListKey(&userID, &types.ReservationFilters{Status: &status}),
+			buildReservationListKey(&userID, &types.ReservationFilters{Status: &status, Date: &date, MinGuests: &minGuests}),
+			buildReservationListKey(&userID, &types.ReservationFilters{Status: &status, Date: &date, Search: &search}),
+			buildReservationListKey(&userID, &types.ReservationFilters{}),
+		}
+		seen := make(map[string]bool)
+		for _, key := range keys {
+			assert.False(t, seen[key], "duplicate key %q", key)
+			seen[key] = true
+		}
+	})
+
+	t.Run("nil filters match empty filters", func(t *testing.T) {
+		assert.Equal(t, buildReservationListKey(&userID, &types.ReservationFilters{}), buildReservationListKey(&userID, nil))
+	})
+}
+
+func TestSanitizeReservationForUser(t *testing.T) {
+	notes := "VIP"
+	code := "ABC123"
+	owner := &types.User{ID: uuid.New(), Role: "user"}
+	other := &types.User{ID: uuid.New(), Role: "user"}
+	admin := &types.User{ID: uuid.New(), Role: adminRole}
+	newReservation := func() *types.Reservation {
+		return &types.Reservation{
+			ID:               uuid.New(),
+			UserID:           &owner.ID,
+			GuestName:        "Alice",
+			GuestPhone:       "+380501234567",
+			GuestEmail:       "alice@example.com",
+			ConfirmationCode: &code,
+			StaffNotes:       &notes,
+		}
+	}
+
+	t.Run("admin sees every field", func(t *testing.T) {
+		reservation := newReservation()
+		assert.Same(t, reservation, sanitizeReservationForUser(reservation, admin))
+	})
+
+	t.Run("owner sees contact details but not staff notes", func(t *testing.T) {
+		reservation := newReservation()
+		sanitized := sanitizeReservationForUser(reservation, owner)
+		assert.Nil(t, sanitized.StaffNotes)
+		assert.Equal(t, "+380501234567", sanitized.GuestPhone)
+		assert.Equal(t, "alice@example.com", sanitized.GuestEmail)
+		assert.Equal(t, &code, sanitized.ConfirmationCode)
+		assert.Equal(t, &owner.ID, sanitized.UserID)
+		assert.Equal(t, &notes, reservation.StaffNotes, "the original reservation must not be modified")
+	})
+
+	t.Run("non-owner viewing a shared list cannot see contact details", func(t *testing.T) {
+		reservations := []*types.Reservation{newReservation(), newReservation()}
+		sanitized := sanitizeReservationsForUser(reservations, other)
+		require.Len(t, sanitized, 2)
+		for i, reservation := range sanitized {
+			assert.Equal(t, "Alice", reservation.GuestName)
+			assert.Empty(t, reservation.GuestPhone)
+			assert.Empty(t, reservation.GuestEmail)
+			assert.Nil(t, reservation.ConfirmationCode)
+			assert.Nil(t, reservation.UserID)
+			assert.Nil(t, reservation.StaffNotes)
+			assert.Equal(t, "+380501234567", reservations[i].GuestPhone)
+		}
+	})
+
+	t.Run("anonymous viewer is treated as a non-owner", func(t *testing.T) {
+		sanitized := sanitizeReservationForUser(newReservation(), nil)
+		assert.Empty(t, sanitized.GuestPhone)
+		assert.Nil(t, sanitized.UserID)
+	})
+}
+
+func TestRebookRequest(t *testing.T) {
+	requests := "Window seat"
+	original := &types.Reservation{
+		ID:              uuid.New(),
+		GuestName:       "Alice",
+		GuestPhone:      "+380501234567",
+		GuestEmail:      "alice@example.com",
+		Date:            time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC),
+		Time:            "19:30:00",
+		Guests:          6,
+		TableNumber:     "T1",
+		TableNumbers:    []string{"T1", "T2"},
+		Status:          "completed",
+		SpecialRequests: &requests,
+	}
+
+	req := rebookRequest(original, "2025-12-25")
+
+	assert.Equal(t, CreateReservationRequest{
+		GuestName:       "Alice",
+		GuestPhone:      "+380501234567",
+		GuestEmail:      "alice@example.com",
+		Date:            "2025-12-25",
+		Time:            "19:30",
+		Guests:          6,
+		TableNumber:     "T1",
+		TableNumbers:    []string{"T1", "T2"},
+		SpecialRequests: &requests,
+	}, req)
+
+	// The table set is copied, so validating the request cannot change the original
+	req.TableNumbers[1] = "T3"
+	assert.Equal(t, "T2", original.TableNumbers[1])
+}
+
+func TestSameClock(t *testing.T) {
+	assert.True(t, sameClock("19:00:00", "19:00"))
+	assert.True(t, sameClock("19:00", "19:00"))
+	assert.False(t, sameClock("19:00:00", "19:30"))
+	assert.False(t, sameClock("invalid", "19:00"))
+}
+
+func TestDuplicateDetails(t *testing.T) {
+	owner := &types.User{ID: uuid.New()}
+	duplicate := &types.Reservation{ID: uuid.New(), UserID: &owner.ID, Time: "19:00:00"}
+
+	details := duplicateDetails(duplicate, owner, "2025-12-25").Translate(i18n.English)
+	assert.Equal(t, duplicate.ID.String(), details["reservationId"])
+	assert.Equal(t, "A reservation under this phone already exists at 19:00:00 on 2025-12-25", details["guestPhone"])
+
+	assert.Nil(t, duplicateDetails(duplicate, &types.User{ID: uuid.New()}, "2025-12-25"))
+	assert.Nil(t, duplicateDetails(&types.Reservation{ID: uuid.New()}, owner, "2025-12-25"))
+}
+
+func TestTableOutOfService(t *testing.T) {
+	assert.Empty(t, tableOutOfService(&types.Table{Number: "T1", Status: types.TableStatusActive}))
+	assert.Equal(t, "Table T1 is under maintenance and cannot be booked", tableOutOfService(&types.Table{Number: "T1", Status: types.TableStatusMaintenance}).Translate(i18n.English))
+	assert.Equal(t, "Table T1 is retired and cannot be booked", tableOutOfService(&types.Table{Number: "T1", Status: types.TableStatusRetired}).Translate(i18n.English))
+}
@@ -0,0 +1,427 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// maxImportRows limits how many reservations a single import may contain
+	maxImportRows = 5000
+	// maxImportBodySize caps import request bodies, which are larger than regular ones
+	maxImportBodySize = 10 << 20
+)
+
+// ImportReservationRow is a single reservation of an import. Status defaults to completed
+// for reservations that already took place and to the initial status of new reservations for upcoming ones
+type ImportReservationRow struct {
+	GuestName       string  `json:"guestName"`
+	GuestPhone      string  `json:"guestPhone"`
+	GuestEmail      string  `json:"guestEmail"`
+	Date            string  `json:"date"`
+	Time            string  `json:"time"`
+	Guests          int     `json:"guests"`
+	TableNumber     string  `json:"tableNumber"`
+	Status          string  `json:"status,omitempty"`
+	SpecialRequests *string `json:"specialRequests,omitempty"`
+}
+
+// ImportRowResult reports the outcome of a single import row. Rows are numbered from 1,
+// not counting the CSV header
+type ImportRowResult struct {
+	Row    int               `json:"row"`
+	Result string            `json:"result"`
+	ID     string            `json:"id,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+type ImportReservationsResponse struct {
+	Imported int               `json:"imported"`
+	Results  []ImportRowResult `json:"results"`
+}
+
+// @Summary Import reservations
+// @Description Import historical reservations from a legacy system (admin only) as a JSON array or,
+// @Description with Content-Type text/csv, a CSV file whose header row names the fields.
+// @Description The import is all-or-nothing: if any row is invalid, none are stored.
+// @Description Availability is only checked for upcoming reservations. Imported reservations have no owner and no confirmation code
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param body body []ImportReservationRow true "Reservations to import"
+// @Success 200 {object} ImportReservationsResponse
+// @Failure 400 {object} ImportReservationsResponse "Some rows are invalid, nothing was imported"
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/import [post]
+func (s *Server) handleImportReservations(w http.ResponseWriter, r *http.Request) {
+	rows, err := decodeImportRows(w, r)
+	if err != nil {
+		s.log.WithError(err).Debug("failed to decode import body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	if len(rows) == 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"rows": i18n.Msg(i18n.ImportRowsMissing),
+		})
+		return
+	}
+	if len(rows) > maxImportRows {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"rows": i18n.Msg(i18n.ImportTooManyRows, maxImportRows),
+		})
+		return
+	}
+
+	imp := &reservationImport{
+		now:    s.reservationPolicy.Now(),
+		tables: make(map[string]bool),
+		booked: make(map[string]int),
+		seated: make(map[string][]time.Duration),
+	}
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	results := make([]ImportRowResult, len(rows))
+	reservations := make([]*types.Reservation, 0, len(rows))
+	hasFailures := false
+
+	for i, row := range rows {
+		results[i].Row = i + 1
+
+		reservation, details, err := s.validateImportRow(r.Context(), imp, results[i].Row, row)
+		if err != nil {
+			s.log.WithError(err).WithField("row", results[i].Row).Error("failed to validate import row")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if len(details) > 0 {
+			results[i].Result, results[i].Errors = bulkResultFailed, details.Translate(lang)
+			hasFailures = true
+			continue
+		}
+
+		results[i].Result, results[i].ID = bulkResultSucceeded, reservation.ID.String()
+		reservations = append(reservations, reservation)
+	}
+
+	// Nothing is imported when at least one row is invalid, so the corrected file can simply be sent again
+	if hasFailures {
+		for i := range results {
+			if results[i].Result == bulkResultSucceeded {
+				results[i].Result, results[i].ID = bulkResultFailed, ""
+				results[i].Errors = i18n.Details{"row": i18n.Msg(i18n.RowNotImported)}.Translate(lang)
+			}
+		}
+		writeJSONResponse(w, http.StatusBadRequest, ImportReservationsResponse{Results: results})
+		return
+	}
+
+	if err := s.db.ReservationQ().BulkCreate(r.Context(), reservations, s.reservationPolicy.InitialStatus()); err != nil {
+		s.log.WithError(err).WithField("reservations", len(reservations)).Error("failed to import reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// Imported reservations have no owner, so only the shared lists and reports are affected
+	if err := s.cache.ReservationCache().InvalidateReservationLists(r.Context()); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate reservation lists cache")
+	}
+	if err := s.cache.ReportCache().InvalidateAllStats(r.Context()); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate stats cache")
+	}
+	dates := make([]time.Time, 0, len(reservations))
+	for _, reservation := range reservations {
+		dates = append(dates, reservation.Date)
+	}
+	s.invalidateAvailableTables(r.Context(), dates...)
+	s.logAction(r, actionReservationsImported, logan.F{"reservations": len(reservations)})
+
+	writeJSONResponse(w, http.StatusOK, ImportReservationsResponse{Imported: len(reservations), Results: results})
+}
+
+// reservationImport holds the state shared by the rows of a single import
+type reservationImport struct {
+	now time.Time
+	// tables caches whether each referenced table number exists
+	tables map[string]bool
+	// booked maps the table, date and time of every upcoming row to its row number,
+	// so that rows of the same import cannot double-book a table
+	booked map[string]int
+	// seated lists the start times of the upcoming active rows by date, so that rows
+	// of the same import count towards the concurrent parties cap
+	seated map[string][]time.Duration
+}
+
+// validateImportRow validates a single import row and builds its reservation.
+// Validation failures are returned as details, the error is reserved for internal failures
+func (s *Server) validateImportRow(ctx context.Context, imp *reservationImport, rowNumber int, row ImportReservationRow) (*types.Reservation, i18n.Details, error) {
+	v := validation.New()
+	row.GuestName = strings.TrimSpace(row.GuestName)
+	row.GuestPhone = normalizePhone(row.GuestPhone)
+	row.GuestEmail = strings.TrimSpace(row.GuestEmail)
+	row.TableNumber = strings.TrimSpace(row.TableNumber)
+	row.Status = strings.TrimSpace(row.Status)
+	if row.SpecialRequests != nil && strings.TrimSpace(*row.SpecialRequests) == "" {
+		row.SpecialRequests = nil
+	}
+
+	if row.GuestName == "" {
+		v.Add("guestName", i18n.GuestNameMissing)
+	}
+	if row.GuestPhone == "" {
+		v.Add("guestPhone", i18n.GuestPhoneMissing)
+	} else if !isValidPhone(row.GuestPhone) {
+		v.Add("guestPhone", i18n.InvalidPhoneFormat)
+	}
+	if row.GuestEmail == "" {
+		v.Add("guestEmail", i18n.GuestEmailMissing)
+	} else if !isValidEmail(row.GuestEmail) {
+		v.Add("guestEmail", i18n.InvalidEmailFormat)
+	}
+	date, dateErr := time.Parse("2006-01-02", row.Date)
+	if row.Date == "" {
+		v.Add("date", i18n.DateMissing)
+	} else if dateErr != nil {
+		v.Add("date", i18n.InvalidDateFormat)
+	}
+	if row.Time == "" {
+		v.Add("time", i18n.TimeMissing)
+	} else if _, err := time.Parse("15:04", row.Time); err != nil {
+		v.Add("time", i18n.InvalidTimeFormat)
+	}
+	if row.Guests <= 0 {
+		v.Add("guests", i18n.GuestsNotPositive)
+	}
+	if row.Status != "" && !isValidReservationStatus(row.Status) {
+		v.Add("status", i18n.InvalidStatus)
+	}
+
+	if row.TableNumber == "" {
+		v.Add("tableNumber", i18n.TableNumberMissing)
+	} else {
+		exists, ok := imp.tables[row.TableNumber]
+		if !ok {
+			_, err := s.db.TableQ().GetByNumber(ctx, row.TableNumber)
+			if err != nil && !errors.Is(err, data.ErrNotFound) {
+				return nil, nil, err
+			}
+			exists = err == nil
+			imp.tables[row.TableNumber] = exists
+		}
+		if !exists {
+			v.Add("tableNumber", i18n.TableNotFound)
+		}
+	}
+
+	if v.HasErrors() {
+		return nil, v.Map(), nil
+	}
+
+	start, err := s.reservationPolicy.ReservationStart(date, row.Time)
+	if err != nil {
+		return nil, i18n.Details{"time": i18n.Msg(i18n.InvalidTimeFormat)}, nil
+	}
+	upcoming := start.After(imp.now)
+	if row.Status == "" {
+		row.Status = "completed"
+		if upcoming {
+			row.Status = s.reservationPolicy.InitialStatus()
+		}
+	}
+
+	// Past reservations are history and are stored as they are. Upcoming active ones
+	// must not collide with existing bookings or with other rows of the import
+	if upcoming && (row.Status == "pending" || row.Status == "confirmed") {
+		slot := row.TableNumber + "|" + row.Date + "|" + row.Time
+		if other, ok := imp.booked[slot]; ok {
+			return nil, i18n.Details{"tableNumber": i18n.Msg(i18n.TableBookedByRow, other)}, nil
+		}
+		available, err := s.db.ReservationQ().CheckTableAvailability(ctx, row.TableNumber, row.Date, row.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !available {
+			return nil, i18n.Details{"tableNumber": i18n.Msg(i18n.TableBookedAtTime)}, nil
+		}
+		detail, err := s.checkImportConcurrentParties(ctx, imp, row.Date, row.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !detail.IsZero() {
+			return nil, i18n.Details{"time": detail}, nil
+		}
+		imp.booked[slot] = rowNumber
+		at, _ := parseClock(row.Time)
+		imp.seated[row.Date] = append(imp.seated[row.Date], at)
+	}
+
+	return &types.Reservation{
+		ID:              uuid.New(),
+		GuestName:       row.GuestName,
+		GuestPhone:      row.GuestPhone,
+		GuestEmail:      row.GuestEmail,
+		Date:            date,
+		Time:            row.Time,
+		Guests:          row.Guests,
+		TableNumber:     row.TableNumber,
+		Status:          row.Status,
+		SpecialRequests: row.SpecialRequests,
+		CreatedAt:       imp.now,
+		UpdatedAt:       imp.now,
+	}, nil, nil
+}
+
+// checkImportConcurrentParties returns a validation detail when the restaurant already seats the maximum
+// number of parties at the time of an import row, counting both stored reservations and earlier rows
+func (s *Server) checkImportConcurrentParties(ctx context.Context, imp *reservationImport, date, clock string) (i18n.Message, error) {
+	if s.reservationPolicy.MaxConcurrentParties <= 0 {
+		return i18n.Message{}, nil
+	}
+
+	window := s.reservationPolicy.SlotInterval()
+	concurrent, err := s.db.ReservationQ().CountConcurrentAt(ctx, date, clock, window, uuid.Nil)
+	if err != nil {
+		return i18n.Message{}, err
+	}
+
+	at, _ := parseClock(clock)
+	for _, other := range imp.seated[date] {
+		if diff := other - at; diff > -window && diff < window {
+			concurrent++
+		}
+	}
+
+	if concurrent >= s.reservationPolicy.MaxConcurrentParties {
+		return i18n.Msg(i18n.PartiesSeatedLimit, concurrent, clock, s.reservationPolicy.MaxConcurrentParties), nil
+	}
+	return i18n.Message{}, nil
+}
+
+// decodeImportRows reads the rows of an import from a CSV body when the request
+// is sent as text/csv and from a JSON array otherwise
+func decodeImportRows(w http.ResponseWriter, r *http.Request) ([]ImportReservationRow, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "text/csv" {
+		var rows []ImportReservationRow
+		if err := decodeJSONWithLimit(w, r, &rows, maxImportBodySize); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+
+	rows, err := parseImportCSV(http.MaxBytesReader(w, r.Body, maxImportBodySize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return nil, i18n.Errorf(i18n.BodyTooLarge, maxBytesErr.Limit)
+	}
+	return rows, err
+}
+
+// importCSVColumns maps the lower-cased CSV header names to the row fields they fill
+var importCSVColumns = map[string]func(row *ImportReservationRow, value string) error{
+	"guestname":  func(row *ImportReservationRow, value string) error { row.GuestName = value; return nil },
+	"guestphone": func(row *ImportReservationRow, value string) error { row.GuestPhone = value; return nil },
+	"guestemail": func(row *ImportReservationRow, value string) error { row.GuestEmail = value; return nil },
+	"date":       func(row *ImportReservationRow, value string) error { row.Date = value; return nil },
+	"time":       func(row *ImportReservationRow, value string) error { row.Time = value; return nil },
+	"guests": func(row *ImportReservationRow, value string) error {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		guests, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return i18n.Errorf(i18n.CSVGuestsNotNumber)
+		}
+		row.Guests = guests
+		return nil
+	},
+	"tablenumber": func(row *ImportReservationRow, value string) error { row.TableNumber = value; return nil },
+	"status":      func(row *ImportReservationRow, value string) error { row.Status = value; return nil },
+	"specialrequests": func(row *ImportReservationRow, value string) error {
+		if value != "" {
+			row.SpecialRequests = &value
+		}
+		return nil
+	},
+}
+
+// parseImportCSV parses a CSV import. The header row names the columns using the JSON field
+// names in any letter case. Malformed files are rejected as a whole, with a message meant
+// to be shown to the client
+func parseImportCSV(body io.Reader) ([]ImportReservationRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, i18n.Errorf(i18n.CSVHeaderMissing)
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+
+	// A leading byte order mark, as written by spreadsheet software, is not part of the first column name
+	setters := make([]func(row *ImportReservationRow, value string) error, len(header))
+	for i, name := range header {
+		setter, ok := importCSVColumns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))]
+		if !ok {
+			return nil, i18n.Errorf(i18n.CSVUnknownColumn, name)
+		}
+		setters[i] = setter
+	}
+
+	var rows []ImportReservationRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+		// Bound the work done for oversized files, the caller rejects them anyway
+		if len(rows) > maxImportRows {
+			return rows, nil
+		}
+
+		var row ImportReservationRow
+		for i, value := range record {
+			if err := setters[i](&row, value); err != nil {
+				return nil, i18n.Errorf(i18n.CSVRowInvalid, len(rows)+1, i18n.MessageOf(err))
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// csvError describes a CSV parse failure for the client
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return err
+	case errors.As(err, &parseErr):
+		return i18n.Errorf(i18n.CSVMalformedAt, parseErr.Line, parseErr.Err.Error())
+	default:
+		return i18n.Errorf(i18n.CSVMalformed)
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	t.Run("maps columns by header name", func(t *testing.T) {
+		body := "\ufeffTableNumber,guestName,guestPhone,guestEmail,date,time,guests,status,specialRequests\n" +
+			"T1,Alice,+380501234567,alice@example.com,2024-03-10,19:00,4,completed,\"Window seat, please\"\n" +
+			"T2,Bob,+380507654321,bob@example.com,2024-03-11,20:30,2,,\n"
+
+		rows, err := parseImportCSV(strings.NewReader(body))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, "T1", rows[0].TableNumber)
+		assert.Equal(t, "Alice", rows[0].GuestName)
+		assert.Equal(t, 4, rows[0].Guests)
+		assert.Equal(t, "completed", rows[0].Status)
+		require.NotNil(t, rows[0].SpecialRequests)
+		assert.Equal(t, "Window seat, please", *rows[0].SpecialRequests)
+
+		assert.Equal(t, "Bob", rows[1].GuestName)
+		assert.Empty(t, rows[1].Status)
+		assert.Nil(t, rows[1].SpecialRequests)
+	})
+
+	t.Run("header only", func(t *testing.T) {
+		rows, err := parseImportCSV(strings.NewReader("guestName,date\n"))
+		assert.NoError(t, err)
+		assert.Empty(t, rows)
+	})
+
+	tests := []struct {
+		name   string
+		body   string
+		errMsg string
+	}{
+		{"empty file", "", "CSV file must start with a header row"},
+		{"unknown column", "guestName,tableId\nAlice,1\n", `CSV file contains unknown column "tableId"`},
+		{"invalid guests", "guestName,guests\nAlice,4\nBob,many\n", "CSV row 2: guests must be a whole number"},
+		{"wrong field count", "guestName,guests\nAlice\n", "CSV file is malformed at line 2: wrong number of fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportCSV(strings.NewReader(tt.body))
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
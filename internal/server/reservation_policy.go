@@ -0,0 +1,325 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+)
+
+// defaultSlotInterval is the spacing of the slot grid when no slot granularity is configured
+const defaultSlotInterval = 30 * time.Minute
+
+// ReservationPolicy describes the business rules a reservation has to satisfy
+type ReservationPolicy struct {
+	// RestaurantName is printed on reservation confirmations
+	RestaurantName string
+	BusinessHours  BusinessHours
+	MinPartySize   int
+	MaxPartySize   int
+	// DuplicateWindow is how close to an existing reservation under the same guest phone
+	// a new one may start before it is treated as a duplicate. Zero disables the check
+	DuplicateWindow time.Duration
+	// MaxConcurrentParties caps the pending and confirmed reservations seated at the same time across
+	// all tables, e.g. to match kitchen throughput. A party is seated for one slot interval from its
+	// start. Zero disables the limit
+	MaxConcurrentParties int
+	// MaxActivePerUser caps the upcoming pending and confirmed reservations a non-admin
+	// user may hold at once. Zero disables the limit
+	MaxActivePerUser int
+	// MaxAdvanceDays is how many days ahead reservations are accepted. Zero disables the limit
+	MaxAdvanceDays int
+	// MinLeadHours is how many hours before its start a reservation must be made. Zero disables the limit
+	MinLeadHours int
+	// AutoConfirmReservations creates new reservations as confirmed instead of pending
+	AutoConfirmReservations bool
+	// Location is the restaurant timezone reservation dates and times are given in.
+	// Nil stands for the server local timezone
+	Location *time.Location
+	// SlotMinutes is the seating granularity. Reservations have to start on a multiple of it
+	// counted from midnight and the slot grid steps by it. Zero accepts any minute and
+	// keeps the default 30-minute grid
+	SlotMinutes int
+}
+
+// SlotInterval returns the spacing of the slot grid offered for a date
+func (p ReservationPolicy) SlotInterval() time.Duration {
+	if p.SlotMinutes <= 0 {
+		return defaultSlotInterval
+	}
+	return time.Duration(p.SlotMinutes) * time.Minute
+}
+
+// ValidateSlotBoundary returns a validation detail when a reservation time does not fall on
+// a slot boundary, or a zero message when it does. Malformed times are left to the caller
+func (p ReservationPolicy) ValidateSlotBoundary(clock string) i18n.Message {
+	if p.SlotMinutes <= 0 {
+		return i18n.Message{}
+	}
+	at, err := parseClock(clock)
+	if err != nil {
+		return i18n.Message{}
+	}
+	if at%(time.Duration(p.SlotMinutes)*time.Minute) != 0 {
+		return i18n.Msg(i18n.SlotBoundary, p.SlotMinutes, formatClock(at-at%p.SlotInterval()))
+	}
+	return i18n.Message{}
+}
+
+// location returns the restaurant timezone
+func (p ReservationPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.Local
+	}
+	return p.Location
+}
+
+// Now returns the current moment on the restaurant's wall clock
+func (p ReservationPolicy) Now() time.Time {
+	return time.Now().In(p.location())
+}
+
+// ReservationStart combines a reservation date and time of day into the moment it starts in the restaurant timezone
+func (p ReservationPolicy) ReservationStart(date time.Time, clock string) (time.Time, error) {
+	return types.ZonedTime(date, clock, p.location())
+}
+
+// InitialStatus returns the status new reservations are created in
+func (p ReservationPolicy) InitialStatus() string {
+	if p.AutoConfirmReservations {
+		return types.ReservationStatusConfirmed
+	}
+	return types.DefaultReservationStatus
+}
+
+// ValidateBookingWindow returns a validation field and detail when a reservation starting at the given
+// moment is too far ahead of now or too close to it, or an empty field and a zero message when it is allowed
+func (p ReservationPolicy) ValidateBookingWindow(at, now time.Time) (string, i18n.Message) {
+	if p.MaxAdvanceDays > 0 && at.Sub(now) > time.Duration(p.MaxAdvanceDays)*24*time.Hour {
+		return "date", i18n.Msg(i18n.BookingTooFarAhead, p.MaxAdvanceDays)
+	}
+	if p.MinLeadHours > 0 && at.Sub(now) < time.Duration(p.MinLeadHours)*time.Hour {
+		return "time", i18n.Msg(i18n.BookingTooLate, p.MinLeadHours)
+	}
+	return "", i18n.Message{}
+}
+
+// FindDuplicate returns the reservation among existing, all made under the same phone on the same date,
+// that starts within the duplicate window of the given time, or nil when there is none
+func (p ReservationPolicy) FindDuplicate(existing []*types.Reservation, clock string) *types.Reservation {
+	if p.DuplicateWindow <= 0 {
+		return nil
+	}
+	at, err := parseClock(clock)
+	if err != nil {
+		return nil
+	}
+
+	for _, reservation := range existing {
+		offset, err := parseClock(reservation.Time)
+		if err != nil {
+			continue
+		}
+		diff := offset - at
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < p.DuplicateWindow {
+			return reservation
+		}
+	}
+	return nil
+}
+
+// ValidatePartySize returns a validation detail when the number of guests is outside
+// the configured limits, or a zero message when it is allowed. A zero limit is not enforced
+func (p ReservationPolicy) ValidatePartySize(guests int) i18n.Message {
+	if p.MinPartySize > 0 && guests < p.MinPartySize {
+		return i18n.Msg(i18n.PartyTooSmall, p.MinPartySize)
+	}
+	if p.MaxPartySize > 0 && guests > p.MaxPartySize {
+		return i18n.Msg(i18n.PartyTooLarge, p.MaxPartySize)
+	}
+	return i18n.Message{}
+}
+
+// OpeningHours is the part of a day, as offsets from midnight, during which reservations are accepted
+type OpeningHours struct {
+	Closed bool
+	Open   time.Duration
+	Close  time.Duration
+}
+
+// BusinessHours maps a weekday to its opening hours. Weekdays without an entry accept reservations at any time
+type BusinessHours map[time.Weekday]OpeningHours
+
+// ParseOpeningHours parses either "closed" or a "HH:MM-HH:MM" range
+func ParseOpeningHours(value string) (OpeningHours, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "closed") {
+		return OpeningHours{Closed: true}, nil
+	}
+
+	openStr, closeStr, ok := strings.Cut(value, "-")
+	if !ok {
+		return OpeningHours{}, fmt.Errorf("invalid opening hours %q: expected HH:MM-HH:MM or closed", value)
+	}
+
+	open, err := parseClock(strings.TrimSpace(openStr))
+	if err != nil {
+		return OpeningHours{}, fmt.Errorf("invalid opening time %q: %w", openStr, err)
+	}
+	closing, err := parseClock(strings.TrimSpace(closeStr))
+	if err != nil {
+		return OpeningHours{}, fmt.Errorf("invalid closing time %q: %w", closeStr, err)
+	}
+	if closing <= open {
+		return OpeningHours{}, fmt.Errorf("invalid opening hours %q: closing time must be after opening time", value)
+	}
+
+	return OpeningHours{Open: open, Close: closing}, nil
+}
+
+// Validate returns a validation detail when a reservation at the given date and
+// time falls outside business hours, or a zero message when it is allowed
+func (h BusinessHours) Validate(date time.Time, clock string) i18n.Message {
+	hours, ok := h[date.Weekday()]
+	if !ok {
+		return i18n.Message{}
+	}
+	return hours.validate(clock, i18n.Msg(weekdayKeys[date.Weekday()]))
+}
+
+// weekdayKeys name the weekdays business hours apply to in validation details
+var weekdayKeys = map[time.Weekday]i18n.Key{
+	time.Monday:    i18n.OnMondays,
+	time.Tuesday:   i18n.OnTuesdays,
+	time.Wednesday: i18n.OnWednesdays,
+	time.Thursday:  i18n.OnThursdays,
+	time.Friday:    i18n.OnFridays,
+	time.Saturday:  i18n.OnSaturdays,
+	time.Sunday:    i18n.OnSundays,
+}
+
+// Slots returns the slot grid (HH:MM) for the given date, starting at the first multiple of interval
+// from midnight at or after opening time and stepping by interval while the slot still starts before
+// closing time. Days without configured hours span the whole day and closed days have no slots
+func (h BusinessHours) Slots(date time.Time, interval time.Duration) []string {
+	hours, ok := h[date.Weekday()]
+	if !ok {
+		hours = OpeningHours{Open: 0, Close: 24 * time.Hour}
+	}
+	return hours.Slots(interval)
+}
+
+// ValidateOn returns a validation detail when a reservation at the given time falls outside
+// the opening hours of a single date, or a zero message when it is allowed
+func (h OpeningHours) ValidateOn(date time.Time, clock string) i18n.Message {
+	return h.validate(clock, i18n.Msg(i18n.OnDate, date.Format("2006-01-02")))
+}
+
+// validate checks a reservation time against the opening hours, naming the day they apply to in the detail
+func (h OpeningHours) validate(clock string, day i18n.Message) i18n.Message {
+	if h.Closed {
+		return i18n.Msg(i18n.RestaurantClosedOn, day)
+	}
+
+	at, err := parseClock(clock)
+	if err != nil {
+		return i18n.Msg(i18n.InvalidTimeFormat)
+	}
+	if at < h.Open || at >= h.Close {
+		return i18n.Msg(i18n.OutsideOpeningHours, day, formatClock(h.Open), formatClock(h.Close))
+	}
+
+	return i18n.Message{}
+}
+
+// Slots returns the slot grid (HH:MM) within the opening hours, see BusinessHours.Slots
+func (h OpeningHours) Slots(interval time.Duration) []string {
+	slots := make([]string, 0)
+	if interval <= 0 || h.Closed {
+		return slots
+	}
+
+	start := h.Open
+	if offset := start % interval; offset != 0 {
+		start += interval - offset
+	}
+	for at := start; at < h.Close; at += interval {
+		slots = append(slots, formatClock(at))
+	}
+	return slots
+}
+
+// nextFreeSlot returns the first slot after the given time that is not booked,
+// or an empty string when every later slot is taken
+func nextFreeSlot(slots []string, booked []string, after string) string {
+	at, err := parseClock(after)
+	if err != nil {
+		return ""
+	}
+
+	taken := make(map[time.Duration]bool, len(booked))
+	for _, b := range booked {
+		if offset, err := parseClock(b); err == nil {
+			taken[offset] = true
+		}
+	}
+
+	for _, slot := range slots {
+		offset, err := parseClock(slot)
+		if err != nil || offset <= at || taken[offset] {
+			continue
+		}
+		return slot
+	}
+	return ""
+}
+
+// currentSlot returns the latest slot starting at or before the time of day of now. Once the last
+// slot has ended, i.e. after closing time, it returns an empty string. Without an earlier slot, e.g.
+// before opening, the time of day is rounded down to interval instead
+func currentSlot(slots []string, interval time.Duration, now time.Time) string {
+	at := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+
+	current, currentOffset, lastOffset := "", time.Duration(0), time.Duration(-1)
+	for _, slot := range slots {
+		offset, err := parseClock(slot)
+		if err != nil {
+			continue
+		}
+		if offset > lastOffset {
+			lastOffset = offset
+		}
+		if offset > at {
+			continue
+		}
+		current, currentOffset = slot, offset
+	}
+	if current != "" && currentOffset == lastOffset && interval > 0 && at >= currentOffset+interval {
+		return ""
+	}
+	if current != "" || interval <= 0 {
+		return current
+	}
+	return formatClock(at - at%interval)
+}
+
+// parseClock converts "HH:MM" or "HH:MM:SS" into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		t, err = time.Parse("15:04:05", value)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+}
+
+func formatClock(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
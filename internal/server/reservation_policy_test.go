@@ -0,0 +1,307 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpeningHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    OpeningHours
+		wantErr bool
+	}{
+		{name: "range", value: "10:00-22:30", want: OpeningHours{Open: 10 * time.Hour, Close: 22*time.Hour + 30*time.Minute}},
+		{name: "closed", value: "Closed", want: OpeningHours{Closed: true}},
+		{name: "missing separator", value: "10:00", wantErr: true},
+		{name: "invalid time", value: "10:00-25:00", wantErr: true},
+		{name: "closing before opening", value: "22:00-10:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOpeningHours(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBusinessHours_Validate(t *testing.T) {
+	hours := BusinessHours{
+		time.Monday: {Open: 10 * time.Hour, Close: 22 * time.Hour},
+		time.Sunday: {Closed: true},
+	}
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		hours BusinessHours
+		date  time.Time
+		clock string
+		want  string
+	}{
+		{name: "no business hours configured", hours: nil, date: monday, clock: "03:00", want: ""},
+		{name: "within opening hours", hours: hours, date: monday, clock: "19:00", want: ""},
+		{name: "at opening time", hours: hours, date: monday, clock: "10:00:00", want: ""},
+		{name: "at closing time", hours: hours, date: monday, clock: "22:00", want: "Reservations on Mondays are accepted between 10:00 and 22:00"},
+		{name: "before opening", hours: hours, date: monday, clock: "03:00", want: "Reservations on Mondays are accepted between 10:00 and 22:00"},
+		{name: "closed day", hours: hours, date: sunday, clock: "19:00", want: "The restaurant is closed on Sundays"},
+		{name: "unconfigured day", hours: hours, date: tuesday, clock: "03:00", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.hours.Validate(tt.date, tt.clock).Translate(i18n.English))
+		})
+	}
+
+	assert.Equal(t, "Бронювання по понеділках приймаються з 10:00 до 22:00", hours.Validate(monday, "03:00").Translate(i18n.Ukrainian))
+}
+
+func TestBusinessHours_Slots(t *testing.T) {
+	hours := BusinessHours{
+		time.Monday: {Open: 18 * time.Hour, Close: 20 * time.Hour},
+		time.Sunday: {Closed: true},
+	}
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []string{"18:00", "18:30", "19:00", "19:30"}, hours.Slots(monday, 30*time.Minute))
+	assert.Equal(t, []string{"18:00", "19:00"}, hours.Slots(monday, time.Hour))
+	assert.Empty(t, hours.Slots(sunday, 30*time.Minute))
+
+	allDay := hours.Slots(tuesday, 30*time.Minute)
+	require.Len(t, allDay, 48)
+	assert.Equal(t, "00:00", allDay[0])
+	assert.Equal(t, "23:30", allDay[47])
+
+	offGrid := BusinessHours{time.Monday: {Open: 18*time.Hour + 10*time.Minute, Close: 20 * time.Hour}}
+	assert.Equal(t, []string{"18:15", "18:30", "18:45", "19:00", "19:15", "19:30", "19:45"}, offGrid.Slots(monday, 15*time.Minute))
+}
+
+func TestOpeningHours_ValidateOn(t *testing.T) {
+	newYearsEve := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	hours := OpeningHours{Open: 18 * time.Hour, Close: 23*time.Hour + 30*time.Minute}
+
+	assert.Empty(t, hours.ValidateOn(newYearsEve, "18:00"))
+	assert.Empty(t, hours.ValidateOn(newYearsEve, "23:00"))
+	assert.Equal(t, "Reservations on 2025-12-31 are accepted between 18:00 and 23:30", hours.ValidateOn(newYearsEve, "12:00").Translate(i18n.English))
+	assert.Equal(t, "Reservations on 2025-12-31 are accepted between 18:00 and 23:30", hours.ValidateOn(newYearsEve, "23:30").Translate(i18n.English))
+}
+
+func TestOpeningHours_Slots(t *testing.T) {
+	hours := OpeningHours{Open: 21 * time.Hour, Close: 23 * time.Hour}
+
+	assert.Equal(t, []string{"21:00", "21:30", "22:00", "22:30"}, hours.Slots(30*time.Minute))
+	assert.Empty(t, OpeningHours{Closed: true}.Slots(30*time.Minute))
+	assert.Empty(t, hours.Slots(0))
+}
+
+func TestReservationPolicy_ValidateSlotBoundary(t *testing.T) {
+	tests := []struct {
+		name        string
+		slotMinutes int
+		clock       string
+		wantErr     bool
+	}{
+		{name: "on half hour boundary", slotMinutes: 30, clock: "19:30"},
+		{name: "on the hour", slotMinutes: 30, clock: "19:00:00"},
+		{name: "off boundary", slotMinutes: 30, clock: "19:07", wantErr: true},
+		{name: "quarter hour rejected by hourly slots", slotMinutes: 60, clock: "19:15", wantErr: true},
+		{name: "quarter hour", slotMinutes: 15, clock: "19:45"},
+		{name: "disabled", slotMinutes: 0, clock: "19:07"},
+		{name: "invalid time left to caller", slotMinutes: 30, clock: "evening"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := ReservationPolicy{SlotMinutes: tt.slotMinutes}.ValidateSlotBoundary(tt.clock)
+			assert.Equal(t, tt.wantErr, !message.IsZero())
+		})
+	}
+}
+
+func TestReservationPolicy_SlotInterval(t *testing.T) {
+	assert.Equal(t, 30*time.Minute, ReservationPolicy{}.SlotInterval())
+	assert.Equal(t, 15*time.Minute, ReservationPolicy{SlotMinutes: 15}.SlotInterval())
+}
+
+func TestNextFreeSlot(t *testing.T) {
+	slots := []string{"18:00", "18:30", "19:00", "19:30"}
+
+	tests := []struct {
+		name   string
+		booked []string
+		after  string
+		want   string
+	}{
+		{name: "next slot free", booked: []string{"18:00"}, after: "18:00", want: "18:30"},
+		{name: "skips booked slots", booked: []string{"18:00", "18:30", "19:00"}, after: "18:00", want: "19:30"},
+		{name: "booked times with seconds", booked: []string{"18:30:00"}, after: "18:00", want: "19:00"},
+		{name: "off-grid time", booked: nil, after: "18:10", want: "18:30"},
+		{name: "no later free slot", booked: []string{"19:30"}, after: "19:00", want: ""},
+		{name: "invalid time", booked: nil, after: "evening", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextFreeSlot(slots, tt.booked, tt.after))
+		})
+	}
+}
+
+func TestCurrentSlot(t *testing.T) {
+	slots := []string{"18:00", "18:30", "19:00", "19:30"}
+
+	tests := []struct {
+		name  string
+		slots []string
+		now   time.Time
+		want  string
+	}{
+		{name: "on a slot", slots: slots, now: time.Date(2025, 12, 24, 18, 30, 0, 0, time.UTC), want: "18:30"},
+		{name: "between slots", slots: slots, now: time.Date(2025, 12, 24, 19, 10, 45, 0, time.UTC), want: "19:00"},
+		{name: "during the last slot", slots: slots, now: time.Date(2025, 12, 24, 19, 45, 0, 0, time.UTC), want: "19:30"},
+		{name: "at closing time", slots: slots, now: time.Date(2025, 12, 24, 20, 0, 0, 0, time.UTC), want: ""},
+		{name: "after closing time", slots: slots, now: time.Date(2025, 12, 24, 22, 0, 0, 0, time.UTC), want: ""},
+		{name: "before opening", slots: slots, now: time.Date(2025, 12, 24, 9, 40, 0, 0, time.UTC), want: "09:30"},
+		{name: "closed day", slots: nil, now: time.Date(2025, 12, 24, 12, 5, 0, 0, time.UTC), want: "12:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, currentSlot(tt.slots, 30*time.Minute, tt.now))
+		})
+	}
+}
+
+func TestReservationPolicy_ValidatePartySize(t *testing.T) {
+	policy := ReservationPolicy{MinPartySize: 2, MaxPartySize: 12}
+
+	tests := []struct {
+		name   string
+		policy ReservationPolicy
+		guests int
+		want   string
+	}{
+		{name: "no limits configured", policy: ReservationPolicy{}, guests: 40, want: ""},
+		{name: "within limits", policy: policy, guests: 4, want: ""},
+		{name: "at minimum", policy: policy, guests: 2, want: ""},
+		{name: "at maximum", policy: policy, guests: 12, want: ""},
+		{name: "under minimum", policy: policy, guests: 1, want: "Online reservations require at least 2 guests"},
+		{name: "over maximum", policy: policy, guests: 13, want: "Parties over 12 must call the restaurant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.ValidatePartySize(tt.guests).Translate(i18n.English))
+		})
+	}
+}
+
+func TestReservationPolicy_InitialStatus(t *testing.T) {
+	assert.Equal(t, types.DefaultReservationStatus, ReservationPolicy{}.InitialStatus())
+	assert.Equal(t, types.ReservationStatusConfirmed, ReservationPolicy{AutoConfirmReservations: true}.InitialStatus())
+}
+
+func TestReservationPolicy_FindDuplicate(t *testing.T) {
+	existing := []*types.Reservation{
+		{Time: "12:00", TableNumber: "T1"},
+		{Time: "19:00", TableNumber: "T2"},
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		clock  string
+		want   string
+	}{
+		{name: "same time", window: 2 * time.Hour, clock: "19:00", want: "T2"},
+		{name: "earlier within window", window: 2 * time.Hour, clock: "17:30", want: "T2"},
+		{name: "later within window", window: 2 * time.Hour, clock: "20:30", want: "T2"},
+		{name: "outside window", window: 2 * time.Hour, clock: "15:30", want: ""},
+		{name: "window boundary", window: 2 * time.Hour, clock: "21:00", want: ""},
+		{name: "disabled", window: 0, clock: "19:00", want: ""},
+		{name: "invalid time", window: 2 * time.Hour, clock: "evening", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReservationPolicy{DuplicateWindow: tt.window}.FindDuplicate(existing, tt.clock)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.TableNumber)
+		})
+	}
+}
+
+func TestReservationPolicy_ValidateBookingWindow(t *testing.T) {
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	policy := ReservationPolicy{MaxAdvanceDays: 30, MinLeadHours: 2}
+
+	tests := []struct {
+		name      string
+		policy    ReservationPolicy
+		at        time.Time
+		wantField string
+	}{
+		{name: "within window", policy: policy, at: now.Add(48 * time.Hour)},
+		{name: "at the lead boundary", policy: policy, at: now.Add(2 * time.Hour)},
+		{name: "too soon", policy: policy, at: now.Add(90 * time.Minute), wantField: "time"},
+		{name: "in the past", policy: policy, at: now.Add(-time.Hour), wantField: "time"},
+		{name: "at the advance boundary", policy: policy, at: now.Add(30 * 24 * time.Hour)},
+		{name: "too far in advance", policy: policy, at: now.Add(31 * 24 * time.Hour), wantField: "date"},
+		{name: "limits disabled", policy: ReservationPolicy{}, at: now.Add(365 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, message := tt.policy.ValidateBookingWindow(tt.at, now)
+			assert.Equal(t, tt.wantField, field)
+			assert.Equal(t, tt.wantField == "", message.IsZero())
+		})
+	}
+}
+
+func TestReservationPolicy_ReservationStart(t *testing.T) {
+	date := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	got, err := ReservationPolicy{}.ReservationStart(date, "19:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 12, 25, 19, 30, 0, 0, time.Local), got)
+
+	kyiv := time.FixedZone("EET", 2*60*60)
+	policy := ReservationPolicy{Location: kyiv}
+
+	got, err = policy.ReservationStart(date, "19:30:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 12, 25, 19, 30, 0, 0, kyiv), got)
+	assert.Equal(t, time.Date(2025, 12, 25, 17, 30, 0, 0, time.UTC), got.UTC())
+
+	_, err = policy.ReservationStart(date, "evening")
+	assert.Error(t, err)
+}
+
+func TestReservationPolicy_Now(t *testing.T) {
+	kyiv := time.FixedZone("EET", 2*60*60)
+
+	assert.Equal(t, kyiv, ReservationPolicy{Location: kyiv}.Now().Location())
+	assert.Equal(t, time.Local, ReservationPolicy{}.Now().Location())
+}
@@ -0,0 +1,414 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/EduardMikhrin/university-booking-project/internal/webhook"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// maxRecurringOccurrences limits how many reservations a single recurring booking may create
+	maxRecurringOccurrences = 52
+	// recurrenceWeekly repeats a booking every 7 days
+	recurrenceWeekly = "weekly"
+)
+
+// errNoOccurrences aborts a recurring booking none of whose occurrences could be booked
+var errNoOccurrences = errors.New("no occurrence of the recurring booking is available")
+
+// RecurrenceRule describes how a recurring booking repeats. Exactly one of Count and Until is required
+type RecurrenceRule struct {
+	// Frequency is how often the booking repeats. Only weekly is supported
+	Frequency string `json:"frequency"`
+	// Count is the number of occurrences, including the first one
+	Count *int `json:"count,omitempty"`
+	// Until is the last date (YYYY-MM-DD) an occurrence may fall on
+	Until *string `json:"until,omitempty"`
+}
+
+type CreateRecurringReservationRequest struct {
+	// Reservation is the first occurrence, which is repeated on the following dates
+	Reservation CreateReservationRequest `json:"reservation"`
+	Recurrence  RecurrenceRule           `json:"recurrence"`
+}
+
+// SkippedOccurrence reports a date of a recurring booking that was not booked
+type SkippedOccurrence struct {
+	Date   string            `json:"date"`
+	Reason string            `json:"reason"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+type RecurringReservationResponse struct {
+	RecurrenceGroupID uuid.UUID            `json:"recurrenceGroupId"`
+	Reservations      []*types.Reservation `json:"reservations"`
+	Skipped           []SkippedOccurrence  `json:"skipped,omitempty"`
+}
+
+type CancelRecurringReservationsResponse struct {
+	Cancelled []uuid.UUID `json:"cancelled"`
+}
+
+// @Summary Create recurring reservation
+// @Description Book the same table, time and party size repeatedly, e.g. every Friday for 8 weeks.
+// @Description The recurrence repeats weekly from the date of the base reservation, either count times or until a date,
+// @Description creating at most 52 reservations. The base reservation must be valid and its table free; later dates that
+// @Description break the reservation policy, have a booked table or a full time slot, or duplicate a booking of the guest phone are skipped and reported.
+// @Description All reservations are created in one transaction and share a recurrence group ID. Only the first one is confirmed to the guest
+// @Tags Reservations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateRecurringReservationRequest true "Base reservation and recurrence rule"
+// @Success 201 {object} RecurringReservationResponse
+// @Failure 400 {object} TableConflictResponse "Validation error, or the table is already booked on the first date"
+// @Failure 409 {object} ErrorResponse "The guest phone already has a reservation close to the first occurrence, the user would hold too many active reservations, or the first time slot is full"
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/recurring [post]
+func (s *Server) handleCreateRecurringReservation(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	var req CreateRecurringReservationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	tableNumbers, v, err := s.validateCreateReservation(r.Context(), &req.Reservation)
+	if err != nil {
+		s.log.WithError(err).Error("failed to validate reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	var dates []time.Time
+	if first, err := time.Parse("2006-01-02", req.Reservation.Date); err == nil {
+		var rv *validation.Errors
+		dates, rv = recurrenceDates(first, req.Recurrence)
+		v.Merge(rv.Map())
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if s.reservationPolicy.MaxActivePerUser > 0 && user.Role != adminRole {
+		active, err := s.db.ReservationQ().CountActiveByUser(r.Context(), user.ID)
+		if err != nil {
+			s.log.WithError(err).Error("failed to count active reservations")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if active+len(dates) > s.reservationPolicy.MaxActivePerUser {
+			writeErrorResponse(w, r, http.StatusConflict, codeTooManyReservations, i18n.TooManyActiveReservations, i18n.Details{
+				"reservations": i18n.Msg(i18n.ActiveReservationsLimitRecurring, active, len(dates), s.reservationPolicy.MaxActivePerUser),
+			})
+			return
+		}
+	}
+
+	// The first occurrence is checked up front so that it fails the way a regular booking does
+	first := req.Reservation
+	if !s.checkBlockedDate(w, r, first.Date) {
+		return
+	}
+	for _, tableNumber := range tableNumbers {
+		available, err := s.db.ReservationQ().CheckTableAvailability(r.Context(), tableNumber, first.Date, first.Time)
+		if err != nil {
+			s.log.WithError(err).Error("failed to check table availability")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if !available {
+			s.writeTableConflict(w, r, tableNumber, dates[0], first.Time, first.Guests, tableNumbers)
+			return
+		}
+	}
+	if !s.checkConcurrentParties(w, r, first.Date, first.Time, uuid.Nil) {
+		return
+	}
+	if s.reservationPolicy.DuplicateWindow > 0 {
+		existing, err := s.db.ReservationQ().GetByPhoneAndDate(r.Context(), first.GuestPhone, first.Date)
+		if err != nil {
+			s.log.WithError(err).Error("failed to get reservations by phone")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if duplicate := s.reservationPolicy.FindDuplicate(existing, first.Time); duplicate != nil {
+			writeErrorResponse(w, r, http.StatusConflict, codeDuplicateReservation, i18n.DuplicateReservation, duplicateDetails(duplicate, user, first.Date))
+			return
+		}
+	}
+
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	groupID := uuid.New()
+	var created []*types.Reservation
+	var skipped []SkippedOccurrence
+	var reasons i18n.Details
+	err = s.db.Transaction(r.Context(), func(tx data.MasterQ) error {
+		created, skipped, reasons = nil, nil, make(i18n.Details)
+		for _, date := range dates {
+			occurrence := req.Reservation
+			occurrence.Date = date.Format("2006-01-02")
+
+			reason, details, err := s.checkOccurrence(r.Context(), tx, &occurrence, tableNumbers)
+			if err != nil {
+				return err
+			}
+			if !reason.IsZero() {
+				skipped = append(skipped, SkippedOccurrence{Date: occurrence.Date, Reason: reason.Translate(lang), Errors: details.Translate(lang)})
+				reasons[occurrence.Date] = reason
+				continue
+			}
+
+			reservation := s.newReservation(occurrence, &user.ID, tableNumbers)
+			reservation.RecurrenceGroupID = &groupID
+			code, err := generateConfirmationCode()
+			if err != nil {
+				return fmt.Errorf("failed to generate confirmation code: %w", err)
+			}
+			reservation.ConfirmationCode = &code
+
+			// A confirmation code conflict fails the whole booking, since the transaction
+			// cannot continue after a failed statement
+			if err := tx.ReservationQ().Create(r.Context(), reservation, s.reservationPolicy.InitialStatus()); err != nil {
+				return fmt.Errorf("failed to create reservation on %s: %w", occurrence.Date, err)
+			}
+			created = append(created, reservation)
+		}
+		if len(created) == 0 {
+			return errNoOccurrences
+		}
+		return nil
+	})
+	if errors.Is(err, errNoOccurrences) {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, reasons)
+		return
+	}
+	if err != nil {
+		s.log.WithError(err).Error("failed to create recurring reservation")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	s.invalidateReservationCache(r.Context(), created...)
+	for _, reservation := range created {
+		s.webhooks.Dispatch(r.Context(), webhook.EventReservationCreated, reservation)
+		s.logAction(r, actionReservationCreated, reservationFields(reservation))
+	}
+
+	go s.sendReservationConfirmation(context.WithoutCancel(r.Context()), created[0])
+
+	writeJSONResponse(w, http.StatusCreated, RecurringReservationResponse{
+		RecurrenceGroupID: groupID,
+		Reservations:      created,
+		Skipped:           skipped,
+	})
+}
+
+// checkOccurrence checks a single occurrence of a recurring booking against the reservation policy,
+// table availability and duplicate bookings. It returns why the occurrence is skipped together with the failed
+// validation details, or a zero message when it can be booked
+func (s *Server) checkOccurrence(ctx context.Context, tx data.MasterQ, occurrence *CreateReservationRequest, tableNumbers []string) (i18n.Message, i18n.Details, error) {
+	_, v, err := s.validateCreateReservation(ctx, occurrence)
+	if err != nil {
+		return i18n.Message{}, nil, err
+	}
+	if v.HasErrors() {
+		return i18n.Msg(i18n.OccurrenceBreaksPolicy), v.Map(), nil
+	}
+
+	reason, err := blockedReason(ctx, tx.BlockedDateQ(), occurrence.Date)
+	if err != nil {
+		return i18n.Message{}, nil, fmt.Errorf("failed to check blocked dates: %w", err)
+	}
+	if reason != "" {
+		return i18n.Msg(i18n.OccurrenceClosed, reason), nil, nil
+	}
+
+	for _, tableNumber := range tableNumbers {
+		available, err := tx.ReservationQ().CheckTableAvailability(ctx, tableNumber, occurrence.Date, occurrence.Time)
+		if err != nil {
+			return i18n.Message{}, nil, fmt.Errorf("failed to check table availability: %w", err)
+		}
+		if !available {
+			return i18n.Msg(i18n.OccurrenceTableBooked, tableNumber), nil, nil
+		}
+	}
+
+	if s.reservationPolicy.MaxConcurrentParties > 0 {
+		concurrent, err := tx.ReservationQ().CountConcurrentAt(ctx, occurrence.Date, occurrence.Time, s.reservationPolicy.SlotInterval(), uuid.Nil)
+		if err != nil {
+			return i18n.Message{}, nil, fmt.Errorf("failed to count concurrent reservations: %w", err)
+		}
+		if concurrent >= s.reservationPolicy.MaxConcurrentParties {
+			return i18n.Msg(i18n.PartiesSeated, concurrent, occurrence.Time), nil, nil
+		}
+	}
+
+	if s.reservationPolicy.DuplicateWindow > 0 {
+		existing, err := tx.ReservationQ().GetByPhoneAndDate(ctx, occurrence.GuestPhone, occurrence.Date)
+		if err != nil {
+			return i18n.Message{}, nil, fmt.Errorf("failed to get reservations by phone: %w", err)
+		}
+		if duplicate := s.reservationPolicy.FindDuplicate(existing, occurrence.Time); duplicate != nil {
+			return i18n.Msg(i18n.OccurrenceDuplicate), nil, nil
+		}
+	}
+
+	return i18n.Message{}, nil, nil
+}
+
+// recurrenceDates lists the dates of a recurring booking starting on first, validating the rule.
+// Errors are keyed by the path of the offending field in the request body
+func recurrenceDates(first time.Time, rule RecurrenceRule) ([]time.Time, *validation.Errors) {
+	v := validation.New()
+	if strings.ToLower(strings.TrimSpace(rule.Frequency)) != recurrenceWeekly {
+		v.Add("recurrence.frequency", i18n.RecurringFrequencyWeekly)
+	}
+
+	count := 0
+	switch {
+	case rule.Count != nil && rule.Until != nil:
+		v.Add("recurrence", i18n.RecurringCountAndUntil)
+	case rule.Count != nil:
+		count = *rule.Count
+		if count < 2 {
+			v.Add("recurrence.count", i18n.RecurringTooFewOccurrences)
+		} else if count > maxRecurringOccurrences {
+			v.Add("recurrence.count", i18n.RecurringTooManyOccurrences, maxRecurringOccurrences)
+		}
+	case rule.Until != nil:
+		until, err := time.Parse("2006-01-02", strings.TrimSpace(*rule.Until))
+		if err != nil {
+			v.Add("recurrence.until", i18n.InvalidDateFormat)
+			break
+		}
+		count = int(until.Sub(first).Hours()/24)/7 + 1
+		if !until.After(first) || count < 2 {
+			v.Add("recurrence.until", i18n.RecurringUntilTooEarly)
+		} else if count > maxRecurringOccurrences {
+			v.Add("recurrence.until", i18n.RecurringTooManyOccurrences, maxRecurringOccurrences)
+		}
+	default:
+		v.Add("recurrence", i18n.RecurringEndMissing)
+	}
+	if v.HasErrors() {
+		return nil, v
+	}
+
+	dates := make([]time.Time, count)
+	for i := range dates {
+		dates[i] = first.AddDate(0, 0, 7*i)
+	}
+	return dates, v
+}
+
+// @Summary Cancel recurring reservation
+// @Description Cancel every upcoming pending or confirmed reservation of a recurring booking (owner or admin).
+// @Description Occurrences that already took place or are no longer active are left untouched
+// @Tags Reservations
+// @Security BearerAuth
+// @Produce json
+// @Param groupId path string true "Recurrence group ID"
+// @Success 200 {object} CancelRecurringReservationsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/recurring/{groupId} [delete]
+func (s *Server) handleCancelRecurringReservations(w http.ResponseWriter, r *http.Request) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get user from context")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	groupID, err := uuid.Parse(r.PathValue("groupId"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid recurrence group ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRecurrenceGroupID, nil)
+		return
+	}
+
+	reservations, err := s.db.ReservationQ().GetByRecurrenceGroup(r.Context(), groupID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get recurring reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	if len(reservations) == 0 {
+		writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.RecurrenceGroupNotFound, nil)
+		return
+	}
+
+	if user.Role != adminRole {
+		for _, reservation := range reservations {
+			if !reservation.OwnedBy(user.ID) {
+				writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+				return
+			}
+		}
+	}
+
+	now := s.reservationPolicy.Now()
+	cancelled := make([]*types.Reservation, 0, len(reservations))
+	changes := make([]*types.StatusChange, 0, len(reservations))
+	for _, reservation := range reservations {
+		if validateStatusTransition(reservation.Status, "cancelled") != nil {
+			continue
+		}
+		if startsAt, err := s.reservationPolicy.ReservationStart(reservation.Date, reservation.Time); err != nil || !startsAt.After(now) {
+			continue
+		}
+		cancelled = append(cancelled, reservation)
+		changes = append(changes, &types.StatusChange{
+			ReservationID: reservation.ID,
+			FromStatus:    reservation.Status,
+			ToStatus:      "cancelled",
+			ChangedBy:     &user.ID,
+		})
+	}
+
+	ids := make([]uuid.UUID, 0, len(cancelled))
+	if len(changes) > 0 {
+		if err := s.db.ReservationQ().BulkUpdateStatus(r.Context(), changes); err != nil {
+			s.log.WithError(err).Error("failed to cancel recurring reservations")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+
+		s.invalidateReservationCache(r.Context(), cancelled...)
+		for _, reservation := range cancelled {
+			s.logAction(r, actionReservationStatusChanged, logan.F{
+				"reservation_id":      reservation.ID,
+				"from_status":         reservation.Status,
+				"to_status":           "cancelled",
+				"recurrence_group_id": groupID,
+			})
+			reservation.Status = "cancelled"
+			s.webhooks.Dispatch(r.Context(), webhook.EventReservationStatusChanged, reservation)
+			ids = append(ids, reservation.ID)
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, CancelRecurringReservationsResponse{Cancelled: ids})
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrenceDates(t *testing.T) {
+	first := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	count := func(n int) *int { return &n }
+	until := func(s string) *string { return &s }
+
+	t.Run("count", func(t *testing.T) {
+		dates, v := recurrenceDates(first, RecurrenceRule{Frequency: "weekly", Count: count(3)})
+		require.False(t, v.HasErrors())
+		require.Len(t, dates, 3)
+		assert.Equal(t, "2024-03-01", dates[0].Format("2006-01-02"))
+		assert.Equal(t, "2024-03-08", dates[1].Format("2006-01-02"))
+		assert.Equal(t, "2024-03-15", dates[2].Format("2006-01-02"))
+	})
+
+	t.Run("until includes the last matching date", func(t *testing.T) {
+		dates, v := recurrenceDates(first, RecurrenceRule{Frequency: "Weekly", Until: until("2024-03-22")})
+		require.False(t, v.HasErrors())
+		require.Len(t, dates, 4)
+		assert.Equal(t, "2024-03-22", dates[3].Format("2006-01-02"))
+	})
+
+	t.Run("until between occurrences", func(t *testing.T) {
+		dates, v := recurrenceDates(first, RecurrenceRule{Frequency: "weekly", Until: until("2024-03-20")})
+		require.False(t, v.HasErrors())
+		assert.Len(t, dates, 3)
+	})
+
+	t.Run("keeps the weekday across months", func(t *testing.T) {
+		dates, v := recurrenceDates(first, RecurrenceRule{Frequency: "weekly", Until: until("2024-04-05")})
+		require.False(t, v.HasErrors())
+		require.Len(t, dates, 6)
+		assert.Equal(t, time.Friday, dates[5].Weekday())
+	})
+
+	tests := []struct {
+		name  string
+		rule  RecurrenceRule
+		field string
+	}{
+		{"unsupported frequency", RecurrenceRule{Frequency: "daily", Count: count(3)}, "recurrence.frequency"},
+		{"missing count and until", RecurrenceRule{Frequency: "weekly"}, "recurrence"},
+		{"both count and until", RecurrenceRule{Frequency: "weekly", Count: count(3), Until: until("2024-04-01")}, "recurrence"},
+		{"single occurrence", RecurrenceRule{Frequency: "weekly", Count: count(1)}, "recurrence.count"},
+		{"too many occurrences", RecurrenceRule{Frequency: "weekly", Count: count(maxRecurringOccurrences + 1)}, "recurrence.count"},
+		{"invalid until", RecurrenceRule{Frequency: "weekly", Until: until("01.04.2024")}, "recurrence.until"},
+		{"until before first", RecurrenceRule{Frequency: "weekly", Until: until("2024-02-20")}, "recurrence.until"},
+		{"until within the first week", RecurrenceRule{Frequency: "weekly", Until: until("2024-03-05")}, "recurrence.until"},
+		{"until too far", RecurrenceRule{Frequency: "weekly", Until: until("2025-03-01")}, "recurrence.until"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, v := recurrenceDates(first, tt.rule)
+			assert.Nil(t, dates)
+			assert.Contains(t, v.Map(), tt.field)
+		})
+	}
+}
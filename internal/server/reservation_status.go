@@ -0,0 +1,39 @@
+package server
+
+import "github.com/EduardMikhrin/university-booking-project/internal/i18n"
+
+// reservationStatusTransitions lists, for every known reservation status,
+// the statuses it is allowed to move to. Cancelled, completed and no-show
+// reservations are final: they cannot be reopened or moved to another final status.
+// A reservation cannot be set to the status it already has either, so that a repeated
+// request is reported instead of recording a change that did not happen
+var reservationStatusTransitions = map[string][]string{
+	"pending":   {"confirmed", "cancelled"},
+	"confirmed": {"completed", "cancelled", "no_show"},
+	"cancelled": {},
+	"completed": {},
+	"no_show":   {},
+}
+
+// isValidReservationStatus checks that the status is a known reservation status
+func isValidReservationStatus(status string) bool {
+	_, ok := reservationStatusTransitions[status]
+	return ok
+}
+
+// validateStatusTransition checks that a reservation may move from one status to another
+// and returns an error carrying a catalog message with the reason when it may not
+func validateStatusTransition(from, to string) error {
+	if from == to {
+		return i18n.Errorf(i18n.StatusUnchanged, from)
+	}
+	for _, allowed := range reservationStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	if len(reservationStatusTransitions[from]) == 0 {
+		return i18n.Errorf(i18n.StatusFinal, from, to, from)
+	}
+	return i18n.Errorf(i18n.StatusTransitionNotAllowed, from, to)
+}
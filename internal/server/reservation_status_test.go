@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		wantErr string
+	}{
+		{from: "pending", to: "confirmed"},
+		{from: "pending", to: "cancelled"},
+		{from: "confirmed", to: "completed"},
+		{from: "confirmed", to: "no_show"},
+		{from: "pending", to: "completed", wantErr: "cannot change status from pending to completed"},
+		{from: "confirmed", to: "confirmed", wantErr: "reservation is already confirmed"},
+		{from: "cancelled", to: "pending", wantErr: "cannot change status from cancelled to pending, cancelled reservations are final"},
+		{from: "completed", to: "cancelled", wantErr: "cannot change status from completed to cancelled, completed reservations are final"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := validateStatusTransition(tt.from, tt.to)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
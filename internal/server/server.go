@@ -9,74 +9,192 @@ import (
 	_ "github.com/EduardMikhrin/university-booking-project/docs"
 	"github.com/EduardMikhrin/university-booking-project/internal/cache"
 	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/EduardMikhrin/university-booking-project/internal/notifier"
+	"github.com/EduardMikhrin/university-booking-project/internal/storage"
+	"github.com/EduardMikhrin/university-booking-project/internal/webhook"
+	"github.com/EduardMikhrin/university-booking-project/internal/worker"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
 type Server struct {
-	log       *logan.Entry
-	db        data.MasterQ
-	cache     cache.CacheQ
-	listener  net.Listener
-	jwtConfig JWT
-	router    *http.ServeMux
+	log               *logan.Entry
+	db                data.MasterQ
+	cache             cache.CacheQ
+	notifier          notifier.Notifier
+	photos            storage.ObjectStore
+	webhooks          *webhook.Dispatcher
+	invalidations     *worker.InvalidationPublisher
+	listener          net.Listener
+	jwtConfig         JWT
+	router            *http.ServeMux
+	passwordPolicy    PasswordPolicy
+	reservationPolicy ReservationPolicy
+	metrics           *metrics.Metrics
+	tenancy           Tenancy
 }
 
 func init() {
 	docs.SwaggerInfo.BasePath = "/api/v1"
 }
 
-func NewServer(log *logan.Entry, db data.MasterQ, cache cache.CacheQ, listener net.Listener, jwtConfig JWT) *Server {
+func NewServer(log *logan.Entry, db data.MasterQ, cache cache.CacheQ, notifier notifier.Notifier, photos storage.ObjectStore, webhooks *webhook.Dispatcher, invalidations *worker.InvalidationPublisher, listener net.Listener, jwtConfig JWT, passwordPolicy PasswordPolicy, reservationPolicy ReservationPolicy, metrics *metrics.Metrics, tenancy Tenancy) *Server {
 	s := &Server{
-		log:       log,
-		db:        db,
-		cache:     cache,
-		listener:  listener,
-		jwtConfig: jwtConfig,
-		router:    http.NewServeMux(),
+		log:               log,
+		db:                db,
+		cache:             cache,
+		notifier:          notifier,
+		photos:            photos,
+		webhooks:          webhooks,
+		invalidations:     invalidations,
+		listener:          listener,
+		jwtConfig:         jwtConfig,
+		router:            http.NewServeMux(),
+		passwordPolicy:    passwordPolicy,
+		reservationPolicy: reservationPolicy,
+		metrics:           metrics,
+		tenancy:           tenancy,
 	}
 	s.mountRoutes()
 	return s
 }
 
+// routeAccess is the authentication a route requires
+type routeAccess int
+
+const (
+	// accessPublic routes are served without a bearer token
+	accessPublic routeAccess = iota
+	// accessUser routes require an authenticated user
+	accessUser
+	// accessAdmin routes require an authenticated admin
+	accessAdmin
+)
+
+// route is a single API endpoint mounted under /api/v1
+type route struct {
+	method  string
+	pattern string
+	handler http.HandlerFunc
+	access  routeAccess
+}
+
+// routes returns the API route table. Handlers check ownership themselves where
+// a user route is limited to the caller's own resources
+func (s *Server) routes() []route {
+	return []route{
+		// Authentication routes
+		{http.MethodPost, "/auth/login", s.handleLogin, accessPublic},
+		{http.MethodPost, "/auth/register", s.handleRegister, accessPublic},
+		{http.MethodGet, "/auth/me", s.handleGetMe, accessUser},
+		{http.MethodPost, "/auth/logout", s.handleLogout, accessUser},
+		{http.MethodPost, "/auth/logout-all", s.handleLogoutAll, accessUser},
+
+		// Guest self-service routes (rate limited)
+		{http.MethodGet, "/reservations/lookup", s.handleLookupReservation, accessPublic},
+
+		// Restaurant routes
+		{http.MethodGet, "/restaurants", s.handleGetRestaurants, accessPublic},
+
+		// Reservation routes
+		{http.MethodGet, "/reservations", s.handleGetReservations, accessUser},
+		{http.MethodGet, "/reservations/{id}", s.handleGetReservation, accessUser},
+		{http.MethodGet, "/reservations/me", s.handleGetMyReservations, accessUser},
+		{http.MethodGet, "/reservations/me/count", s.handleGetMyUpcomingCount, accessUser},
+		{http.MethodGet, "/reservations/deleted", s.handleGetDeletedReservations, accessAdmin},
+		{http.MethodGet, "/reservations/{id}/{resource}", s.handleGetReservationResource, accessUser},
+		{http.MethodGet, "/reservations/user/{userId}", s.handleGetUserReservations, accessUser},
+		{http.MethodPost, "/reservations", s.handleCreateReservation, accessUser},
+		{http.MethodPost, "/reservations/guest", s.handleCreateGuestReservation, accessAdmin},
+		{http.MethodPost, "/reservations/import", s.handleImportReservations, accessAdmin},
+		{http.MethodPatch, "/reservations/{id}", s.handleUpdateReservation, accessUser},
+		{http.MethodPatch, "/reservations/{id}/status", s.handleUpdateReservationStatus, accessUser},
+		{http.MethodPatch, "/reservations/{id}/notes", s.handleUpdateReservationNotes, accessAdmin},
+		{http.MethodPatch, "/reservations/status/bulk", s.handleBulkUpdateReservationStatus, accessAdmin},
+		{http.MethodDelete, "/reservations/{id}", s.handleDeleteReservation, accessUser},
+		{http.MethodPost, "/reservations/{id}/restore", s.handleRestoreReservation, accessAdmin},
+		{http.MethodPost, "/reservations/{id}/rebook", s.handleRebookReservation, accessUser},
+		{http.MethodPost, "/reservations/{id}/resend-confirmation", s.handleResendConfirmation, accessUser},
+		{http.MethodPost, "/reservations/{id}/feedback", s.handleCreateFeedback, accessUser},
+		{http.MethodPost, "/reservations/recurring", s.handleCreateRecurringReservation, accessUser},
+		{http.MethodDelete, "/reservations/recurring/{groupId}", s.handleCancelRecurringReservations, accessUser},
+
+		// Table routes
+		{http.MethodGet, "/tables", s.handleGetTables, accessUser},
+		{http.MethodGet, "/tables/{id}", s.handleGetTable, accessUser},
+		{http.MethodGet, "/tables/available", s.handleGetAvailableTables, accessUser},
+		{http.MethodGet, "/tables/summary", s.handleGetCapacitySummary, accessAdmin},
+		{http.MethodGet, "/tables/{number}/availability", s.handleGetTableAvailability, accessUser},
+		{http.MethodGet, "/tables/{number}/slots", s.handleGetTableSlots, accessUser},
+		{http.MethodGet, "/tables/{number}/reservations", s.handleGetTableReservations, accessAdmin},
+		{http.MethodPatch, "/tables/{id}/availability", s.handleUpdateTableAvailability, accessUser},
+		{http.MethodPatch, "/tables/availability/bulk", s.handleBulkUpdateTableAvailability, accessAdmin},
+		{http.MethodPatch, "/tables/{id}/status", s.handleUpdateTableStatus, accessAdmin},
+
+		// Report routes
+		{http.MethodGet, "/reports/monthly", s.handleGetMonthlyReports, accessAdmin},
+		{http.MethodGet, "/reports/yearly", s.handleGetYearlyReports, accessAdmin},
+		{http.MethodGet, "/reports/weekly", s.handleGetWeeklyReports, accessAdmin},
+		{http.MethodGet, "/reports/today", s.handleGetTodayReport, accessAdmin},
+		{http.MethodGet, "/reports/monthly/{month}", s.handleGetMonthlyReport, accessAdmin},
+		{http.MethodGet, "/reports/occupancy", s.handleGetOccupancyReport, accessAdmin},
+		{http.MethodGet, "/reports/user/{userId}", s.handleGetUserReport, accessUser},
+
+		// Webhook routes
+		{http.MethodGet, "/webhooks", s.handleGetWebhooks, accessAdmin},
+		{http.MethodPost, "/webhooks", s.handleCreateWebhook, accessAdmin},
+		{http.MethodDelete, "/webhooks/{id}", s.handleDeleteWebhook, accessAdmin},
+
+		// Blocked date routes
+		{http.MethodGet, "/blocked-dates", s.handleGetBlockedDates, accessUser},
+		{http.MethodPost, "/blocked-dates", s.handleCreateBlockedDate, accessAdmin},
+		{http.MethodDelete, "/blocked-dates/{id}", s.handleDeleteBlockedDate, accessAdmin},
+
+		// Special hours routes
+		{http.MethodGet, "/special-hours", s.handleGetSpecialHours, accessUser},
+		{http.MethodPost, "/special-hours", s.handleCreateSpecialHours, accessAdmin},
+		{http.MethodPatch, "/special-hours/{id}", s.handleUpdateSpecialHours, accessAdmin},
+		{http.MethodDelete, "/special-hours/{id}", s.handleDeleteSpecialHours, accessAdmin},
+
+		// User routes
+		{http.MethodGet, "/users", s.handleGetUsers, accessAdmin},
+		{http.MethodGet, "/users/{id}", s.handleGetUser, accessUser},
+		{http.MethodPatch, "/users/{id}", s.handleUpdateUser, accessUser},
+		{http.MethodPost, "/users/{id}/photo", s.handleUploadUserPhoto, accessUser},
+		{http.MethodDelete, "/users/{id}", s.handleDeleteUser, accessUser},
+	}
+}
+
 func (s *Server) mountRoutes() {
 	// API v1 base path
 	apiV1 := http.NewServeMux()
+	for _, rt := range s.routes() {
+		handler := rt.handler
+		switch rt.access {
+		case accessUser:
+			handler = s.userMiddleware(handler)
+		case accessAdmin:
+			handler = s.adminMiddleware(handler)
+		}
+		apiV1.HandleFunc(rt.method+" "+rt.pattern, handler)
+	}
 
-	// Authentication routes (public - no middleware)
-	apiV1.HandleFunc("POST /auth/login", s.handleLogin)
-	apiV1.HandleFunc("POST /auth/register", s.handleRegister)
-
-	// Authentication routes (require authentication)
-	apiV1.HandleFunc("GET /auth/me", s.userMiddleware(s.handleGetMe))
-	apiV1.HandleFunc("POST /auth/logout", s.userMiddleware(s.handleLogout))
-
-	// Reservation routes (require authentication)
-	apiV1.HandleFunc("GET /reservations", s.userMiddleware(s.handleGetReservations))
-	apiV1.HandleFunc("GET /reservations/{id}", s.userMiddleware(s.handleGetReservation))
-	apiV1.HandleFunc("GET /reservations/user/{userId}", s.userMiddleware(s.handleGetUserReservations))
-	apiV1.HandleFunc("POST /reservations", s.userMiddleware(s.handleCreateReservation))
-	apiV1.HandleFunc("PATCH /reservations/{id}", s.userMiddleware(s.handleUpdateReservation))
-	apiV1.HandleFunc("PATCH /reservations/{id}/status", s.userMiddleware(s.handleUpdateReservationStatus))
-	apiV1.HandleFunc("DELETE /reservations/{id}", s.userMiddleware(s.handleDeleteReservation))
-
-	// Table routes (require authentication)
-	apiV1.HandleFunc("GET /tables", s.userMiddleware(s.handleGetTables))
-	apiV1.HandleFunc("GET /tables/{id}", s.userMiddleware(s.handleGetTable))
-	apiV1.HandleFunc("GET /tables/available", s.userMiddleware(s.handleGetAvailableTables))
-	apiV1.HandleFunc("PATCH /tables/{id}/availability", s.userMiddleware(s.handleUpdateTableAvailability))
-
-	// Report routes (Admin only)
-	apiV1.HandleFunc("GET /reports/monthly", s.adminMiddleware(s.handleGetMonthlyReports))
-	apiV1.HandleFunc("GET /reports/monthly/{month}", s.adminMiddleware(s.handleGetMonthlyReport))
-
-	// User routes (require authentication)
-	apiV1.HandleFunc("GET /users/{id}", s.userMiddleware(s.handleGetUser))
-	apiV1.HandleFunc("PATCH /users/{id}", s.userMiddleware(s.handleUpdateUser))
+	// The restaurant is resolved outside of the metrics middleware, which reads the route
+	// pattern the mux sets on the request it is given
+	api := s.metricsMiddleware(apiV1)
+	if s.tenancy.Enabled {
+		api = s.restaurantMiddleware(api)
+	}
 
 	// Mount API v1 under /api/v1
-	s.router.Handle("/api/v1/", http.StripPrefix("/api/v1", apiV1))
+	s.router.Handle("/api/v1/", http.StripPrefix("/api/v1", api))
+	s.router.Handle("GET /metrics", s.metrics.Handler())
 	s.router.Handle("/swagger/", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
+	// Stores that cannot serve photos themselves, like S3, hand out URLs of their own
+	if photos, ok := s.photos.(http.Handler); ok {
+		s.router.Handle("GET "+photosPath, http.StripPrefix(photosPath, photos))
+	}
 }
 
 // Run starts the HTTP server and blocks until an error occurs
@@ -91,3 +209,9 @@ func (s *Server) Run(ctx context.Context) error {
 	s.log.WithField("address", s.listener.Addr().String()).Info("starting server")
 	return server.Serve(s.listener)
 }
+
+// publishInvalidation queues a change of an entity for the next broadcast to all service instances.
+// An empty id stands for every entity of the type
+func (s *Server) publishInvalidation(entity, id string) {
+	s.invalidations.Publish(cache.InvalidationEvent{Entity: entity, ID: id})
+}
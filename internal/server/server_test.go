@@ -0,0 +1,170 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/docs"
+	"github.com/EduardMikhrin/university-booking-project/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// publicRoutes are the only operations that may be served without a bearer token
+var publicRoutes = map[string]bool{
+	"POST /auth/login":         true,
+	"POST /auth/register":      true,
+	"GET /reservations/lookup": true,
+	"GET /restaurants":         true,
+}
+
+// adminRoutes are the operations restricted to admins
+var adminRoutes = map[string]bool{
+	"GET /reservations/deleted":         true,
+	"POST /reservations/guest":          true,
+	"POST /reservations/import":         true,
+	"PATCH /reservations/{id}/notes":    true,
+	"PATCH /reservations/status/bulk":   true,
+	"POST /reservations/{id}/restore":   true,
+	"GET /tables/summary":               true,
+	"PATCH /tables/{id}/status":         true,
+	"PATCH /tables/availability/bulk":   true,
+	"GET /tables/{number}/reservations": true,
+	"GET /reports/monthly":              true,
+	"GET /reports/yearly":               true,
+	"GET /reports/weekly":               true,
+	"GET /reports/today":                true,
+	"GET /reports/monthly/{month}":      true,
+	"GET /reports/occupancy":            true,
+	"GET /users":                        true,
+	"GET /webhooks":                     true,
+	"POST /webhooks":                    true,
+	"DELETE /webhooks/{id}":             true,
+	"POST /blocked-dates":               true,
+	"DELETE /blocked-dates/{id}":        true,
+	"POST /special-hours":               true,
+	"PATCH /special-hours/{id}":         true,
+	"DELETE /special-hours/{id}":        true,
+}
+
+type documentedRoute struct {
+	method  string
+	path    string
+	secured bool
+}
+
+// documentedRoutes lists every operation of the generated Swagger document
+func documentedRoutes(t *testing.T) []documentedRoute {
+	t.Helper()
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &doc))
+
+	var routes []documentedRoute
+	for path, operations := range doc.Paths {
+		for method, operation := range operations {
+			routes = append(routes, documentedRoute{
+				method:  strings.ToUpper(method),
+				path:    path,
+				secured: len(operation.Security) > 0,
+			})
+		}
+	}
+	require.NotEmpty(t, routes)
+	return routes
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+// serveWithoutToken sends an unauthenticated request through the mounted routes. A route mounted
+// without auth middleware reaches its handler, which panics on the missing dependencies; that is
+// reported as a 500 rather than aborting the test
+func serveWithoutToken(s *Server, method, path string) (status int) {
+	defer func() {
+		if recover() != nil {
+			status = http.StatusInternalServerError
+		}
+	}()
+
+	target := "/api/v1" + pathParam.ReplaceAllString(path, "00000000-0000-0000-0000-000000000000")
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec.Code
+}
+
+func TestRoutesRequireAuthentication(t *testing.T) {
+	s := NewServer(logan.New().Out(io.Discard), nil, nil, nil, nil, nil, nil, nil, JWT{SecretKey: "secret"}, PasswordPolicy{}, ReservationPolicy{}, metrics.New("test"), Tenancy{})
+
+	for _, route := range documentedRoutes(t) {
+		name := route.method + " " + route.path
+		t.Run(name, func(t *testing.T) {
+			if publicRoutes[name] {
+				assert.False(t, route.secured, "public route is documented with @Security")
+				return
+			}
+
+			assert.True(t, route.secured, "protected route is missing @Security BearerAuth")
+			assert.Equal(t, http.StatusUnauthorized, serveWithoutToken(s, route.method, route.path),
+				"protected route is mounted without auth middleware")
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	s := &Server{}
+	routes := s.routes()
+
+	t.Run("unique", func(t *testing.T) {
+		seen := make(map[string]bool, len(routes))
+		for _, rt := range routes {
+			key := rt.method + " " + pathParam.ReplaceAllString(rt.pattern, "{}")
+			assert.False(t, seen[key], "route %s %s is registered twice", rt.method, rt.pattern)
+			seen[key] = true
+		}
+	})
+
+	t.Run("access", func(t *testing.T) {
+		for _, rt := range routes {
+			name := rt.method + " " + rt.pattern
+			want := accessUser
+			switch {
+			case publicRoutes[name]:
+				want = accessPublic
+			case adminRoutes[name]:
+				want = accessAdmin
+			}
+			assert.Equal(t, want, rt.access, "route %s has unexpected access", name)
+			assert.NotNil(t, rt.handler, "route %s has no handler", name)
+		}
+	})
+
+	// Documented paths are resolved the way the router would, so a pattern that dispatches
+	// several sub-resources counts as documented when any of them is
+	t.Run("documented", func(t *testing.T) {
+		mux := http.NewServeMux()
+		for _, rt := range routes {
+			mux.HandleFunc(rt.method+" "+rt.pattern, rt.handler)
+		}
+
+		documented := make(map[string]bool, len(routes))
+		for _, route := range documentedRoutes(t) {
+			target := pathParam.ReplaceAllString(route.path, "00000000-0000-0000-0000-000000000000")
+			_, pattern := mux.Handler(httptest.NewRequest(route.method, target, nil))
+			assert.NotEmpty(t, pattern, "documented route %s %s is not mounted", route.method, route.path)
+			documented[pattern] = true
+		}
+		for _, rt := range routes {
+			assert.True(t, documented[rt.method+" "+rt.pattern], "route %s %s has no Swagger annotations", rt.method, rt.pattern)
+		}
+	})
+}
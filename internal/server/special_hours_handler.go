@@ -0,0 +1,392 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// maxSpecialHoursDays caps the date range of a special hours listing
+	maxSpecialHoursDays = 366
+
+	// maxSpecialHoursNoteLength matches the note column of the special_hours table
+	maxSpecialHoursNoteLength = 255
+)
+
+// CreateSpecialHoursRequest sets the opening hours of a single date
+type CreateSpecialHoursRequest struct {
+	Date      string `json:"date"`
+	OpenTime  string `json:"openTime"`
+	CloseTime string `json:"closeTime"`
+	Note      string `json:"note,omitempty"`
+}
+
+// UpdateSpecialHoursRequest changes the fields that are set
+type UpdateSpecialHoursRequest struct {
+	Date      *string `json:"date,omitempty"`
+	OpenTime  *string `json:"openTime,omitempty"`
+	CloseTime *string `json:"closeTime,omitempty"`
+	Note      *string `json:"note,omitempty"`
+}
+
+// @Summary Get special hours
+// @Description List the dates, within a date range, whose opening hours override the weekly business hours.
+// @Description The range defaults to the year starting today
+// @Tags Special hours
+// @Security BearerAuth
+// @Produce json
+// @Param from query string false "First included date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last included date (YYYY-MM-DD), at most 366 days after from"
+// @Success 200 {array} types.SpecialHours
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /special-hours [get]
+func (s *Server) handleGetSpecialHours(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	v := validation.New()
+	from, _ := time.Parse("2006-01-02", s.reservationPolicy.Now().Format("2006-01-02"))
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			v.Add("from", i18n.InvalidDateFormat)
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, maxSpecialHoursDays-1)
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			v.Add("to", i18n.InvalidDateFormat)
+		}
+		to = parsed
+	}
+	if !v.HasErrors() {
+		if to.Before(from) {
+			v.Add("to", i18n.ToDateBeforeFrom)
+		} else if to.Sub(from) > maxSpecialHoursDays*24*time.Hour {
+			v.Add("to", i18n.DateRangeTooLong, maxSpecialHoursDays)
+		}
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	hours, err := s.db.SpecialHoursQ().GetInRange(r.Context(), from, to)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, hours)
+}
+
+// @Summary Set special hours
+// @Description Override the weekly business hours of a date, e.g. New Year's Eve (admin only). Reservations on the date
+// @Description are only accepted between openTime and closeTime, and the slot grid of the date follows them.
+// @Description Existing reservations are kept
+// @Tags Special hours
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateSpecialHoursRequest true "Special hours"
+// @Success 201 {object} types.SpecialHours
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /special-hours [post]
+func (s *Server) handleCreateSpecialHours(w http.ResponseWriter, r *http.Request) {
+	var req CreateSpecialHoursRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	hours := &types.SpecialHours{
+		OpenTime:  strings.TrimSpace(req.OpenTime),
+		CloseTime: strings.TrimSpace(req.CloseTime),
+		Note:      strings.TrimSpace(req.Note),
+	}
+	v := validation.New()
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(req.Date))
+	if strings.TrimSpace(req.Date) == "" {
+		v.Add("date", i18n.DateMissing)
+	} else if err != nil {
+		v.Add("date", i18n.InvalidDateFormat)
+	}
+	hours.Date = date
+	v.Merge(validateSpecialHours(hours).Map())
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if err := s.db.SpecialHoursQ().Create(r.Context(), hours); err != nil {
+		if errors.Is(err, data.ErrConflict) {
+			writeSpecialHoursDateTaken(w, r, hours.Date)
+			return
+		}
+		s.log.WithError(err).Error("failed to create special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionSpecialHoursCreated, specialHoursFields(hours))
+
+	writeJSONResponse(w, http.StatusCreated, hours)
+}
+
+// @Summary Update special hours
+// @Description Change the date, times or note of special hours (admin only)
+// @Tags Special hours
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Special hours ID"
+// @Param body body UpdateSpecialHoursRequest true "Fields to change"
+// @Success 200 {object} types.SpecialHours
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /special-hours/{id} [patch]
+func (s *Server) handleUpdateSpecialHours(w http.ResponseWriter, r *http.Request) {
+	hoursID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid special hours ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidSpecialHoursID, nil)
+		return
+	}
+
+	var req UpdateSpecialHoursRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	hours, err := s.db.SpecialHoursQ().GetByID(r.Context(), hoursID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.SpecialHoursNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	before := specialHoursFields(hours)
+
+	v := validation.New()
+	if req.Date != nil {
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(*req.Date))
+		if err != nil {
+			v.Add("date", i18n.InvalidDateFormat)
+		}
+		hours.Date = date
+	}
+	if req.OpenTime != nil {
+		hours.OpenTime = strings.TrimSpace(*req.OpenTime)
+	}
+	if req.CloseTime != nil {
+		hours.CloseTime = strings.TrimSpace(*req.CloseTime)
+	}
+	if req.Note != nil {
+		hours.Note = strings.TrimSpace(*req.Note)
+	}
+	v.Merge(validateSpecialHours(hours).Map())
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if err := s.db.SpecialHoursQ().Update(r.Context(), hours); err != nil {
+		if errors.Is(err, data.ErrConflict) {
+			writeSpecialHoursDateTaken(w, r, hours.Date)
+			return
+		}
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.SpecialHoursNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to update special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	changes := changedFields(before, specialHoursFields(hours))
+	changes["special_hours_id"] = hours.ID
+	s.logAction(r, actionSpecialHoursUpdated, changes)
+
+	writeJSONResponse(w, http.StatusOK, hours)
+}
+
+// @Summary Delete special hours
+// @Description Remove special hours so that the weekly business hours apply to the date again (admin only)
+// @Tags Special hours
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Special hours ID"
+// @Success 200 {object} DeleteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /special-hours/{id} [delete]
+func (s *Server) handleDeleteSpecialHours(w http.ResponseWriter, r *http.Request) {
+	hoursID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid special hours ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidSpecialHoursID, nil)
+		return
+	}
+
+	if err := s.db.SpecialHoursQ().Delete(r.Context(), hoursID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.SpecialHoursNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to delete special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionSpecialHoursDeleted, logan.F{"special_hours_id": hoursID})
+
+	writeJSONResponse(w, http.StatusOK, DeleteResponse{
+		Message: "Special hours deleted successfully",
+	})
+}
+
+// validateSpecialHours checks the times and note of special hours
+func validateSpecialHours(hours *types.SpecialHours) *validation.Errors {
+	v := validation.New()
+
+	open, err := parseClock(hours.OpenTime)
+	if hours.OpenTime == "" {
+		v.Add("openTime", i18n.OpenTimeMissing)
+	} else if err != nil {
+		v.Add("openTime", i18n.InvalidTimeFormat)
+	}
+	closing, err := parseClock(hours.CloseTime)
+	if hours.CloseTime == "" {
+		v.Add("closeTime", i18n.CloseTimeMissing)
+	} else if err != nil {
+		v.Add("closeTime", i18n.InvalidTimeFormat)
+	}
+	if !v.HasErrors() && closing <= open {
+		v.Add("closeTime", i18n.CloseTimeBeforeOpen)
+	}
+	if len([]rune(hours.Note)) > maxSpecialHoursNoteLength {
+		v.Add("note", i18n.NoteTooLong, maxSpecialHoursNoteLength)
+	}
+
+	return v
+}
+
+// writeSpecialHoursDateTaken responds to special hours set for a date that already has them
+func writeSpecialHoursDateTaken(w http.ResponseWriter, r *http.Request, date time.Time) {
+	writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+		"date": i18n.Msg(i18n.SpecialHoursExist, date.Format("2006-01-02")),
+	})
+}
+
+// specialHoursOn returns the opening hours of the date when they are overridden by special hours,
+// or nil when the weekly business hours apply
+func (s *Server) specialHoursOn(ctx context.Context, date time.Time) (*OpeningHours, error) {
+	hours, err := s.db.SpecialHoursQ().GetByDate(ctx, date.Format("2006-01-02"))
+	if errors.Is(err, data.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return specialOpeningHours(hours)
+}
+
+// specialOpeningHours returns the opening hours set by special hours
+func specialOpeningHours(hours *types.SpecialHours) (*OpeningHours, error) {
+	open, err := parseClock(hours.OpenTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid special opening time %q: %w", hours.OpenTime, err)
+	}
+	closing, err := parseClock(hours.CloseTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid special closing time %q: %w", hours.CloseTime, err)
+	}
+	return &OpeningHours{Open: open, Close: closing}, nil
+}
+
+// validateOpeningHours returns a validation detail when a reservation at the date and time falls outside
+// the special hours of the date or, when it has none, the weekly business hours
+func (s *Server) validateOpeningHours(ctx context.Context, date time.Time, clock string) (i18n.Message, error) {
+	special, err := s.specialHoursOn(ctx, date)
+	if err != nil {
+		return i18n.Message{}, err
+	}
+	if special != nil {
+		return special.ValidateOn(date, clock), nil
+	}
+	return s.reservationPolicy.BusinessHours.Validate(date, clock), nil
+}
+
+// slotGrid returns the slot grid of the date, following its special hours when it has them
+func (s *Server) slotGrid(ctx context.Context, date time.Time) ([]string, error) {
+	special, err := s.specialHoursOn(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if special != nil {
+		return special.Slots(s.reservationPolicy.SlotInterval()), nil
+	}
+	return s.reservationPolicy.BusinessHours.Slots(date, s.reservationPolicy.SlotInterval()), nil
+}
+
+// bookableSlots counts the slots a single table can be booked in from through to, inclusive,
+// following the special hours of the dates that have them and the weekly business hours otherwise
+func (s *Server) bookableSlots(ctx context.Context, from, to time.Time) (int, error) {
+	specials, err := s.db.SpecialHoursQ().GetInRange(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	special := make(map[string]*OpeningHours, len(specials))
+	for _, hours := range specials {
+		opening, err := specialOpeningHours(hours)
+		if err != nil {
+			return 0, err
+		}
+		special[hours.Date.Format("2006-01-02")] = opening
+	}
+
+	interval := s.reservationPolicy.SlotInterval()
+	slots := 0
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if hours, ok := special[date.Format("2006-01-02")]; ok {
+			slots += len(hours.Slots(interval))
+			continue
+		}
+		slots += len(s.reservationPolicy.BusinessHours.Slots(date, interval))
+	}
+	return slots, nil
+}
+
+// specialHoursFields describes special hours in business action logs
+func specialHoursFields(hours *types.SpecialHours) logan.F {
+	return logan.F{
+		"special_hours_id": hours.ID,
+		"date":             hours.Date.Format("2006-01-02"),
+		"open_time":        hours.OpenTime,
+		"close_time":       hours.CloseTime,
+	}
+}
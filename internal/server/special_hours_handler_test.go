@@ -0,0 +1,36 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpecialHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours types.SpecialHours
+		field string
+	}{
+		{"valid", types.SpecialHours{OpenTime: "18:00", CloseTime: "23:30", Note: "New Year's Eve"}, ""},
+		{"missing opening time", types.SpecialHours{CloseTime: "23:30"}, "openTime"},
+		{"invalid opening time", types.SpecialHours{OpenTime: "6pm", CloseTime: "23:30"}, "openTime"},
+		{"missing closing time", types.SpecialHours{OpenTime: "18:00"}, "closeTime"},
+		{"closing before opening", types.SpecialHours{OpenTime: "18:00", CloseTime: "17:00"}, "closeTime"},
+		{"closing at opening", types.SpecialHours{OpenTime: "18:00", CloseTime: "18:00"}, "closeTime"},
+		{"note too long", types.SpecialHours{OpenTime: "18:00", CloseTime: "23:30", Note: strings.Repeat("a", maxSpecialHoursNoteLength+1)}, "note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validateSpecialHours(&tt.hours)
+			if tt.field == "" {
+				assert.False(t, v.HasErrors())
+				return
+			}
+			assert.Contains(t, v.Map(), tt.field)
+		})
+	}
+}
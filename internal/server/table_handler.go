@@ -1,35 +1,235 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
 	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/EduardMikhrin/university-booking-project/internal/validation"
 	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// tablesCacheExpiration bounds how long the full table list is served from the cache
+	tablesCacheExpiration = 10 * time.Minute
+
+	// availableTablesCacheExpiration bounds how stale an available tables listing can get
+	// when an invalidation is missed. Reservation changes invalidate the listings of their date
+	availableTablesCacheExpiration = 2 * time.Minute
+
+	// capacitySummaryCacheExpiration is short so dashboards see availability changes quickly
+	// even when an invalidation is missed
+	capacitySummaryCacheExpiration = 30 * time.Second
+
+	// maxAlternativeTables caps the number of free tables suggested when the requested one is booked
+	maxAlternativeTables = 5
+
+	// maxTableReservationsDays caps the date range of a table's reservation listing
+	maxTableReservationsDays = 92
+
+	// maxBulkAvailabilityUpdateSize limits how many tables can be listed in a single bulk availability request
+	maxBulkAvailabilityUpdateSize = 100
 )
 
 type UpdateTableAvailabilityRequest struct {
 	IsAvailable bool `json:"isAvailable"`
 }
 
+// BulkUpdateTableAvailabilityRequest selects tables either by ID or by location, not both
+type BulkUpdateTableAvailabilityRequest struct {
+	IDs         []string `json:"ids,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	IsAvailable *bool    `json:"isAvailable"`
+}
+
+type UpdateTableStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// TableAvailabilityResponse represents the availability of a table at a specific date and time
+type TableAvailabilityResponse struct {
+	TableNumber string `json:"tableNumber"`
+	Date        string `json:"date"`
+	Time        string `json:"time"`
+	Available   bool   `json:"available"`
+	// Conflict is the slot taken by an active reservation, set only when the table is not available
+	Conflict *ReservationSlot `json:"conflict,omitempty"`
+	// NextAvailableTime is the first later slot of the day at which the table is free,
+	// set only when the table is not available and such a slot exists
+	NextAvailableTime string `json:"nextAvailableTime,omitempty"`
+	// BlockedReason is why the restaurant does not accept reservations on the date, set only when the date is blocked
+	BlockedReason string `json:"blockedReason,omitempty"`
+}
+
+// TimeSlot represents whether a table can be booked at a time slot
+type TimeSlot struct {
+	Time      string `json:"time"`
+	Available bool   `json:"available"`
+}
+
+// TableConflictResponse is returned when a requested table is already booked. Besides the validation
+// details it lists other tables that are free at the same date and time and fit the party
+type TableConflictResponse struct {
+	ErrorResponse
+	AlternativeTables []*types.Table `json:"alternativeTables"`
+}
+
+// ReservationSlot is the date and time a table is booked for
+type ReservationSlot struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
 // @Summary Get all tables
-// @Description Get list of all tables
+// @Description Get list of all tables. The response carries an ETag; sending it back in If-None-Match returns 304 when the list is unchanged
 // @Tags Tables
 // @Security BearerAuth
 // @Produce json
+// @Param location query string false "Filter by location"
+// @Param If-None-Match header string false "ETag of a previously fetched list"
 // @Success 200 {array} types.Table
+// @Success 304 "Not modified"
 // @Failure 500 {object} ErrorResponse
 // @Router /tables [get]
 func (s *Server) handleGetTables(w http.ResponseWriter, r *http.Request) {
-	tables, err := s.db.TableQ().GetAll(r.Context())
+	var (
+		tables []*types.Table
+		err    error
+	)
+	if location := r.URL.Query().Get("location"); location != "" {
+		tables, err = s.db.TableQ().GetByLocation(r.Context(), location)
+	} else {
+		tables, err = s.getAllTables(r.Context())
+	}
 	if err != nil {
 		s.log.WithError(err).Error("failed to get tables")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
-	writeJSONResponse(w, http.StatusOK, tables)
+
+	if err := writeJSONResponseWithETag(w, r, http.StatusOK, tables); err != nil {
+		s.log.WithError(err).Error("failed to encode tables")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+	}
+}
+
+// nextAvailableTime returns the first slot of the date after clock at which the table is free,
+// or an empty string when there is none
+func (s *Server) nextAvailableTime(ctx context.Context, tableNumber string, date time.Time, clock string) (string, error) {
+	booked, err := s.db.ReservationQ().GetBookedTimes(ctx, tableNumber, date.Format("2006-01-02"))
+	if err != nil {
+		return "", err
+	}
+	grid, err := s.slotGrid(ctx, date)
+	if err != nil {
+		return "", err
+	}
+	return nextFreeSlot(grid, booked, clock), nil
+}
+
+// writeTableConflict responds to a request for a table that is already booked at the requested time.
+// The response points the client to the next free slot of the table and to other tables free at that
+// time which fit the party. The tables in exclude are never suggested
+func (s *Server) writeTableConflict(w http.ResponseWriter, r *http.Request, tableNumber string, date time.Time, clock string, guests int, exclude []string) {
+	next, err := s.nextAvailableTime(r.Context(), tableNumber, date, clock)
+	if err != nil {
+		s.log.WithError(err).Error("failed to find next available time")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	available, err := s.db.TableQ().GetAvailable(r.Context(), &types.TableAvailabilityFilters{
+		Date:   &date,
+		Time:   &clock,
+		Guests: &guests,
+	})
+	if err != nil {
+		s.log.WithError(err).Error("failed to get alternative tables")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	details := i18n.Details{
+		"tableNumber": i18n.Msg(i18n.TableBookedNoFreeSlots, tableNumber, clock),
+	}
+	if next != "" {
+		details["tableNumber"] = i18n.Msg(i18n.TableBookedNextFree, tableNumber, clock, next)
+		details["nextAvailableTime"] = i18n.Value(next)
+	}
+
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	writeJSONResponse(w, http.StatusBadRequest, TableConflictResponse{
+		ErrorResponse:     ErrorResponse{Error: i18n.Translate(lang, i18n.ValidationError), Code: codeTableUnavailable, Details: details.Translate(lang)},
+		AlternativeTables: rankAlternativeTables(available, guests, exclude, maxAlternativeTables),
+	})
+}
+
+// rankAlternativeTables orders free tables by how closely their capacity fits the party,
+// then by number, and returns at most limit of them leaving out the excluded tables
+func rankAlternativeTables(tables []*types.Table, guests int, exclude []string, limit int) []*types.Table {
+	ranked := make([]*types.Table, 0, len(tables))
+	for _, table := range tables {
+		if table.Capacity < guests || slices.Contains(exclude, table.Number) {
+			continue
+		}
+		ranked = append(ranked, table)
+	}
+
+	slices.SortStableFunc(ranked, func(a, b *types.Table) int {
+		if a.Capacity != b.Capacity {
+			return a.Capacity - b.Capacity
+		}
+		return strings.Compare(a.Number, b.Number)
+	})
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// getAllTables returns the full table list, reading through the table cache
+func (s *Server) getAllTables(ctx context.Context) ([]*types.Table, error) {
+	if tables, err := s.cache.TableCache().GetAllTables(ctx); err == nil {
+		return tables, nil
+	}
+
+	tables, err := s.db.TableQ().GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.TableCache().SetAllTables(ctx, tables, tablesCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache tables")
+	}
+
+	return tables, nil
+}
+
+// invalidateAvailableTables drops the cached available tables of the given dates.
+// Each date is invalidated once, however often it is given
+func (s *Server) invalidateAvailableTables(ctx context.Context, dates ...time.Time) {
+	seen := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		day := date.Format("2006-01-02")
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		if err := s.cache.TableCache().InvalidateAvailableTables(ctx, day); err != nil {
+			s.log.WithError(err).WithField("date", day).Warn("failed to invalidate available tables cache")
+		}
+	}
 }
 
 // @Summary Get table by ID
@@ -48,19 +248,18 @@ func (s *Server) handleGetTable(w http.ResponseWriter, r *http.Request) {
 	tableID, err := uuid.Parse(tableIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid table ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid table ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidTableID, nil)
 		return
 	}
 
 	table, err := s.db.TableQ().GetByID(r.Context(), tableID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get table")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if table == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Table not found", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -68,14 +267,19 @@ func (s *Server) handleGetTable(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get available tables
-// @Description Get tables available for specified date/time/guests
+// @Description Get tables available for specified date/time/guests. Without date and time the current slot
+// @Description of today is checked, so the list reflects live bookings for walk-ins, and the list is empty after closing time.
+// @Description A time alone applies to today. The list is empty on a blocked date
 // @Tags Tables
 // @Security BearerAuth
 // @Produce json
-// @Param date query string false "Date (YYYY-MM-DD)"
-// @Param time query string false "Time (HH:mm)"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Param time query string false "Time (HH:mm), defaults to the current slot when date is omitted too"
 // @Param guests query int false "Number of guests"
+// @Param maxGuests query int false "Maximum table capacity"
+// @Param location query string false "Location"
 // @Success 200 {array} types.Table
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /tables/available [get]
 func (s *Server) handleGetAvailableTables(w http.ResponseWriter, r *http.Request) {
@@ -95,17 +299,349 @@ func (s *Server) handleGetAvailableTables(w http.ResponseWriter, r *http.Request
 			filters.Guests = &guests
 		}
 	}
+	if maxGuestsStr := r.URL.Query().Get("maxGuests"); maxGuestsStr != "" {
+		var maxGuests int
+		if _, err := fmt.Sscanf(maxGuestsStr, "%d", &maxGuests); err == nil {
+			filters.MaxCapacity = &maxGuests
+		}
+	}
+	if location := r.URL.Query().Get("location"); location != "" {
+		filters.Location = &location
+	}
+
+	// Without an explicit date the request is about right now
+	if r.URL.Query().Get("date") == "" {
+		now := s.reservationPolicy.Now()
+		today, _ := time.Parse("2006-01-02", now.Format("2006-01-02"))
+		filters.Date = &today
+		if filters.Time == nil {
+			grid, err := s.slotGrid(r.Context(), today)
+			if err != nil {
+				s.log.WithError(err).Error("failed to get special hours")
+				writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+				return
+			}
+			slot := currentSlot(grid, s.reservationPolicy.SlotInterval(), now)
+			if slot == "" {
+				// The restaurant has closed for the day, so no table can be taken right now
+				writeJSONResponse(w, http.StatusOK, []*types.Table{})
+				return
+			}
+			filters.Time = &slot
+		}
+	}
+
+	if filters.Guests != nil && filters.MaxCapacity != nil && *filters.Guests > *filters.MaxCapacity {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"maxGuests": i18n.Msg(i18n.MaxGuestsBelowGuests),
+		})
+		return
+	}
+
+	// No table can be booked on a blocked date. The check comes before the cache, which therefore
+	// never holds listings of blocked dates and needs no invalidation when dates are blocked or unblocked
+	reason, err := blockedReason(r.Context(), s.db.BlockedDateQ(), filters.Date.Format("2006-01-02"))
+	if err != nil {
+		s.log.WithError(err).Error("failed to check blocked dates")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	if reason != "" {
+		writeJSONResponse(w, http.StatusOK, []*types.Table{})
+		return
+	}
+
+	// Listings filtered by date, time and guests only, the common case, are cached per date
+	cacheable := filters.MaxCapacity == nil && filters.Location == nil
+	date, clock, guests := filters.Date.Format("2006-01-02"), "", 0
+	if filters.Time != nil {
+		clock = *filters.Time
+	}
+	if filters.Guests != nil {
+		guests = *filters.Guests
+	}
+	if cacheable {
+		if tables, err := s.cache.TableCache().GetAvailableTables(r.Context(), date, clock, guests); err == nil {
+			writeJSONResponse(w, http.StatusOK, tables)
+			return
+		}
+	}
 
 	tables, err := s.db.TableQ().GetAvailable(r.Context(), filters)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get available tables")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
+	if cacheable {
+		if err := s.cache.TableCache().SetAvailableTables(r.Context(), date, clock, guests, tables, availableTablesCacheExpiration); err != nil {
+			s.log.WithError(err).Warn("failed to cache available tables")
+		}
+	}
+
 	writeJSONResponse(w, http.StatusOK, tables)
 }
 
+// @Summary Get capacity summary
+// @Description Get table and seat totals for dashboards (admin only). Available tables are open for booking and in active service
+// @Tags Tables
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} types.CapacitySummary
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/summary [get]
+func (s *Server) handleGetCapacitySummary(w http.ResponseWriter, r *http.Request) {
+	if summary, err := s.cache.TableCache().GetCapacitySummary(r.Context()); err == nil {
+		writeJSONResponse(w, http.StatusOK, summary)
+		return
+	}
+
+	summary, err := s.db.TableQ().GetCapacitySummary(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get capacity summary")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.TableCache().SetCapacitySummary(r.Context(), summary, capacitySummaryCacheExpiration); err != nil {
+		s.log.WithError(err).Warn("failed to cache capacity summary")
+	}
+
+	writeJSONResponse(w, http.StatusOK, summary)
+}
+
+// @Summary Check table availability
+// @Description Check whether a table can be booked at the given date and time. On a blocked date the table is
+// @Description not available and blockedReason tells why
+// @Tags Tables
+// @Security BearerAuth
+// @Produce json
+// @Param number path string true "Table number"
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Param time query string true "Time (HH:mm)"
+// @Success 200 {object} TableAvailabilityResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{number}/availability [get]
+func (s *Server) handleGetTableAvailability(w http.ResponseWriter, r *http.Request) {
+	tableNumber := r.PathValue("number")
+	dateStr := r.URL.Query().Get("date")
+	timeStr := r.URL.Query().Get("time")
+
+	validationErrors := make(i18n.Details)
+	if dateStr == "" {
+		validationErrors["date"] = i18n.Msg(i18n.DateMissing)
+	} else if _, err := time.Parse("2006-01-02", dateStr); err != nil {
+		validationErrors["date"] = i18n.Msg(i18n.InvalidDateFormat)
+	}
+	if timeStr == "" {
+		validationErrors["time"] = i18n.Msg(i18n.TimeMissing)
+	} else if _, err := time.Parse("15:04", timeStr); err != nil {
+		validationErrors["time"] = i18n.Msg(i18n.InvalidTimeFormat)
+	}
+	if len(validationErrors) > 0 {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
+		return
+	}
+
+	if _, err := s.db.TableQ().GetByNumber(r.Context(), tableNumber); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get table")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reason, err := blockedReason(r.Context(), s.db.BlockedDateQ(), dateStr)
+	if err != nil {
+		s.log.WithError(err).Error("failed to check blocked dates")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	if reason != "" {
+		writeJSONResponse(w, http.StatusOK, TableAvailabilityResponse{
+			TableNumber:   tableNumber,
+			Date:          dateStr,
+			Time:          timeStr,
+			BlockedReason: reason,
+		})
+		return
+	}
+
+	available, err := s.db.ReservationQ().CheckTableAvailability(r.Context(), tableNumber, dateStr, timeStr)
+	if err != nil {
+		s.log.WithError(err).Error("failed to check table availability")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	response := TableAvailabilityResponse{
+		TableNumber: tableNumber,
+		Date:        dateStr,
+		Time:        timeStr,
+		Available:   available,
+	}
+	if !available {
+		response.Conflict = &ReservationSlot{Date: dateStr, Time: timeStr}
+
+		date, _ := time.Parse("2006-01-02", dateStr)
+		next, err := s.nextAvailableTime(r.Context(), tableNumber, date, timeStr)
+		if err != nil {
+			s.log.WithError(err).Error("failed to find next available time")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		response.NextAvailableTime = next
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// @Summary Get table time slots
+// @Description List the time slots of a date within business hours, or the special hours of the date when it has them,
+// @Description and whether the table is free at each of them.
+// @Description No slot is available on a blocked date
+// @Tags Tables
+// @Security BearerAuth
+// @Produce json
+// @Param number path string true "Table number"
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Success 200 {array} TimeSlot
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{number}/slots [get]
+func (s *Server) handleGetTableSlots(w http.ResponseWriter, r *http.Request) {
+	tableNumber := r.PathValue("number")
+	dateStr := r.URL.Query().Get("date")
+
+	if dateStr == "" {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"date": i18n.Msg(i18n.DateMissing),
+		})
+		return
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"date": i18n.Msg(i18n.InvalidDateFormat),
+		})
+		return
+	}
+
+	if _, err := s.db.TableQ().GetByNumber(r.Context(), tableNumber); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get table")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reason, err := blockedReason(r.Context(), s.db.BlockedDateQ(), dateStr)
+	if err != nil {
+		s.log.WithError(err).Error("failed to check blocked dates")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	bookedTimes, err := s.db.ReservationQ().GetBookedTimes(r.Context(), tableNumber, dateStr)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get booked times")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	booked := make(map[string]bool, len(bookedTimes))
+	for _, t := range bookedTimes {
+		booked[t] = true
+	}
+
+	grid, err := s.slotGrid(r.Context(), date)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get special hours")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// Every slot of a blocked date is unavailable
+	slots := make([]TimeSlot, 0, len(grid))
+	for _, t := range grid {
+		slots = append(slots, TimeSlot{Time: t, Available: reason == "" && !booked[t]})
+	}
+
+	writeJSONResponse(w, http.StatusOK, slots)
+}
+
+// @Summary Get table reservations
+// @Description List every reservation holding a table, alone or merged with others, within a date range ordered by date and time (admin only)
+// @Tags Tables
+// @Security BearerAuth
+// @Produce json
+// @Param number path string true "Table number"
+// @Param from query string true "First included date (YYYY-MM-DD)"
+// @Param to query string true "Last included date (YYYY-MM-DD), at most 92 days after from"
+// @Success 200 {array} types.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{number}/reservations [get]
+func (s *Server) handleGetTableReservations(w http.ResponseWriter, r *http.Request) {
+	tableNumber := r.PathValue("number")
+	query := r.URL.Query()
+
+	v := validation.New()
+	from, err := time.Parse("2006-01-02", query.Get("from"))
+	if query.Get("from") == "" {
+		v.Add("from", i18n.FromDateMissing)
+	} else if err != nil {
+		v.Add("from", i18n.InvalidDateFormat)
+	}
+	to, err := time.Parse("2006-01-02", query.Get("to"))
+	if query.Get("to") == "" {
+		v.Add("to", i18n.ToDateMissing)
+	} else if err != nil {
+		v.Add("to", i18n.InvalidDateFormat)
+	}
+	if !v.HasErrors() {
+		if to.Before(from) {
+			v.Add("to", i18n.ToDateBeforeFrom)
+		} else if to.Sub(from) > maxTableReservationsDays*24*time.Hour {
+			v.Add("to", i18n.DateRangeTooLong, maxTableReservationsDays)
+		}
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if _, err := s.db.TableQ().GetByNumber(r.Context(), tableNumber); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get table")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	reservations, err := s.db.ReservationQ().GetByTableNumber(r.Context(), tableNumber, from, to)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get table reservations")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, reservations)
+}
+
 // @Summary Update table availability
 // @Description Update availability for a specific table
 // @Tags Tables
@@ -124,45 +660,231 @@ func (s *Server) handleUpdateTableAvailability(w http.ResponseWriter, r *http.Re
 	tableID, err := uuid.Parse(tableIDStr)
 	if err != nil {
 		s.log.WithError(err).Debug("invalid table ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid table ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidTableID, nil)
 		return
 	}
 
 	table, err := s.db.TableQ().GetByID(r.Context(), tableID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
 		s.log.WithError(err).Error("failed to get table")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if table == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Table not found", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	var req UpdateTableAvailabilityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(w, r, &req); err != nil {
 		s.log.WithError(err).Debug("failed to decode request body")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
 	if err := s.db.TableQ().UpdateAvailability(r.Context(), tableID, req.IsAvailable); err != nil {
 		s.log.WithError(err).Error("failed to update table availability")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionTableAvailabilityChanged, logan.F{
+		"table_id":          tableID,
+		"table_number":      table.Number,
+		"from_is_available": table.IsAvailable,
+		"to_is_available":   req.IsAvailable,
+	})
+
+	table, err = s.db.TableQ().GetByID(r.Context(), tableID)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get updated table")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.cache.TableCache().InvalidateTableCache(r.Context()); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate table cache")
+	}
+	s.publishInvalidation(cache.EntityTable, tableID.String())
+
+	writeJSONResponse(w, http.StatusOK, table)
+}
+
+// @Summary Bulk update table availability
+// @Description Open or close several tables at once (admin only), selected either by ID or by location.
+// @Description Changes are all-or-nothing: if any table does not exist, none are updated
+// @Tags Tables
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body BulkUpdateTableAvailabilityRequest true "Table IDs or location and availability"
+// @Success 200 {array} types.Table
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/availability/bulk [patch]
+func (s *Server) handleBulkUpdateTableAvailability(w http.ResponseWriter, r *http.Request) {
+	var req BulkUpdateTableAvailabilityRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	v := validation.New()
+	location := strings.TrimSpace(req.Location)
+	switch {
+	case len(req.IDs) == 0 && location == "":
+		v.Add("ids", i18n.TableIDsOrLocationMissing)
+	case len(req.IDs) > 0 && location != "":
+		v.Add("ids", i18n.TableIDsWithLocation)
+	case len(req.IDs) > maxBulkAvailabilityUpdateSize:
+		v.Add("ids", i18n.BulkTablesTooMany, maxBulkAvailabilityUpdateSize)
+	}
+	tableIDs := make([]uuid.UUID, 0, len(req.IDs))
+	for i, idStr := range req.IDs {
+		tableID, err := uuid.Parse(idStr)
+		if err != nil {
+			v.Add(validation.Path("ids", i), i18n.InvalidTableID)
+			continue
+		}
+		if !slices.Contains(tableIDs, tableID) {
+			tableIDs = append(tableIDs, tableID)
+		}
+	}
+	if req.IsAvailable == nil {
+		v.Add("isAvailable", i18n.AvailabilityMissing)
+	}
+	if v.HasErrors() {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, v.Map())
+		return
+	}
+
+	if location != "" {
+		tables, err := s.db.TableQ().GetByLocation(r.Context(), location)
+		if err != nil {
+			s.log.WithError(err).Error("failed to get tables by location")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		if len(tables) == 0 {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, i18n.Details{"location": i18n.Value(location)})
+			return
+		}
+		for _, table := range tables {
+			tableIDs = append(tableIDs, table.ID)
+		}
+	}
+
+	if err := s.db.TableQ().BulkUpdateAvailability(r.Context(), tableIDs, *req.IsAvailable); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to bulk update table availability")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	// The cached table list is dropped once for the whole batch
+	if err := s.cache.TableCache().InvalidateTableCache(r.Context()); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate table cache")
+	}
+	s.publishInvalidation(cache.EntityTable, "")
+
+	tables := make([]*types.Table, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		table, err := s.db.TableQ().GetByID(r.Context(), tableID)
+		if err != nil {
+			s.log.WithError(err).WithField("table_id", tableID).Error("failed to get updated table")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+		tables = append(tables, table)
+		s.logAction(r, actionTableAvailabilityChanged, logan.F{
+			"table_id":        tableID,
+			"table_number":    table.Number,
+			"to_is_available": *req.IsAvailable,
+			"bulk":            true,
+		})
+	}
+
+	writeJSONResponse(w, http.StatusOK, tables)
+}
+
+// @Summary Update table status
+// @Description Set the service status of a table (admin only). Only active tables are offered as available
+// @Tags Tables
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Table ID"
+// @Param body body UpdateTableStatusRequest true "Status payload (active, maintenance, retired)"
+// @Success 200 {object} types.Table
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{id}/status [patch]
+func (s *Server) handleUpdateTableStatus(w http.ResponseWriter, r *http.Request) {
+	tableIDStr := r.PathValue("id")
+	tableID, err := uuid.Parse(tableIDStr)
+	if err != nil {
+		s.log.WithError(err).Debug("invalid table ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidTableID, nil)
+		return
+	}
+
+	table, err := s.db.TableQ().GetByID(r.Context(), tableID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.TableNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to get table")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	var req UpdateTableStatusRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	switch req.Status {
+	case types.TableStatusActive, types.TableStatusMaintenance, types.TableStatusRetired:
+	default:
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"status": i18n.Msg(i18n.InvalidStatus),
+		})
+		return
+	}
+
+	if err := s.db.TableQ().UpdateStatus(r.Context(), tableID, req.Status); err != nil {
+		s.log.WithError(err).Error("failed to update table status")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
+	s.logAction(r, actionTableStatusChanged, logan.F{
+		"table_id":     tableID,
+		"table_number": table.Number,
+		"from_status":  table.Status,
+		"to_status":    req.Status,
+	})
 
 	table, err = s.db.TableQ().GetByID(r.Context(), tableID)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get updated table")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	if err := s.cache.TableCache().InvalidateTableCache(r.Context()); err != nil {
 		s.log.WithError(err).Warn("failed to invalidate table cache")
 	}
+	s.publishInvalidation(cache.EntityTable, tableID.String())
 
 	writeJSONResponse(w, http.StatusOK, table)
 }
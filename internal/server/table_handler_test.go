@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankAlternativeTables(t *testing.T) {
+	tables := []*types.Table{
+		{Number: "T8", Capacity: 8},
+		{Number: "T4b", Capacity: 4},
+		{Number: "T2", Capacity: 2},
+		{Number: "T4a", Capacity: 4},
+		{Number: "T6", Capacity: 6},
+	}
+
+	numbers := func(tables []*types.Table) []string {
+		result := make([]string, 0, len(tables))
+		for _, table := range tables {
+			result = append(result, table.Number)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		guests  int
+		exclude []string
+		limit   int
+		want    []string
+	}{
+		{name: "closest fit first", guests: 3, limit: 5, want: []string{"T4a", "T4b", "T6", "T8"}},
+		{name: "excluded tables are skipped", guests: 3, exclude: []string{"T4a"}, limit: 5, want: []string{"T4b", "T6", "T8"}},
+		{name: "limited", guests: 1, limit: 2, want: []string{"T2", "T4a"}},
+		{name: "nothing fits", guests: 10, limit: 5, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, numbers(rankAlternativeTables(tables, tt.guests, tt.exclude, tt.limit)))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+)
+
+// restaurantHeader names the restaurant, by slug, a request is served for
+const restaurantHeader = "X-Restaurant"
+
+// Tenancy describes how requests are mapped to restaurants
+type Tenancy struct {
+	// Enabled scopes every API request to the restaurant named by its X-Restaurant header.
+	// Single-restaurant deployments leave it disabled and the header is ignored
+	Enabled bool
+}
+
+// restaurantMiddleware scopes the request to the restaurant named by its X-Restaurant header.
+// Requests without the header are served for the default restaurant that owns all data created
+// before tenancy was enabled
+func (s *Server) restaurantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := r.Header.Get(restaurantHeader)
+		if slug == "" {
+			next.ServeHTTP(w, r.WithContext(tenant.WithRestaurant(r.Context(), types.DefaultRestaurantID)))
+			return
+		}
+
+		restaurant, err := s.db.RestaurantQ().GetBySlug(r.Context(), slug)
+		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.RestaurantNotFound, nil)
+				return
+			}
+			s.log.WithError(err).Error("failed to resolve restaurant")
+			writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(tenant.WithRestaurant(r.Context(), restaurant.ID)))
+	})
+}
+
+// @Summary Get all restaurants
+// @Description Get list of the restaurants served by this deployment. When tenancy is enabled, requests are scoped to a restaurant by sending its slug in the X-Restaurant header
+// @Tags Restaurants
+// @Produce json
+// @Success 200 {array} types.Restaurant
+// @Failure 500 {object} ErrorResponse
+// @Router /restaurants [get]
+func (s *Server) handleGetRestaurants(w http.ResponseWriter, r *http.Request) {
+	restaurants, err := s.db.RestaurantQ().GetAll(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get restaurants")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, restaurants)
+}
@@ -1,18 +1,107 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
 	"github.com/google/uuid"
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
+const (
+	// defaultUsersPageLimit is the page size used when the limit query parameter is missing
+	defaultUsersPageLimit = 20
+	// maxUsersPageLimit caps the page size of the users listing
+	maxUsersPageLimit = 100
+)
+
+type UsersListResponse struct {
+	Users  []*types.User `json:"users"`
+	Total  int           `json:"total"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+}
+
 type UpdateUserRequest struct {
 	Name  *string `json:"name,omitempty"`
 	Phone *string `json:"phone,omitempty"`
 	Email *string `json:"email,omitempty"`
+	// Photo is an http or https URL of the user photo. An empty value restores the default photo
+	Photo *string `json:"photo,omitempty"`
+}
+
+// fieldNames lists the JSON names of the fields present in the request
+func (req UpdateUserRequest) fieldNames() []string {
+	names := make([]string, 0, 4)
+	if req.Name != nil {
+		names = append(names, "name")
+	}
+	if req.Phone != nil {
+		names = append(names, "phone")
+	}
+	if req.Email != nil {
+		names = append(names, "email")
+	}
+	if req.Photo != nil {
+		names = append(names, "photo")
+	}
+	return names
+}
+
+// @Summary Get all users
+// @Description Get a paginated list of users, optionally searched by name or email (admin only)
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of users to skip"
+// @Param search query string false "Search by name or email"
+// @Success 200 {object} UsersListResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users [get]
+func (s *Server) handleGetUsers(w http.ResponseWriter, r *http.Request) {
+	limit := defaultUsersPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxUsersPageLimit)
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o > 0 {
+			offset = o
+		}
+	}
+
+	var search *string
+	if searchStr := strings.TrimSpace(r.URL.Query().Get("search")); searchStr != "" {
+		search = &searchStr
+	}
+
+	users, total, err := s.db.UserQ().GetAll(r.Context(), limit, offset, search)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get users from database")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, UsersListResponse{
+		Users:  users,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
 }
 
 // @Summary Get user by ID
@@ -32,14 +121,14 @@ func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		s.log.WithError(err).WithField("user_id", userIDStr).Debug("invalid user ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
 		return
 	}
 
 	authenticatedUser, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get authenticated user")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -48,20 +137,19 @@ func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
 			"authenticated_user_id": authenticatedUser.ID,
 			"requested_user_id":     userID,
 		}).Debug("unauthorized access attempt")
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
 	user, err := s.db.UserQ().GetByID(r.Context(), userID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			s.log.WithField("user_id", userID).Debug("user not found")
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
 		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user from database")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if user == nil {
-		s.log.WithField("user_id", userID).Debug("user not found")
-		writeErrorResponse(w, http.StatusNotFound, "User not found", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -87,14 +175,14 @@ func (s *Server) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		s.log.WithError(err).WithField("user_id", userIDStr).Debug("invalid user ID format")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID format", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
 		return
 	}
 
 	authenticatedUser, err := GetUserFromContext(r)
 	if err != nil {
 		s.log.WithError(err).Error("failed to get authenticated user")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
@@ -103,73 +191,88 @@ func (s *Server) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 			"authenticated_user_id": authenticatedUser.ID,
 			"requested_user_id":     userID,
 		}).Debug("unauthorized update attempt")
-		writeErrorResponse(w, http.StatusForbidden, "Forbidden", nil)
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
 		return
 	}
 
 	user, err := s.db.UserQ().GetByID(r.Context(), userID)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			s.log.WithField("user_id", userID).Debug("user not found")
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
 		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user from database")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
-		return
-	}
-
-	if user == nil {
-		s.log.WithField("user_id", userID).Debug("user not found")
-		writeErrorResponse(w, http.StatusNotFound, "User not found", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
 
 	var updateReq UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+	if err := decodeJSON(w, r, &updateReq); err != nil {
 		s.log.WithError(err).Debug("failed to decode request body")
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
 		return
 	}
 
-	validationErrors := make(map[string]string)
+	// Only the changed fields are written, so concurrent updates of other fields are kept
+	validationErrors := make(i18n.Details)
+	changes := &types.User{}
 	hasUpdates := false
 
 	if updateReq.Name != nil {
 		name := strings.TrimSpace(*updateReq.Name)
 		if name == "" {
-			validationErrors["name"] = "Name cannot be empty"
+			validationErrors["name"] = i18n.Msg(i18n.NameEmpty)
 		} else {
-			user.Name = name
+			changes.Name = name
 			hasUpdates = true
 		}
 	}
 
 	if updateReq.Phone != nil {
-		phone := strings.TrimSpace(*updateReq.Phone)
-		user.Phone = &phone
-		hasUpdates = true
+		phone := normalizePhone(*updateReq.Phone)
+		if phone != "" && !isValidPhone(phone) {
+			validationErrors["phone"] = i18n.Msg(i18n.InvalidPhoneFormat)
+		} else {
+			changes.Phone = &phone
+			hasUpdates = true
+		}
 	}
 
 	if updateReq.Email != nil {
 		email := strings.TrimSpace(*updateReq.Email)
 		if email == "" {
-			validationErrors["email"] = "Email cannot be empty"
+			validationErrors["email"] = i18n.Msg(i18n.EmailEmpty)
 		} else if !isValidEmail(email) {
-			validationErrors["email"] = "Invalid email format"
+			validationErrors["email"] = i18n.Msg(i18n.InvalidEmailFormat)
 		} else if email != user.Email {
 			existingUser, err := s.db.UserQ().GetByEmail(r.Context(), email)
-			if err != nil {
+			if err != nil && !errors.Is(err, data.ErrNotFound) {
 				s.log.WithError(err).Error("failed to check email existence")
-				writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+				writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 				return
 			}
 			if existingUser != nil && existingUser.ID != userID {
-				validationErrors["email"] = "Email already exists"
+				validationErrors["email"] = i18n.Msg(i18n.EmailTaken)
 			} else {
-				user.Email = email
+				changes.Email = email
 				hasUpdates = true
 			}
 		}
 	}
 
+	if updateReq.Photo != nil {
+		photo := strings.TrimSpace(*updateReq.Photo)
+		if photo != "" && !isValidPhotoURL(photo) {
+			validationErrors["photo"] = i18n.Msg(i18n.PhotoURLInvalid)
+		} else {
+			changes.Photo = &photo
+			hasUpdates = true
+		}
+	}
+
 	if len(validationErrors) > 0 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation error", validationErrors)
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, validationErrors)
 		return
 	}
 
@@ -178,15 +281,147 @@ func (s *Server) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.db.UserQ().Update(r.Context(), userID, user); err != nil {
+	if err := s.db.UserQ().Update(r.Context(), userID, changes); err != nil {
 		s.log.WithError(err).WithField("user_id", userID).Error("failed to update user")
-		writeErrorResponse(w, http.StatusInternalServerError, "Internal server error", nil)
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
 		return
 	}
+	if changes.Name != "" {
+		user.Name = changes.Name
+	}
+	if changes.Email != "" {
+		user.Email = changes.Email
+	}
+	if changes.Phone != nil {
+		user.Phone = changes.Phone
+	}
+	if changes.Photo != nil {
+		user.Photo = changes.Photo
+		types.NormalizeUser(user)
+	}
 
 	if err := s.cache.UserCache().DeleteUser(r.Context(), userID); err != nil {
 		s.log.WithError(err).WithField("user_id", userID).Warn("failed to invalidate user cache")
 	}
+	s.publishInvalidation(cache.EntityUser, userID.String())
+	// Only the names of the changed fields are logged, not the contact details themselves
+	s.logAction(r, actionUserUpdated, logan.F{
+		"target_user_id": userID,
+		"changed_fields": strings.Join(updateReq.fieldNames(), ","),
+	})
 
 	writeJSONResponse(w, http.StatusOK, user)
 }
+
+// @Summary Delete user
+// @Description Delete a user account (only self or admin). Pending and confirmed reservations of the user
+// @Description are cancelled and all reservations are handed over to the deleted user system account
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} DeleteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id} [delete]
+func (s *Server) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.PathValue("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userIDStr).Debug("invalid user ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
+		return
+	}
+
+	authenticatedUser, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get authenticated user")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if authenticatedUser.ID != userID && authenticatedUser.Role != adminRole {
+		s.log.WithFields(logan.F{
+			"authenticated_user_id": authenticatedUser.ID,
+			"requested_user_id":     userID,
+		}).Debug("unauthorized delete attempt")
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	if userID == types.DeletedUserID {
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.SystemAccountUndeletable, nil)
+		return
+	}
+
+	user, err := s.db.UserQ().GetByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			s.log.WithField("user_id", userID).Debug("user not found")
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user from database")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	cancelledDates, err := s.db.UserQ().Delete(r.Context(), userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to delete user")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.revokeUserTokens(r.Context(), userID); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to revoke user tokens")
+	}
+	s.invalidateUserCache(r.Context(), user, cancelledDates)
+	s.logAction(r, actionUserDeleted, logan.F{"target_user_id": userID})
+
+	writeJSONResponse(w, http.StatusOK, DeleteResponse{
+		Message: "User deleted successfully",
+	})
+}
+
+// revokeUserTokens blacklists all active tokens of the user and removes them from the cache
+func (s *Server) revokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	tokens, err := s.cache.TokenCache().ListUserTokens(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list user tokens: %w", err)
+	}
+
+	for _, token := range tokens {
+		if err := s.cache.TokenCache().SetTokenBlacklist(ctx, token, s.jwtConfig.AccessTokenLifetime); err != nil {
+			return fmt.Errorf("failed to blacklist token %s: %w", maskToken(token), err)
+		}
+	}
+
+	if err := s.cache.TokenCache().ClearUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to clear user tokens: %w", err)
+	}
+
+	return nil
+}
+
+// invalidateUserCache purges all cached data of a deleted user. Statistics are invalidated
+// as a whole since the user's reservations were cancelled and reassigned, and the available
+// tables of cancelledDates are dropped since the cancelled reservations freed their tables
+func (s *Server) invalidateUserCache(ctx context.Context, user *types.User, cancelledDates []time.Time) {
+	if err := s.cache.UserCache().DeleteUser(ctx, user.ID); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to invalidate user cache")
+	}
+	s.publishInvalidation(cache.EntityUser, user.ID.String())
+	if err := s.cache.ReservationCache().InvalidateUserReservations(ctx, user.ID); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to invalidate user reservations cache")
+	}
+	if err := s.cache.ReservationCache().InvalidateReservationLists(ctx); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate reservation lists cache")
+	}
+	if err := s.cache.ReportCache().InvalidateAllStats(ctx); err != nil {
+		s.log.WithError(err).Warn("failed to invalidate stats cache")
+	}
+	s.invalidateAvailableTables(ctx, cancelledDates...)
+}
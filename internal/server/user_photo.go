@@ -0,0 +1,189 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// photoFormField is the multipart field carrying the uploaded photo
+	photoFormField = "photo"
+	// maxPhotoSize caps the size of an uploaded photo
+	maxPhotoSize = 5 << 20
+	// maxPhotoBodySize caps photo upload bodies, leaving room for the multipart framing
+	maxPhotoBodySize = maxPhotoSize + 64<<10
+	// maxPhotoURLLength caps the length of a photo URL set directly on a user
+	maxPhotoURLLength = 2048
+	// photosPath is where the service serves photos kept by a store that serves them itself
+	photosPath = "/uploads/"
+)
+
+// photoExtensions maps the accepted photo content types to the extensions photos are stored with
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// @Summary Upload user photo
+// @Description Upload a JPEG, PNG or WebP photo of up to 5 MiB as the user photo (only self or admin)
+// @Tags Users
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "User ID"
+// @Param photo formData file true "Photo"
+// @Success 200 {object} types.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/photo [post]
+func (s *Server) handleUploadUserPhoto(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.PathValue("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userIDStr).Debug("invalid user ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidUserID, nil)
+		return
+	}
+
+	authenticatedUser, err := GetUserFromContext(r)
+	if err != nil {
+		s.log.WithError(err).Error("failed to get authenticated user")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if authenticatedUser.ID != userID && authenticatedUser.Role != adminRole {
+		s.log.WithFields(logan.F{
+			"authenticated_user_id": authenticatedUser.ID,
+			"requested_user_id":     userID,
+		}).Debug("unauthorized photo upload attempt")
+		writeErrorResponse(w, r, http.StatusForbidden, codeForbidden, i18n.Forbidden, nil)
+		return
+	}
+
+	user, err := s.db.UserQ().GetByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			s.log.WithField("user_id", userID).Debug("user not found")
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user from database")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhotoBodySize)
+	file, header, err := r.FormFile(photoFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+				photoFormField: i18n.Msg(i18n.PhotoTooLarge, maxPhotoSize),
+			})
+		case errors.Is(err, http.ErrMissingFile):
+			writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+				photoFormField: i18n.Msg(i18n.PhotoMissing),
+			})
+		default:
+			s.log.WithError(err).Debug("failed to parse multipart form")
+			writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		}
+		return
+	}
+	defer file.Close()
+	defer r.MultipartForm.RemoveAll()
+
+	if header.Size > maxPhotoSize {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			photoFormField: i18n.Msg(i18n.PhotoTooLarge, maxPhotoSize),
+		})
+		return
+	}
+
+	contentType, err := detectPhotoType(file)
+	if err != nil {
+		s.log.WithError(err).Error("failed to read uploaded photo")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	extension, ok := photoExtensions[contentType]
+	if !ok {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			photoFormField: i18n.Msg(i18n.PhotoFormat),
+		})
+		return
+	}
+
+	// Every upload gets a new key, so clients and CDNs never serve a cached previous photo
+	key := fmt.Sprintf("users/%s/%s%s", userID, uuid.New(), extension)
+	photoURL, err := s.photos.Put(r.Context(), key, contentType, file, header.Size)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to store user photo")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	if err := s.db.UserQ().Update(r.Context(), userID, &types.User{Photo: &photoURL}); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.UserNotFound, nil)
+			return
+		}
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to update user photo")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	user.Photo = &photoURL
+
+	if err := s.cache.UserCache().DeleteUser(r.Context(), userID); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to invalidate user cache")
+	}
+	s.publishInvalidation(cache.EntityUser, userID.String())
+	s.logAction(r, actionUserPhotoUpdated, logan.F{
+		"target_user_id": userID,
+		"content_type":   contentType,
+		"size":           header.Size,
+	})
+
+	writeJSONResponse(w, http.StatusOK, user)
+}
+
+// detectPhotoType sniffs the content type of an uploaded file from its first bytes, ignoring
+// the type declared by the client, and rewinds the file
+func detectPhotoType(file io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// isValidPhotoURL checks that a photo URL set directly on a user is an absolute http or https URL
+func isValidPhotoURL(raw string) bool {
+	if len(raw) > maxPhotoURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectPhotoType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "image/png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), "image/jpeg"},
+		{"webp", []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"text declared as image", []byte("<html><body>not an image</body></html>"), "text/html; charset=utf-8"},
+		{"empty", nil, "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := bytes.NewReader(tt.content)
+			contentType, err := detectPhotoType(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, contentType)
+
+			rest, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.content), len(rest), "file is not rewound")
+		})
+	}
+}
+
+func TestIsValidPhotoURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://cdn.example.com/photos/1.jpg", true},
+		{"http://example.com/photo.png", true},
+		{"ftp://example.com/photo.png", false},
+		{"javascript:alert(1)", false},
+		{"/uploads/users/1/photo.png", false},
+		{"https://", false},
+		{"not a url", false},
+		{"https://example.com/" + strings.Repeat("a", maxPhotoURLLength), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isValidPhotoURL(tt.url), tt.url)
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// webhookSecretLength is the number of random bytes of a generated webhook secret
+const webhookSecretLength = 32
+
+type CreateWebhookRequest struct {
+	URL string `json:"url"`
+}
+
+// @Summary Get all webhooks
+// @Description Get list of the registered webhooks (admin only). Secrets are only returned on registration
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} types.Webhook
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [get]
+func (s *Server) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := s.db.WebhookQ().GetAll(r.Context())
+	if err != nil {
+		s.log.WithError(err).Error("failed to get webhooks")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	for _, hook := range webhooks {
+		hook.Secret = ""
+	}
+
+	writeJSONResponse(w, http.StatusOK, webhooks)
+}
+
+// @Summary Register webhook
+// @Description Register an endpoint receiving reservation events (admin only): reservation.created,
+// @Description reservation.status_changed and reservation.deleted. Every delivery is a POST carrying the
+// @Description event type in X-Webhook-Event and "sha256=" followed by the hex HMAC-SHA256 of the body,
+// @Description keyed with the returned secret, in X-Webhook-Signature
+// @Tags Webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateWebhookRequest true "Endpoint URL"
+// @Success 201 {object} types.Webhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [post]
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.log.WithError(err).Debug("failed to decode request body")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidRequestBody, bodyErrorDetails(err))
+		return
+	}
+
+	endpoint := strings.TrimSpace(req.URL)
+	if !isValidWebhookURL(endpoint) {
+		writeErrorResponse(w, r, http.StatusBadRequest, codeValidationFailed, i18n.ValidationError, i18n.Details{
+			"url": i18n.Msg(i18n.WebhookURLInvalid),
+		})
+		return
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		s.log.WithError(err).Error("failed to generate webhook secret")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+
+	hook := &types.Webhook{
+		URL:    endpoint,
+		Secret: secret,
+	}
+	if err := s.db.WebhookQ().Create(r.Context(), hook); err != nil {
+		s.log.WithError(err).Error("failed to create webhook")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	// The signing secret is returned to the caller once and never logged
+	s.logAction(r, actionWebhookCreated, logan.F{"webhook_id": hook.ID})
+
+	writeJSONResponse(w, http.StatusCreated, hook)
+}
+
+// @Summary Delete webhook
+// @Description Stop delivering reservation events to a webhook (admin only)
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} DeleteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.log.WithError(err).Debug("invalid webhook ID format")
+		writeErrorResponse(w, r, http.StatusBadRequest, codeInvalidRequest, i18n.InvalidWebhookID, nil)
+		return
+	}
+
+	if err := s.db.WebhookQ().Delete(r.Context(), webhookID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			writeErrorResponse(w, r, http.StatusNotFound, codeNotFound, i18n.WebhookNotFound, nil)
+			return
+		}
+		s.log.WithError(err).Error("failed to delete webhook")
+		writeErrorResponse(w, r, http.StatusInternalServerError, codeInternal, i18n.InternalError, nil)
+		return
+	}
+	s.logAction(r, actionWebhookDeleted, logan.F{"webhook_id": webhookID})
+
+	writeJSONResponse(w, http.StatusOK, DeleteResponse{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// isValidWebhookURL checks that events can be posted to the URL
+func isValidWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// generateWebhookSecret returns a random hex-encoded key for signing deliveries
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidWebhookURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://pos.example.com/hooks/reservations", want: true},
+		{url: "http://10.0.0.5:8080/webhook", want: true},
+		{url: "ftp://pos.example.com/hooks", want: false},
+		{url: "/hooks/reservations", want: false},
+		{url: "https://", want: false},
+		{url: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, isValidWebhookURL(tt.url))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"github.com/EduardMikhrin/university-booking-project/internal/storage"
+	"github.com/EduardMikhrin/university-booking-project/internal/storage/fs"
+	"github.com/EduardMikhrin/university-booking-project/internal/storage/s3"
+	"github.com/pkg/errors"
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/comfig"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+const (
+	storageConfigKey = "storage"
+
+	driverFS = "fs"
+	driverS3 = "s3"
+
+	defaultDir     = "uploads"
+	defaultBaseURL = "/uploads"
+)
+
+type Storager interface {
+	ObjectStore() storage.ObjectStore
+}
+
+func NewStorager(getter kv.Getter) Storager {
+	return &storager{
+		getter: getter,
+	}
+}
+
+type storager struct {
+	getter kv.Getter
+	once   comfig.Once
+}
+
+type config struct {
+	Driver string `fig:"driver"`
+	// Filesystem store settings
+	Dir     string `fig:"dir"`
+	BaseURL string `fig:"base_url"`
+	// S3 store settings
+	Endpoint  string `fig:"endpoint"`
+	Region    string `fig:"region"`
+	Bucket    string `fig:"bucket"`
+	AccessKey string `fig:"access_key"`
+	SecretKey string `fig:"secret_key"`
+	PublicURL string `fig:"public_url"`
+}
+
+// ObjectStore returns the store uploaded files are kept in. The filesystem store is used unless s3 is configured
+func (s *storager) ObjectStore() storage.ObjectStore {
+	return s.once.Do(func() interface{} {
+		var cfg config
+		if err := figure.Out(&cfg).From(kv.MustGetStringMap(s.getter, storageConfigKey)).Please(); err != nil {
+			panic(errors.Wrap(err, "failed to figure out storage config"))
+		}
+
+		switch cfg.Driver {
+		case "", driverFS:
+			if cfg.Dir == "" {
+				cfg.Dir = defaultDir
+			}
+			if cfg.BaseURL == "" {
+				cfg.BaseURL = defaultBaseURL
+			}
+			return fs.NewStore(fs.Options{Dir: cfg.Dir, BaseURL: cfg.BaseURL})
+		case driverS3:
+			if cfg.Endpoint == "" || cfg.Region == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+				panic(errors.New("storage endpoint, region, bucket, access_key and secret_key are required for s3"))
+			}
+			return s3.NewStore(s3.Options{
+				Endpoint:  cfg.Endpoint,
+				Region:    cfg.Region,
+				Bucket:    cfg.Bucket,
+				AccessKey: cfg.AccessKey,
+				SecretKey: cfg.SecretKey,
+				PublicURL: cfg.PublicURL,
+			})
+		default:
+			panic(errors.Errorf("unknown storage driver %q", cfg.Driver))
+		}
+	}).(storage.ObjectStore)
+}
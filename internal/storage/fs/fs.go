@@ -0,0 +1,86 @@
+package fs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/storage"
+	"github.com/pkg/errors"
+)
+
+// Options holds the filesystem store settings
+type Options struct {
+	// Dir is the directory objects are written to
+	Dir string
+	// BaseURL is the URL prefix objects are served under
+	BaseURL string
+}
+
+// Store implements storage.ObjectStore interface on the local filesystem. It is meant for
+// development; the stored objects are served by the store itself
+type Store struct {
+	opts Options
+}
+
+// NewStore creates a new filesystem Store instance
+func NewStore(opts Options) storage.ObjectStore {
+	return &Store{opts: opts}
+}
+
+// Put writes the object to a temporary file and moves it into place, so that
+// readers never see a partially written object
+func (s *Store) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) (string, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", errors.Wrap(err, "failed to create object directory")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, io.LimitReader(body, size)); err != nil {
+		tmp.Close()
+		return "", errors.Wrap(err, "failed to write object")
+	}
+	if err := tmp.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write object")
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", errors.Wrap(err, "failed to set object permissions")
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", errors.Wrap(err, "failed to move object into place")
+	}
+
+	return strings.TrimRight(s.opts.BaseURL, "/") + "/" + key, nil
+}
+
+// ServeHTTP serves the stored objects by their keys. Directory listings are not served
+func (s *Store) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	http.FileServer(http.Dir(s.opts.Dir)).ServeHTTP(w, r)
+}
+
+// path maps a key to a file inside the store directory
+func (s *Store) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key {
+		return "", errors.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(s.opts.Dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,57 @@
+package fs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorePut(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(Options{Dir: dir, BaseURL: "/uploads/"})
+
+	url, err := store.Put(context.Background(), "users/1/photo.png", "image/png", strings.NewReader("image"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/users/1/photo.png", url)
+
+	content, err := os.ReadFile(filepath.Join(dir, "users", "1", "photo.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "image", string(content))
+
+	_, err = store.Put(context.Background(), "users/1/photo.png", "image/png", strings.NewReader("replaced"), 8)
+	require.NoError(t, err)
+	content, err = os.ReadFile(filepath.Join(dir, "users", "1", "photo.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "replaced", string(content))
+}
+
+func TestStorePutRejectsInvalidKeys(t *testing.T) {
+	store := NewStore(Options{Dir: t.TempDir()})
+
+	for _, key := range []string{"", "../photo.png", "users/../../photo.png", "/photo.png", "users//photo.png"} {
+		_, err := store.Put(context.Background(), key, "image/png", strings.NewReader("image"), 5)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestStoreServeHTTP(t *testing.T) {
+	store := NewStore(Options{Dir: t.TempDir()}).(*Store)
+	_, err := store.Put(context.Background(), "users/1/photo.png", "image/png", strings.NewReader("image"), 5)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1/photo.png", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1/", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
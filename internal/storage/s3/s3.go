@@ -0,0 +1,183 @@
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/storage"
+	"github.com/pkg/errors"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	// unsignedPayload lets the body be streamed instead of hashed up front
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// maxErrorBodySize caps how much of an error response is included in the returned error
+	maxErrorBodySize = 1 << 10
+)
+
+// Options holds the settings of an S3-compatible bucket
+type Options struct {
+	// Endpoint is the base URL of the S3 API, e.g. https://s3.eu-central-1.amazonaws.com
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is the URL prefix objects are served under. Defaults to the bucket URL
+	PublicURL string
+}
+
+// Store implements storage.ObjectStore interface on an S3-compatible bucket.
+// Objects are addressed path-style, which every S3-compatible server supports
+type Store struct {
+	opts   Options
+	client *http.Client
+}
+
+// NewStore creates a new S3 Store instance
+func NewStore(opts Options) storage.ObjectStore {
+	return &Store{opts: opts, client: &http.Client{}}
+}
+
+// Put uploads the object with a signed PUT request
+func (s *Store) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.bucketURL()+"/"+key, io.LimitReader(body, size))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build upload request")
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, unsignedPayload, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload object")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return "", errors.Errorf("failed to upload object: status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	publicURL := s.opts.PublicURL
+	if publicURL == "" {
+		publicURL = s.bucketURL()
+	}
+	return strings.TrimRight(publicURL, "/") + "/" + key, nil
+}
+
+func (s *Store) bucketURL() string {
+	return strings.TrimRight(s.opts.Endpoint, "/") + "/" + s.opts.Bucket
+}
+
+// sign adds an AWS Signature Version 4 authorization header covering the host and every header of req
+func (s *Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.opts.Region)
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.opts.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalURI encodes every segment of the path as SigV4 requires
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but the RFC 3986 unreserved characters
+func uriEncode(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hashHex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
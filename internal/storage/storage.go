@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// ObjectStore defines methods for storing uploaded files
+type ObjectStore interface {
+	// Put stores size bytes read from body under key, replacing an existing object,
+	// and returns the URL the object is served from
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) (string, error)
+}
@@ -0,0 +1,23 @@
+// Package tenant carries the restaurant a request is served for. Data and cache access
+// is scoped to that restaurant; without one it is not scoped at all, which is how
+// single-restaurant deployments run
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// WithRestaurant returns a copy of ctx scoped to the restaurant
+func WithRestaurant(ctx context.Context, restaurantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, restaurantID)
+}
+
+// RestaurantFromContext returns the restaurant ctx is scoped to, if any
+func RestaurantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	restaurantID, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return restaurantID, ok
+}
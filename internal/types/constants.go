@@ -1,6 +1,34 @@
 package types
 
+import "github.com/google/uuid"
+
 // DefaultUserPhoto is the default photo URL for users
 // Source: https://www.flaticon.com/free-icon/user_709699
 const DefaultUserPhoto = "https://cdn-icons-png.flaticon.com/512/709/709699.png"
 
+// Table statuses. Only active tables can be booked
+const (
+	TableStatusActive      = "active"
+	TableStatusMaintenance = "maintenance"
+	TableStatusRetired     = "retired"
+)
+
+// Reservation statuses new reservations are created in
+const (
+	ReservationStatusPending   = "pending"
+	ReservationStatusConfirmed = "confirmed"
+)
+
+// DefaultReservationStatus is the status of new reservations unless they are auto-confirmed
+const DefaultReservationStatus = ReservationStatusPending
+
+// RepeatNoShowThreshold is the number of no-shows after which a user is flagged as a repeat no-show
+const RepeatNoShowThreshold = 3
+
+// DeletedUserID is the ID of the system account that takes over the reservations
+// of deleted users, so that they remain available for reports. It is created by migrations
+var DeletedUserID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// DefaultRestaurantID is the ID of the restaurant that owns the tables and reservations
+// of single-restaurant deployments. It is created by migrations
+var DefaultRestaurantID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
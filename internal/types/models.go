@@ -1,9 +1,11 @@
 package types
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // User represents a user in the system
@@ -18,21 +20,136 @@ type User struct {
 	CreatedAt time.Time `db:"created_at" json:"createdAt"`
 }
 
-// Reservation represents a reservation in the system
+// NormalizeUser fills in the defaults of optional user fields, so that a user looks
+// the same regardless of whether it was read from the database or the cache
+func NormalizeUser(user *User) {
+	if user == nil {
+		return
+	}
+	if user.Photo == nil || *user.Photo == "" {
+		photo := DefaultUserPhoto
+		user.Photo = &photo
+	}
+}
+
+// Reservation represents a reservation in the system. UserID is nil for guest
+// reservations created by staff without a linked account
 type Reservation struct {
-	ID              uuid.UUID `db:"id" json:"id"`
-	UserID          uuid.UUID `db:"user_id" json:"userId"`
-	GuestName       string    `db:"guest_name" json:"guestName"`
-	GuestPhone      string    `db:"guest_phone" json:"guestPhone"`
-	GuestEmail      string    `db:"guest_email" json:"guestEmail"`
-	Date            time.Time `db:"date" json:"date"`
-	Time            string    `db:"time" json:"time"`
-	Guests          int       `db:"guests" json:"guests"`
-	TableNumber     string    `db:"table_number" json:"tableNumber"`
-	Status          string    `db:"status" json:"status"`
-	SpecialRequests *string   `db:"special_requests" json:"specialRequests,omitempty"`
-	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt,omitempty"`
+	ID              uuid.UUID  `db:"id" json:"id"`
+	UserID          *uuid.UUID `db:"user_id" json:"userId"`
+	GuestName       string     `db:"guest_name" json:"guestName"`
+	GuestPhone      string     `db:"guest_phone" json:"guestPhone"`
+	GuestEmail      string     `db:"guest_email" json:"guestEmail"`
+	Date            time.Time  `db:"date" json:"date"`
+	Time            string     `db:"time" json:"time"`
+	Guests          int        `db:"guests" json:"guests"`
+	TableNumber     string     `db:"table_number" json:"tableNumber"`
+	Status          string     `db:"status" json:"status"`
+	SpecialRequests *string    `db:"special_requests" json:"specialRequests,omitempty"`
+	// ConfirmationCode lets guests look up the reservation without an account
+	ConfirmationCode *string    `db:"confirmation_code" json:"confirmationCode,omitempty"`
+	CreatedAt        time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updatedAt,omitempty"`
+	DeletedAt        *time.Time `db:"deleted_at" json:"deletedAt,omitempty"`
+
+	// StaffNotes holds internal notes such as allergies or VIP status. They are only
+	// shown to admins, unlike the guest-facing SpecialRequests
+	StaffNotes *string `db:"staff_notes" json:"staffNotes,omitempty"`
+
+	// RecurrenceGroupID is shared by the occurrences of a recurring booking. It is nil for one-off reservations
+	RecurrenceGroupID *uuid.UUID `db:"recurrence_group_id" json:"recurrenceGroupId,omitempty"`
+
+	// TableNumbers lists every table of a reservation spanning several merged tables.
+	// It is empty for single-table reservations, which only use TableNumber
+	TableNumbers pq.StringArray `db:"table_numbers" json:"tableNumbers,omitempty" swaggertype:"array,string"`
+}
+
+// OwnedBy reports whether the reservation belongs to the given user. Guest reservations belong to no one
+func (r *Reservation) OwnedBy(userID uuid.UUID) bool {
+	return r.UserID != nil && *r.UserID == userID
+}
+
+// ZonedTime combines the calendar day of date with an "HH:MM" or "HH:MM:SS" time of day
+// into a moment in loc. A nil loc stands for the server local timezone
+func ZonedTime(date time.Time, clock string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		t, err = time.Parse("15:04:05", clock)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+		}
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+}
+
+// CompletedReservation identifies a reservation marked as completed in the background,
+// together with the restaurant, owner and date its cached data is kept under
+type CompletedReservation struct {
+	ID           uuid.UUID  `db:"id"`
+	RestaurantID uuid.UUID  `db:"restaurant_id"`
+	UserID       *uuid.UUID `db:"user_id"`
+	Date         time.Time  `db:"date"`
+}
+
+// StatusChange represents a single entry of a reservation's status history
+type StatusChange struct {
+	ID            uuid.UUID  `db:"id" json:"id"`
+	ReservationID uuid.UUID  `db:"reservation_id" json:"reservationId"`
+	FromStatus    string     `db:"from_status" json:"fromStatus"`
+	ToStatus      string     `db:"to_status" json:"toStatus"`
+	ChangedBy     *uuid.UUID `db:"changed_by" json:"changedBy"`
+	ChangedAt     time.Time  `db:"changed_at" json:"changedAt"`
+}
+
+// Feedback is a guest's rating, from 1 to 5, of a completed reservation
+type Feedback struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	ReservationID uuid.UUID `db:"reservation_id" json:"reservationId"`
+	Rating        int       `db:"rating" json:"rating"`
+	Comment       *string   `db:"comment" json:"comment,omitempty"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Restaurant represents a venue served by the system. Tables and reservations belong to a single restaurant
+type Restaurant struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Slug      string    `db:"slug" json:"slug"`
+	Name      string    `db:"name" json:"name"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Webhook is an integrator endpoint receiving reservation lifecycle events.
+// The secret is only returned when the webhook is registered
+type Webhook struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	URL       string    `db:"url" json:"url"`
+	Secret    string    `db:"secret" json:"secret,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// BlockedDate is a range of dates, such as a holiday or a private event, during which
+// the restaurant accepts no reservations. A single blocked date has equal start and end dates
+type BlockedDate struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	StartDate time.Time `db:"start_date" json:"startDate"`
+	EndDate   time.Time `db:"end_date" json:"endDate"`
+	Reason    string    `db:"reason" json:"reason"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// SpecialHours overrides the weekly business hours on a single date, e.g. New Year's Eve.
+// Times are given as HH:MM
+type SpecialHours struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Date      time.Time `db:"date" json:"date"`
+	OpenTime  string    `db:"open_time" json:"openTime"`
+	CloseTime string    `db:"close_time" json:"closeTime"`
+	Note      string    `db:"note" json:"note,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
 }
 
 // Table represents a table in the restaurant
@@ -42,21 +159,45 @@ type Table struct {
 	Capacity    int       `db:"capacity" json:"capacity"`
 	IsAvailable bool      `db:"is_available" json:"isAvailable"`
 	Location    string    `db:"location" json:"location"`
+	Status      string    `db:"status" json:"status"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt,omitempty"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt,omitempty"`
 }
 
+// CapacitySummary aggregates the seating capacity of the restaurant. Available tables are
+// those open for booking and in active service
+type CapacitySummary struct {
+	TotalTables     int `db:"total_tables" json:"totalTables"`
+	TotalSeats      int `db:"total_seats" json:"totalSeats"`
+	AvailableTables int `db:"available_tables" json:"availableTables"`
+	AvailableSeats  int `db:"available_seats" json:"availableSeats"`
+}
+
 // ReservationFilters represents filters for querying reservations
 type ReservationFilters struct {
-	Status *string
-	Date   *time.Time
-	Search *string
+	Status      *string
+	Date        *time.Time
+	Search      *string
+	Sort        *string
+	TableNumber *string
+	MinGuests   *int
+	Guests      *int
+	When        *string
+}
+
+// MonthlyStatsFilters bounds the months returned by the monthly statistics list.
+// From and To are the first days of the first and last included months
+type MonthlyStatsFilters struct {
+	From  *time.Time
+	To    *time.Time
+	Limit int
 }
 
 // TableAvailabilityFilters represents filters for querying available tables
 type TableAvailabilityFilters struct {
-	Date   *time.Time
-	Time   *string
-	Guests *int
+	Date        *time.Time
+	Time        *string
+	Guests      *int
+	MaxCapacity *int
+	Location    *string
 }
-
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // MonthlyStats represents monthly statistics
 type MonthlyStats struct {
 	Month                 string  `json:"month"`
@@ -9,17 +15,43 @@ type MonthlyStats struct {
 	Revenue               float64 `json:"revenue"`
 }
 
-// DetailedMonthlyStats represents detailed monthly statistics
+// YearlyStats represents yearly statistics
+type YearlyStats struct {
+	Year                  string  `json:"year"`
+	TotalReservations     int     `json:"totalReservations"`
+	CompletedReservations int     `json:"completedReservations"`
+	CancelledReservations int     `json:"cancelledReservations"`
+	Revenue               float64 `json:"revenue"`
+}
+
+// WeeklyStats represents statistics for an ISO week
+type WeeklyStats struct {
+	Week                  string  `json:"week"`
+	WeekStart             string  `json:"weekStart"`
+	TotalReservations     int     `json:"totalReservations"`
+	CompletedReservations int     `json:"completedReservations"`
+	CancelledReservations int     `json:"cancelledReservations"`
+	Revenue               float64 `json:"revenue"`
+}
+
+// DetailedMonthlyStats represents detailed monthly statistics. AverageRating is the mean guest
+// rating of the month's reservations and is null when none of them was rated
 type DetailedMonthlyStats struct {
 	MonthlyStats
-	PopularTables []PopularTable `json:"popularTables"`
-	PeakHours     []PeakHour     `json:"peakHours"`
+	AveragePartySize float64        `json:"averagePartySize"`
+	NoShowCount      int            `json:"noShowCount"`
+	AverageRating    *float64       `json:"averageRating"`
+	RatingCount      int            `json:"ratingCount"`
+	PopularTables    []PopularTable `json:"popularTables"`
+	PeakHours        []PeakHour     `json:"peakHours"`
 }
 
-// PopularTable represents a popular table statistic
+// PopularTable represents a popular table statistic. AverageRating is the mean feedback rating
+// of the completed reservations at the table, nil when none of them was rated
 type PopularTable struct {
-	TableNumber string `json:"tableNumber"`
-	Count       int    `json:"count"`
+	TableNumber   string   `json:"tableNumber"`
+	Count         int      `json:"count"`
+	AverageRating *float64 `json:"averageRating"`
 }
 
 // PeakHour represents a peak hour statistic
@@ -28,3 +60,30 @@ type PeakHour struct {
 	Count int    `json:"count"`
 }
 
+// UserStats represents reservation statistics for a single user
+type UserStats struct {
+	UserID                uuid.UUID  `json:"userId"`
+	TotalReservations     int        `json:"totalReservations"`
+	CompletedReservations int        `json:"completedReservations"`
+	CancelledReservations int        `json:"cancelledReservations"`
+	NoShowReservations    int        `json:"noShowReservations"`
+	RepeatNoShow          bool       `json:"repeatNoShow"`
+	LastBookingDate       *time.Time `json:"lastBookingDate"`
+}
+
+// TableOccupancy represents occupancy statistics for a single table over a date range
+type TableOccupancy struct {
+	TableNumber   string  `json:"tableNumber"`
+	Reservations  int     `json:"reservations"`
+	OccupancyRate float64 `json:"occupancyRate"`
+}
+
+// TodaySnapshot represents live reservation counts for the current date
+type TodaySnapshot struct {
+	Date      string `json:"date"`
+	Pending   int    `json:"pending"`
+	Confirmed int    `json:"confirmed"`
+	Completed int    `json:"completed"`
+	Cancelled int    `json:"cancelled"`
+	Covers    int    `json:"covers"`
+}
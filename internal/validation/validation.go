@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+)
+
+// Errors accumulates validation failures keyed by field path
+type Errors struct {
+	fields i18n.Details
+}
+
+// New creates an empty Errors accumulator
+func New() *Errors {
+	return &Errors{fields: make(i18n.Details)}
+}
+
+// Add records a validation failure for the given field with the catalog message for key,
+// whose fmt verbs are filled in with args. Only the first message of each field is kept
+func (e *Errors) Add(field string, key i18n.Key, args ...interface{}) {
+	e.AddMessage(field, i18n.Msg(key, args...))
+}
+
+// AddMessage records a validation failure for the given field, e.g. one returned by a policy check.
+// Only the first message of each field is kept
+func (e *Errors) AddMessage(field string, message i18n.Message) {
+	if _, ok := e.fields[field]; ok {
+		return
+	}
+	e.fields[field] = message
+}
+
+// Merge records every field from details, e.g. the result of a nested validator
+func (e *Errors) Merge(details i18n.Details) {
+	for field, message := range details {
+		e.AddMessage(field, message)
+	}
+}
+
+// HasErrors reports whether any validation failure has been recorded
+func (e *Errors) HasErrors() bool {
+	return len(e.fields) > 0
+}
+
+// Map returns the recorded failures in the shape of ErrorResponse.Details
+func (e *Errors) Map() i18n.Details {
+	details := make(i18n.Details, len(e.fields))
+	for field, message := range e.fields {
+		details[field] = message
+	}
+	return details
+}
+
+// Path joins field path segments: strings are separated by dots and ints render as indexes,
+// so Path("tables", 1, "number") returns "tables[1].number"
+func Path(segments ...interface{}) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		switch v := segment.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", v)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, v)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	v := New()
+	assert.False(t, v.HasErrors())
+	assert.Empty(t, v.Map())
+
+	v.Add("guests", i18n.GuestsNotPositive)
+	v.Add("guests", i18n.InvalidStatus)
+	v.AddMessage("notes", i18n.Msg(i18n.StaffNotesTooLong, 500))
+	v.Merge(i18n.Details{"tableNumbers": i18n.Msg(i18n.CombinedCapacityTooSmall, 4)})
+
+	assert.True(t, v.HasErrors())
+	assert.Equal(t, map[string]string{
+		"guests":       "Number of guests must be greater than 0",
+		"notes":        "Staff notes must not be longer than 500 characters",
+		"tableNumbers": "Combined capacity 4 is less than the number of guests",
+	}, v.Map().Translate(i18n.English))
+	assert.Equal(t, "Кількість гостей має бути більшою за 0", v.Map()["guests"].Translate(i18n.Ukrainian))
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []interface{}
+		want     string
+	}{
+		{name: "single field", segments: []interface{}{"guests"}, want: "guests"},
+		{name: "nested field", segments: []interface{}{"guest", "email"}, want: "guest.email"},
+		{name: "indexed field", segments: []interface{}{"tables", 1, "number"}, want: "tables[1].number"},
+		{name: "empty", segments: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Path(tt.segments...))
+		})
+	}
+}
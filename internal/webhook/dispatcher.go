@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// Config describes how events are queued and delivered
+type Config struct {
+	// QueueSize is how many events may wait for delivery; further events are dropped
+	QueueSize int
+	// Timeout bounds a single delivery attempt
+	Timeout time.Duration
+	// MaxRetries is the number of retries after the first attempt; zero disables retries
+	MaxRetries int
+	// MinBackoff is the delay before the first retry; it doubles with every further retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+// backoff returns the delay before the given retry, counting from zero
+func (c Config) backoff(retry int) time.Duration {
+	delay := c.MinBackoff
+	for i := 0; i < retry && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if c.MaxBackoff > 0 && delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
+}
+
+// Dispatcher queues reservation events and posts them to every registered webhook in the background
+type Dispatcher struct {
+	log      *logan.Entry
+	webhooks data.WebhookQ
+	client   *http.Client
+	config   Config
+	queue    chan Event
+}
+
+// NewDispatcher creates a new Dispatcher instance
+func NewDispatcher(log *logan.Entry, webhooks data.WebhookQ, config Config) *Dispatcher {
+	return &Dispatcher{
+		log:      log,
+		webhooks: webhooks,
+		client:   &http.Client{},
+		config:   config,
+		queue:    make(chan Event, config.QueueSize),
+	}
+}
+
+// Dispatch queues an event about the reservation for delivery to the webhooks of the restaurant
+// ctx is scoped to. It never blocks: when the queue is full the event is dropped and logged
+func (d *Dispatcher) Dispatch(ctx context.Context, eventType string, reservation *types.Reservation) {
+	event := Event{
+		Type:        eventType,
+		OccurredAt:  time.Now().UTC(),
+		Reservation: newReservation(reservation),
+	}
+	if restaurantID, ok := tenant.RestaurantFromContext(ctx); ok {
+		event.restaurantID = &restaurantID
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		d.log.WithFields(logan.F{
+			"event":          eventType,
+			"reservation_id": reservation.ID,
+		}).Warn("webhook queue is full, dropping event")
+	}
+}
+
+// Run delivers queued events until the context is cancelled. Deliveries in progress are
+// abandoned on shutdown and events still queued are lost
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.log.Info("starting webhook dispatcher")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.log.Info("stopping webhook dispatcher")
+			return nil
+		case event := <-d.queue:
+			d.dispatch(ctx, event, &wg)
+		}
+	}
+}
+
+// dispatch starts a delivery of the event to every webhook registered for its restaurant,
+// so that a slow endpoint does not hold up the others
+func (d *Dispatcher) dispatch(ctx context.Context, event Event, wg *sync.WaitGroup) {
+	scoped := ctx
+	if event.restaurantID != nil {
+		scoped = tenant.WithRestaurant(ctx, *event.restaurantID)
+	}
+	webhooks, err := d.webhooks.GetAll(scoped)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.WithError(err).WithField("event", event.Type).Error("failed to get webhooks")
+		}
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.log.WithError(err).WithField("event", event.Type).Error("failed to encode webhook event")
+		return
+	}
+
+	for _, webhook := range webhooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, webhook, event.Type, body)
+		}()
+	}
+}
+
+// deliver posts the event to the webhook, retrying with exponential backoff while it fails
+func (d *Dispatcher) deliver(ctx context.Context, webhook *types.Webhook, eventType string, body []byte) {
+	err := d.send(ctx, webhook, eventType, body)
+	for retry := 0; retry < d.config.MaxRetries && isRetryable(err); retry++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.config.backoff(retry)):
+		}
+		err = d.send(ctx, webhook, eventType, body)
+	}
+
+	if err != nil && ctx.Err() == nil {
+		d.log.WithError(err).WithFields(logan.F{
+			"webhook_id": webhook.ID,
+			"event":      eventType,
+		}).Warn("failed to deliver webhook")
+	}
+}
+
+// statusError is returned for deliveries the endpoint did not accept
+type statusError struct {
+	status int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook endpoint responded with status %d", e.status)
+}
+
+// isRetryable reports whether a failed delivery may succeed when repeated. Endpoints rejecting
+// the request are not retried, unless they are overloaded or failing themselves
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusTooManyRequests || statusErr.status >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// send makes a single signed delivery attempt
+func (d *Dispatcher) send(ctx context.Context, webhook *types.Webhook, eventType string, body []byte) error {
+	if d.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{status: resp.StatusCode}
+	}
+
+	return nil
+}
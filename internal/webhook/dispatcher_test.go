@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// staticWebhooks serves a fixed list of webhooks
+type staticWebhooks []*types.Webhook
+
+func (w staticWebhooks) Create(ctx context.Context, webhook *types.Webhook) error { return nil }
+func (w staticWebhooks) GetAll(ctx context.Context) ([]*types.Webhook, error)    { return w, nil }
+func (w staticWebhooks) Delete(ctx context.Context, id uuid.UUID) error          { return nil }
+
+func newTestDispatcher(url string, config Config) *Dispatcher {
+	webhooks := staticWebhooks{{ID: uuid.New(), URL: url, Secret: "s3cret"}}
+	return NewDispatcher(logan.New().Out(io.Discard), webhooks, config)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"type":"reservation.created"}' | openssl dgst -sha256 -hmac s3cret
+	assert.Equal(t,
+		"sha256=3c5dfe0c768a87faa74fb58f194cd2771e75f21d57e2ecf73b9b282ee3ae8283",
+		Sign("s3cret", []byte(`{"type":"reservation.created"}`)))
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	notes := "VIP, allergic to nuts"
+	code := "ABC123"
+	reservation := &types.Reservation{ID: uuid.New(), GuestName: "John Doe", Status: "pending", StaffNotes: &notes, ConfirmationCode: &code}
+
+	t.Run("signed delivery", func(t *testing.T) {
+		received := make(chan *http.Request, 1)
+		var body []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			received <- r
+		}))
+		defer srv.Close()
+
+		d := newTestDispatcher(srv.URL, Config{QueueSize: 1, Timeout: time.Second})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go d.Run(ctx)
+
+		d.Dispatch(context.Background(), EventReservationCreated, reservation)
+
+		select {
+		case r := <-received:
+			assert.Equal(t, EventReservationCreated, r.Header.Get(EventHeader))
+			assert.Equal(t, Sign("s3cret", body), r.Header.Get(SignatureHeader))
+
+			var event Event
+			require.NoError(t, json.Unmarshal(body, &event))
+			assert.Equal(t, EventReservationCreated, event.Type)
+			assert.Equal(t, reservation.ID, event.Reservation.ID)
+			assert.NotContains(t, string(body), notes)
+			assert.NotContains(t, string(body), code)
+		case <-time.After(5 * time.Second):
+			t.Fatal("webhook was not delivered")
+		}
+	})
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		var attempts atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		d := newTestDispatcher(srv.URL, Config{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+		d.deliver(context.Background(), &types.Webhook{URL: srv.URL}, EventReservationCreated, []byte(`{}`))
+
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("rejected deliveries are not retried", func(t *testing.T) {
+		var attempts atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		d := newTestDispatcher(srv.URL, Config{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+		d.deliver(context.Background(), &types.Webhook{URL: srv.URL}, EventReservationCreated, []byte(`{}`))
+
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+}
+
+// scopedWebhooks records the restaurant webhooks were looked up for
+type scopedWebhooks struct {
+	staticWebhooks
+	restaurants chan uuid.UUID
+}
+
+func (w scopedWebhooks) GetAll(ctx context.Context) ([]*types.Webhook, error) {
+	restaurantID, _ := tenant.RestaurantFromContext(ctx)
+	w.restaurants <- restaurantID
+	return nil, nil
+}
+
+func TestDispatcher_DispatchScopesToRestaurant(t *testing.T) {
+	webhooks := scopedWebhooks{restaurants: make(chan uuid.UUID, 1)}
+	d := NewDispatcher(logan.New().Out(io.Discard), webhooks, Config{QueueSize: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	restaurantID := uuid.New()
+	d.Dispatch(tenant.WithRestaurant(context.Background(), restaurantID), EventReservationCreated, &types.Reservation{ID: uuid.New()})
+
+	select {
+	case got := <-webhooks.restaurants:
+		assert.Equal(t, restaurantID, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhooks were not looked up")
+	}
+}
+
+func TestDispatcher_DispatchDoesNotBlock(t *testing.T) {
+	d := newTestDispatcher("http://127.0.0.1:0", Config{QueueSize: 1})
+	reservation := &types.Reservation{ID: uuid.New()}
+
+	done := make(chan struct{})
+	go func() {
+		d.Dispatch(context.Background(), EventReservationCreated, reservation)
+		// The queue is full and nothing consumes it, so this event is dropped
+		d.Dispatch(context.Background(), EventReservationDeleted, reservation)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Dispatch blocked on a full queue")
+	}
+	assert.Len(t, d.queue, 1)
+}
+
+func TestConfigBackoff(t *testing.T) {
+	config := Config{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, config.backoff(0))
+	assert.Equal(t, 2*time.Second, config.backoff(1))
+	assert.Equal(t, 4*time.Second, config.backoff(2))
+	assert.Equal(t, 5*time.Second, config.backoff(3))
+}
@@ -0,0 +1,84 @@
+// Package webhook delivers reservation lifecycle events to the endpoints registered by integrators
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/types"
+	"github.com/google/uuid"
+)
+
+// Reservation lifecycle events
+const (
+	EventReservationCreated       = "reservation.created"
+	EventReservationStatusChanged = "reservation.status_changed"
+	EventReservationDeleted       = "reservation.deleted"
+)
+
+const (
+	// EventHeader carries the type of the delivered event
+	EventHeader = "X-Webhook-Event"
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the request body,
+	// keyed with the secret of the webhook
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Event is the body of a webhook delivery
+type Event struct {
+	Type        string       `json:"type"`
+	OccurredAt  time.Time    `json:"occurredAt"`
+	Reservation *Reservation `json:"reservation"`
+
+	// restaurantID is the restaurant the event happened at, nil when tenancy is disabled.
+	// Only the webhooks registered for that restaurant receive the event
+	restaurantID *uuid.UUID
+}
+
+// Reservation is the reservation sent to integrators. Staff notes, the confirmation code
+// and the owning account stay internal
+type Reservation struct {
+	ID                uuid.UUID  `json:"id"`
+	GuestName         string     `json:"guestName"`
+	GuestPhone        string     `json:"guestPhone"`
+	GuestEmail        string     `json:"guestEmail"`
+	Date              time.Time  `json:"date"`
+	Time              string     `json:"time"`
+	Guests            int        `json:"guests"`
+	TableNumber       string     `json:"tableNumber"`
+	TableNumbers      []string   `json:"tableNumbers,omitempty"`
+	Status            string     `json:"status"`
+	SpecialRequests   *string    `json:"specialRequests,omitempty"`
+	RecurrenceGroupID *uuid.UUID `json:"recurrenceGroupId,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt,omitempty"`
+}
+
+// newReservation copies the fields integrators may see out of a reservation
+func newReservation(reservation *types.Reservation) *Reservation {
+	return &Reservation{
+		ID:                reservation.ID,
+		GuestName:         reservation.GuestName,
+		GuestPhone:        reservation.GuestPhone,
+		GuestEmail:        reservation.GuestEmail,
+		Date:              reservation.Date,
+		Time:              reservation.Time,
+		Guests:            reservation.Guests,
+		TableNumber:       reservation.TableNumber,
+		TableNumbers:      reservation.TableNumbers,
+		Status:            reservation.Status,
+		SpecialRequests:   reservation.SpecialRequests,
+		RecurrenceGroupID: reservation.RecurrenceGroupID,
+		CreatedAt:         reservation.CreatedAt,
+		UpdatedAt:         reservation.UpdatedAt,
+	}
+}
+
+// Sign returns the signature sent in SignatureHeader for a delivery of body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
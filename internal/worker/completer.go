@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"github.com/EduardMikhrin/university-booking-project/internal/data"
+	"github.com/EduardMikhrin/university-booking-project/internal/tenant"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// Completer periodically marks confirmed reservations whose date and time
+// have already passed as completed
+type Completer struct {
+	log      *logan.Entry
+	db       data.MasterQ
+	cache    cache.CacheQ
+	interval time.Duration
+}
+
+// NewCompleter creates a new Completer instance
+func NewCompleter(log *logan.Entry, db data.MasterQ, cache cache.CacheQ, interval time.Duration) *Completer {
+	return &Completer{
+		log:      log,
+		db:       db,
+		cache:    cache,
+		interval: interval,
+	}
+}
+
+// Run runs the completion loop until the context is cancelled
+func (c *Completer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.log.WithField("interval", c.interval.String()).Info("starting reservation completer")
+
+	for {
+		c.complete(ctx)
+
+		select {
+		case <-ctx.Done():
+			c.log.Info("stopping reservation completer")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Completer) complete(ctx context.Context) {
+	reservations, err := c.db.ReservationQ().MarkPastAsCompleted(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.WithError(err).Error("failed to mark past reservations as completed")
+		}
+		return
+	}
+
+	if len(reservations) == 0 {
+		return
+	}
+
+	c.log.WithField("completed", len(reservations)).Info("marked past reservations as completed")
+
+	// Completed reservations contribute to revenue and user statistics
+	if err := c.cache.ReportCache().InvalidateAllStats(ctx); err != nil {
+		c.log.WithError(err).Warn("failed to invalidate statistics cache")
+	}
+	if err := c.cache.ReservationCache().InvalidateReservationLists(ctx); err != nil {
+		c.log.WithError(err).Warn("failed to invalidate reservation lists cache")
+	}
+
+	days := make(map[string]bool)
+	for _, reservation := range reservations {
+		// Reservations and user listings are cached per restaurant, unlike the patterns above
+		// which match every restaurant when ctx is unscoped
+		scoped := tenant.WithRestaurant(ctx, reservation.RestaurantID)
+		if err := c.cache.ReservationCache().DeleteReservation(scoped, reservation.ID); err != nil {
+			c.log.WithError(err).WithField("reservation_id", reservation.ID).Warn("failed to invalidate reservation cache")
+		}
+		if reservation.UserID != nil {
+			if err := c.cache.ReservationCache().InvalidateUserReservations(scoped, *reservation.UserID); err != nil {
+				c.log.WithError(err).WithField("user_id", *reservation.UserID).Warn("failed to invalidate user reservations cache")
+			}
+		}
+		days[reservation.Date.Format("2006-01-02")] = true
+	}
+
+	// Completed reservations no longer hold their tables
+	for day := range days {
+		if err := c.cache.TableCache().InvalidateAvailableTables(ctx, day); err != nil {
+			c.log.WithError(err).WithField("date", day).Warn("failed to invalidate available tables cache")
+		}
+	}
+}
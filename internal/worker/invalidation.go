@@ -0,0 +1,177 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardMikhrin/university-booking-project/internal/cache"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	// minResubscribeBackoff is the delay before subscribing again after the first failure;
+	// it doubles with every further failure up to maxResubscribeBackoff
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = 30 * time.Second
+
+	// invalidationQueueSize is how many events may wait for publishing; further events are dropped
+	invalidationQueueSize = 1024
+	// invalidationFlushInterval is how long events are collected before they are published together
+	invalidationFlushInterval = 100 * time.Millisecond
+	// maxInvalidationBatch is the number of events that are published without waiting for the interval
+	maxInvalidationBatch = 100
+	// invalidationShutdownTimeout bounds publishing the events still pending on shutdown
+	invalidationShutdownTimeout = time.Second
+)
+
+// InvalidationListener receives the cache invalidation events broadcast by all service instances.
+// Instances keep no in-process cache yet, so events are only logged
+type InvalidationListener struct {
+	log   *logan.Entry
+	cache cache.CacheQ
+}
+
+// NewInvalidationListener creates a new InvalidationListener instance
+func NewInvalidationListener(log *logan.Entry, cache cache.CacheQ) *InvalidationListener {
+	return &InvalidationListener{
+		log:   log,
+		cache: cache,
+	}
+}
+
+// Run listens for invalidation events until the context is cancelled. A lost or failed subscription
+// is logged and retried with exponential backoff rather than returned, since losing the broadcast
+// must not stop the API
+func (l *InvalidationListener) Run(ctx context.Context) error {
+	l.log.Info("starting cache invalidation listener")
+
+	backoff := minResubscribeBackoff
+	for {
+		subscribedAt := time.Now()
+		err := l.cache.SubscribeInvalidations(ctx, l.handle)
+		if ctx.Err() != nil {
+			break
+		}
+
+		// A subscription that held for a while was healthy, so the next failure starts over
+		if time.Since(subscribedAt) > maxResubscribeBackoff {
+			backoff = minResubscribeBackoff
+		}
+		entry := l.log.WithField("retry_in", backoff.String())
+		if err != nil {
+			entry.WithError(err).Error("failed to subscribe to cache invalidations")
+		} else {
+			entry.Warn("cache invalidation subscription closed")
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		backoff *= 2
+		if backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+
+	l.log.Info("stopping cache invalidation listener")
+	return nil
+}
+
+func (l *InvalidationListener) handle(event cache.InvalidationEvent) {
+	l.log.WithFields(logan.F{
+		"entity": event.Entity,
+		"id":     event.ID,
+	}).Debug("received cache invalidation")
+}
+
+// InvalidationPublisher collects the cache invalidation events of this instance and broadcasts
+// them in batches, so that a burst of mutations costs one publish instead of one per mutation
+type InvalidationPublisher struct {
+	log   *logan.Entry
+	cache cache.CacheQ
+	queue chan cache.InvalidationEvent
+}
+
+// NewInvalidationPublisher creates a new InvalidationPublisher instance
+func NewInvalidationPublisher(log *logan.Entry, cacheQ cache.CacheQ) *InvalidationPublisher {
+	return &InvalidationPublisher{
+		log:   log,
+		cache: cacheQ,
+		queue: make(chan cache.InvalidationEvent, invalidationQueueSize),
+	}
+}
+
+// Publish queues an event for the next batch. It never blocks: when the queue is full
+// the event is dropped and logged
+func (p *InvalidationPublisher) Publish(event cache.InvalidationEvent) {
+	select {
+	case p.queue <- event:
+	default:
+		p.log.WithFields(logan.F{
+			"entity": event.Entity,
+			"id":     event.ID,
+		}).Warn("cache invalidation queue is full, dropping event")
+	}
+}
+
+// Run publishes queued events until the context is cancelled. Events are published once
+// the flush interval passes or the batch is full; events still pending on shutdown are published last
+func (p *InvalidationPublisher) Run(ctx context.Context) error {
+	p.log.Info("starting cache invalidation publisher")
+
+	ticker := time.NewTicker(invalidationFlushInterval)
+	defer ticker.Stop()
+
+	var batch []cache.InvalidationEvent
+	seen := make(map[cache.InvalidationEvent]bool)
+	// The same change announced twice in a batch is broadcast once
+	add := func(event cache.InvalidationEvent) {
+		if !seen[event] {
+			seen[event] = true
+			batch = append(batch, event)
+		}
+	}
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.cache.PublishInvalidation(ctx, batch); err != nil {
+			p.log.WithError(err).WithField("events", len(batch)).Warn("failed to publish cache invalidations")
+		}
+		batch = nil
+		seen = make(map[cache.InvalidationEvent]bool)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for drained := false; !drained; {
+				select {
+				case event := <-p.queue:
+					add(event)
+				default:
+					drained = true
+				}
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), invalidationShutdownTimeout)
+			flush(shutdownCtx)
+			cancel()
+			p.log.Info("stopping cache invalidation publisher")
+			return nil
+		case event := <-p.queue:
+			add(event)
+			if len(batch) >= maxInvalidationBatch {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}